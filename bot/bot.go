@@ -8,10 +8,13 @@ import (
 	"log"
 	"math/rand"
 	"net"
+	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/packetflinger/libq2/demo"
 	"github.com/packetflinger/libq2/message"
 
 	pl "github.com/packetflinger/libq2/player"
@@ -22,6 +25,7 @@ const (
 	MoveMask       = 1 << 4
 	MaxMessageSize = 1390
 	LightLevel     = 150
+	UpdateBackup   = 16 // how many old frames to keep for delta compression
 )
 
 var (
@@ -31,11 +35,13 @@ var (
 
 var (
 	commands = map[string]func(*Bot, Cmd){
-		"alias": aliasFunc,
-		"exec":  nullFunc,
-		"quit":  quitFunc,
-		"say":   sayFunc,
-		"set":   setFunc,
+		"alias":     aliasFunc,
+		"changing":  changingFunc,
+		"exec":      nullFunc,
+		"quit":      quitFunc,
+		"reconnect": reconnectFunc,
+		"say":       sayFunc,
+		"set":       setFunc,
 	}
 )
 
@@ -55,6 +61,11 @@ type Bot struct {
 	Aliases    map[string]string
 	CVars      map[string]string
 	Cmds       map[string]func(*Bot, Cmd)
+	RecordDir  string // record a demo of every map here, empty disables
+
+	gamestate *pb.DM2Demo       // serverdata, configstrings and baselines
+	recorder  *demo.DM2Recorder // nil when not recording
+	demoFile  *os.File
 }
 
 type Connection struct {
@@ -133,18 +144,20 @@ func (bot *Bot) ClientCommand(str string, reliable bool) error {
 
 func (bot *Bot) Run() error {
 	bot.Cmds = commands
+	bot.oldframes = make(map[int32]*pb.Frame)
 	recv := make(chan bool)
 	stop := make(chan bool)
 
 	if bot.Netchan.QPort == 0 {
 		bot.Netchan.QPort = rand.Intn(256)
 	}
-	addr := fmt.Sprintf("%s:%d", bot.Net.Address, bot.Net.Port)
+	addr := net.JoinHostPort(bot.Net.Address, strconv.Itoa(bot.Net.Port))
 	c, e := net.Dial("udp4", addr)
 	if e != nil {
 		return e
 	}
 	bot.Net.Conn = c
+	defer bot.StopRecording()
 	bot.Netchan.Sequence1 = 1
 	bot.Netchan.Sequence2 = 0
 	bot.Netchan.ReliableS1 = true
@@ -209,13 +222,18 @@ func (bot *Bot) Run() error {
 			}
 			recv <- true
 
+			raw := bot.Netchan.in.Data[bot.Netchan.in.Index:]
 			packet, err := bot.Netchan.in.ParsePacket(bot.oldframes)
 			if err != nil {
 				return
 			}
+			bot.applyGamestate(packet)
+			bot.record(raw, packet)
 
 			for _, fr := range packet.GetFrames() {
 				bot.FrameNum = int(fr.GetNumber())
+				bot.oldframes[fr.GetNumber()] = fr
+				delete(bot.oldframes, fr.GetNumber()-UpdateBackup)
 				cb, ok := bot.callbacks[message.SVCFrame]
 				if ok {
 					cb(fr, &bot.Netchan.out)
@@ -237,6 +255,11 @@ func (bot *Bot) Run() error {
 					bot.AddClientString("begin %s\n", t[1])
 					bot.Netchan.ReliableS1 = true
 					bot.FrameNum = 1
+					if bot.RecordDir != "" {
+						if err := bot.StartRecording(bot.demoName()); err != nil {
+							log.Println(err)
+						}
+					}
 					cb, ok := bot.callbacks[message.CallbackOnBegin]
 					if ok {
 						cb(nil, &bot.Netchan.out)
@@ -400,7 +423,11 @@ func (b *Bot) BuildUserCommand() message.Buffer {
 	msg := message.NewEmptyBuffer()
 	msg.WriteByte(message.CLCMove)
 	msg.WriteByte(0xa1) // checksum, make up something
-	msg.WriteLong(b.FrameNum)
+	if b.recorder != nil && b.recorder.Waiting() {
+		msg.WriteLong(-1) // ask for an uncompressed frame to start the demo
+	} else {
+		msg.WriteLong(b.FrameNum)
+	}
 	move := pl.UserCommand{
 		LightLevel: 150,
 	}
@@ -489,3 +516,15 @@ func sayFunc(b *Bot, c Cmd) {
 
 func quitFunc(b *Bot, c Cmd) {
 }
+
+// The server is changing maps, stop playing until we're back in the game
+func changingFunc(b *Bot, c Cmd) {
+	b.Spawned = false
+}
+
+// The new map is loaded, start the connection sequence over
+func reconnectFunc(b *Bot, c Cmd) {
+	b.Spawned = false
+	b.AddClientString("new")
+	b.Netchan.ReliableS1 = true
+}
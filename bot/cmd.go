@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"strings"
+)
+
+// A single console command, like the ones stuffed to the bot by the server
+type Cmd struct {
+	commandName string
+	arguments   []string
+}
+
+// The argument at index, the command itself isn't counted. Missing arguments
+// are empty like in the client.
+func (c Cmd) Argv(index int) string {
+	if index < 0 || index >= len(c.arguments) {
+		return ""
+	}
+	return c.arguments[index]
+}
+
+// How many arguments the command has
+func (c Cmd) Argc() int {
+	return len(c.arguments)
+}
+
+// Private member accessor
+func (c Cmd) GetCommand() string {
+	return c.commandName
+}
+
+// The command and all its arguments
+func (c Cmd) GetFullCommand() string {
+	return strings.TrimSpace(c.commandName + " " + strings.Join(c.arguments, " "))
+}
+
+// Split a line of text into commands. Commands are separated by semicolons
+// or newlines, arguments by whitespace. Double quotes group words into a
+// single argument and protect semicolons.
+func ParseCmd(s string) []Cmd {
+	var cmds []Cmd
+	var tokens []string
+	var tok strings.Builder
+	quoted, inToken := false, false
+
+	endToken := func() {
+		if inToken {
+			tokens = append(tokens, tok.String())
+			tok.Reset()
+			inToken = false
+		}
+	}
+	endCmd := func() {
+		endToken()
+		if len(tokens) > 0 {
+			cmds = append(cmds, Cmd{commandName: tokens[0], arguments: tokens[1:]})
+		}
+		tokens = nil
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if quoted {
+				tokens = append(tokens, tok.String())
+				tok.Reset()
+				inToken = false
+			} else {
+				endToken()
+			}
+			quoted = !quoted
+		case quoted && r != '\n':
+			tok.WriteRune(r)
+		case r == ';' || r == '\n':
+			quoted = false
+			endCmd()
+		case r == ' ' || r == '\t' || r == '\r':
+			endToken()
+		default:
+			tok.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		inToken = true
+	}
+	endCmd()
+	return cmds
+}
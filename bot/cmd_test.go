@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string // full commands
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: nil,
+		},
+		{
+			name: "single command",
+			in:   "set version v1\n",
+			want: []string{"set version v1"},
+		},
+		{
+			name: "multiple commands",
+			in:   "changing;reconnect\nsay hi",
+			want: []string{"changing", "reconnect", "say hi"},
+		},
+		{
+			name: "quoted semicolon",
+			in:   `alias +hook "cmd hook; wait"; say "hello there"`,
+			want: []string{"alias +hook cmd hook; wait", "say hello there"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, c := range ParseCmd(tc.in) {
+				got = append(got, c.GetFullCommand())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseCmd(%q) mismatch (-want +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
+
+func TestCmdArgv(t *testing.T) {
+	c := ParseCmd(`set name "some guy"`)[0]
+	if c.GetCommand() != "set" {
+		t.Errorf("GetCommand() = %q, want %q", c.GetCommand(), "set")
+	}
+	if c.Argv(0) != "name" || c.Argv(1) != "some guy" || c.Argv(2) != "" {
+		t.Errorf("Argv() = %q %q %q", c.Argv(0), c.Argv(1), c.Argv(2))
+	}
+	if c.Argc() != 2 {
+		t.Errorf("Argc() = %d, want 2", c.Argc())
+	}
+}
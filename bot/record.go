@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Keep track of everything needed to make a demo header. Serverdata means a
+// new map, so any demo being recorded is finished.
+func (b *Bot) applyGamestate(packet *pb.Packet) {
+	if sd := packet.GetServerData(); sd != nil {
+		if b.Recording() {
+			if err := b.StopRecording(); err != nil {
+				log.Println(err)
+			}
+		}
+		b.gamestate = &pb.DM2Demo{
+			Serverinfo:    sd,
+			Configstrings: make(map[int32]*pb.ConfigString),
+			Baselines:     make(map[int32]*pb.PackedEntity),
+		}
+		b.oldframes = make(map[int32]*pb.Frame)
+	}
+	if b.gamestate == nil {
+		return
+	}
+	for _, s := range packet.GetSettings() {
+		if s.GetIndex() == message.SettingFPS {
+			b.gamestate.FrameRate = s.GetValue()
+		}
+	}
+	for _, cs := range packet.GetConfigStrings() {
+		b.gamestate.Configstrings[int32(cs.GetIndex())] = cs
+	}
+	for _, bl := range packet.GetBaselines() {
+		b.gamestate.Baselines[int32(bl.GetNumber())] = bl
+	}
+}
+
+// Add a received packet to the demo being recorded
+func (b *Bot) record(data []byte, packet *pb.Packet) {
+	if b.recorder == nil {
+		return
+	}
+	if err := b.recorder.WritePacket(data, packet); err != nil {
+		log.Println(err)
+		b.StopRecording()
+	}
+}
+
+// Is a demo being recorded right now?
+func (b *Bot) Recording() bool {
+	return b.recorder != nil
+}
+
+// Start recording everything received from the server into a .dm2 file,
+// like the `record` command in the client. The bot has to be in the game.
+// Any demo already being recorded is finished first.
+func (b *Bot) StartRecording(filename string) error {
+	if b.gamestate == nil || !b.Spawned {
+		return fmt.Errorf("can't record, not in the game yet")
+	}
+	if err := b.StopRecording(); err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating demo: %v", err)
+	}
+	rec, err := demo.NewDM2Recorder(f, b.gamestate)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	b.demoFile = f
+	b.recorder = rec
+	log.Println("recording demo to", filename)
+	return nil
+}
+
+// Finish the demo being recorded, if any
+func (b *Bot) StopRecording() error {
+	if b.recorder == nil {
+		return nil
+	}
+	err := b.recorder.Close()
+	if cerr := b.demoFile.Close(); err == nil {
+		err = cerr
+	}
+	log.Printf("stopped recording %s (%d packets)\n", b.demoFile.Name(), b.recorder.Packets())
+	b.recorder = nil
+	b.demoFile = nil
+	return err
+}
+
+// A filename in RecordDir for the current map, like
+// "q2dm1-20240102-150405.dm2"
+func (b *Bot) demoName() string {
+	mapname := "demo"
+	if cs, ok := b.gamestate.GetConfigstrings()[message.CSMapname]; ok {
+		base := filepath.Base(cs.GetData())
+		if name := strings.TrimSuffix(base, filepath.Ext(base)); name != "" && name != "." {
+			mapname = name
+		}
+	}
+	name := fmt.Sprintf("%s-%s.dm2", mapname, time.Now().Format("20060102-150405"))
+	return filepath.Join(b.RecordDir, name)
+}
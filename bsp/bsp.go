@@ -137,9 +137,7 @@ func (bsp *BSPFile) ParseLumpData() error {
 		}
 
 		bsp.LumpData[i] = BSPLumpData{
-			Data: m.Buffer{
-				Data: data,
-			},
+			Data: m.NewBuffer(data),
 		}
 	}
 	return nil
@@ -1,9 +1,15 @@
 package bsp
 
-import "github.com/packetflinger/libq2/types"
+import (
+	"math"
+
+	m "github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/types"
+)
 
 type Vertex types.Vector3
 
+// Vertices are stored as floats, they're truncated to whole map units.
 func (bsp *BSPFile) FetchVertices() []Vertex {
 	verts := []Vertex{}
 	msg := &bsp.LumpData[VerticesLump].Data
@@ -11,10 +17,29 @@ func (bsp *BSPFile) FetchVertices() []Vertex {
 	quantity := len(bsp.LumpData[VerticesLump].Data.Data) / 12
 	for range quantity {
 		verts = append(verts, Vertex{
-			X: msg.ReadLong(),
-			Y: msg.ReadLong(),
-			Z: msg.ReadLong(),
+			X: readFloat(msg),
+			Y: readFloat(msg),
+			Z: readFloat(msg),
 		})
 	}
 	return verts
 }
+
+// The smallest box containing all the vertices, the extent of the map.
+func Bounds(verts []Vertex) (Vertex, Vertex) {
+	if len(verts) == 0 {
+		return Vertex{}, Vertex{}
+	}
+	mins, maxs := verts[0], verts[0]
+	for _, v := range verts[1:] {
+		mins.X, maxs.X = min(mins.X, v.X), max(maxs.X, v.X)
+		mins.Y, maxs.Y = min(mins.Y, v.Y), max(maxs.Y, v.Y)
+		mins.Z, maxs.Z = min(mins.Z, v.Z), max(maxs.Z, v.Z)
+	}
+	return mins, maxs
+}
+
+// Read a 32 bit float as a whole number
+func readFloat(msg *m.Buffer) int {
+	return int(math.Float32frombits(uint32(msg.ReadLong())))
+}
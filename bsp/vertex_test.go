@@ -15,4 +15,44 @@ func TestFetchVertices(t *testing.T) {
 	if len(v) != 1054 {
 		t.Errorf("Wrong plane count, want 402, have %d\n", len(v))
 	}
+	mins, maxs := Bounds(v)
+	if want := (Vertex{X: -392, Y: -528, Z: -48}); mins != want {
+		t.Errorf("mins = %v, want %v", mins, want)
+	}
+	if want := (Vertex{X: 520, Y: 576, Z: 640}); maxs != want {
+		t.Errorf("maxs = %v, want %v", maxs, want)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name  string
+		verts []Vertex
+		mins  Vertex
+		maxs  Vertex
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "single",
+			verts: []Vertex{{X: 1, Y: 2, Z: 3}},
+			mins:  Vertex{X: 1, Y: 2, Z: 3},
+			maxs:  Vertex{X: 1, Y: 2, Z: 3},
+		},
+		{
+			name:  "box",
+			verts: []Vertex{{X: -64, Y: 128, Z: 0}, {X: 512, Y: -32, Z: 8}, {X: 0, Y: 0, Z: -16}},
+			mins:  Vertex{X: -64, Y: -32, Z: -16},
+			maxs:  Vertex{X: 512, Y: 128, Z: 8},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mins, maxs := Bounds(tc.verts)
+			if mins != tc.mins || maxs != tc.maxs {
+				t.Errorf("Bounds() = %v, %v, want %v, %v", mins, maxs, tc.mins, tc.maxs)
+			}
+		})
+	}
 }
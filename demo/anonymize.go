@@ -0,0 +1,284 @@
+package demo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+)
+
+const (
+	DefaultNameFmt  = "player%d"
+	DefaultIPMask   = "0.0.0.0"
+	DefaultChatText = "[removed]"
+)
+
+var ipPattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+
+// An Anonymizer rewrites the identifying bits of a demo in place: player
+// names (in skin configstrings, prints, centerprints and layouts), chat, IP
+// addresses and the skins players picked in their userinfo. The edited demo
+// can then be marshalled again as normal.
+//
+// Names are found in the player skin configstrings and are given generated
+// replacements in the order they're found, so the same player has the same
+// replacement everywhere in a demo (and across demos when reusing the same
+// Anonymizer). Names are only replaced where they're whole words, and never
+// in stufftext since that's commands for the client, only IPs are masked
+// there.
+type Anonymizer struct {
+	Names    map[string]string // original -> replacement
+	NameFmt  string            // for generated names, takes a counter
+	Skin     string            // replacement model/skin, empty keeps skins
+	Chat     string            // replacement chat text (ex: DefaultChatText)
+	DropChat bool              // remove chat prints entirely
+	IPMask   string            // replacement for IPv4 addresses
+	sorted   []string          // names longest first, rebuilt as names are added
+}
+
+// Create an anonymizer that replaces names and IPs but leaves chat and skins
+// alone.
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{
+		Names:   make(map[string]string),
+		NameFmt: DefaultNameFmt,
+		IPMask:  DefaultIPMask,
+	}
+}
+
+// The replacement for a player name, generating a new one if needed
+func (a *Anonymizer) Name(name string) string {
+	if name == "" {
+		return ""
+	}
+	if r, ok := a.Names[name]; ok {
+		return r
+	}
+	for _, r := range a.Names {
+		if r == name {
+			return name // already replaced
+		}
+	}
+	r := fmt.Sprintf(a.NameFmt, len(a.Names)+1)
+	a.Names[name] = r
+	return r
+}
+
+// Replace all known names and IPs in a string. Names only match whole words
+// ("bob" isn't replaced in "bobcat") and longer names are tried first so a
+// name that contains another name is handled properly.
+func (a *Anonymizer) Text(s string) string {
+	if len(a.sorted) != len(a.Names) {
+		a.sorted = a.sorted[:0]
+		for n := range a.Names {
+			a.sorted = append(a.sorted, n)
+		}
+		slices.SortFunc(a.sorted, func(x, y string) int {
+			if len(x) != len(y) {
+				return len(y) - len(x)
+			}
+			return strings.Compare(x, y)
+		})
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		matched := false
+		if i == 0 || !wordChar(s[i-1]) {
+			for _, n := range a.sorted {
+				end := i + len(n)
+				if strings.HasPrefix(s[i:], n) && (end == len(s) || !wordChar(s[end])) {
+					out.WriteString(a.Names[n])
+					i = end
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			out.WriteByte(s[i])
+			i++
+		}
+	}
+	return a.maskIPs(out.String())
+}
+
+// Replace IP addresses in a string
+func (a *Anonymizer) maskIPs(s string) string {
+	if a.IPMask == "" {
+		return s
+	}
+	return ipPattern.ReplaceAllString(s, a.IPMask)
+}
+
+// Letters, digits and underscores make up words
+func wordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Rewrite a player skin configstring ("name\model/skin")
+func (a *Anonymizer) playerSkin(data string) string {
+	name, skin, found := strings.Cut(data, "\\")
+	if !found {
+		return a.Name(name)
+	}
+	if a.Skin != "" {
+		skin = a.Skin
+	}
+	return a.Name(name) + "\\" + skin
+}
+
+// Rewrite a configstring. Only player skins have names in them, the rest
+// (models, sounds, general text, etc) are left alone.
+func (a *Anonymizer) configString(cs *pb.ConfigString, remap *pb.MvdConfigStringRemap) {
+	idx := int32(cs.GetIndex())
+	if idx >= remap.GetPlayerSkins() && idx < remap.GetPlayerSkins()+MaxClients {
+		cs.Data = a.playerSkin(cs.GetData())
+	}
+}
+
+// Find all the player names in some configstrings before anything is
+// rewritten so names show up in text before their configstrings are seen.
+func (a *Anonymizer) collect(configs map[int32]*pb.ConfigString, remap *pb.MvdConfigStringRemap) {
+	for _, k := range util.SortedKeys(configs) {
+		if k >= remap.GetPlayerSkins() && k < remap.GetPlayerSkins()+MaxClients {
+			name, _, _ := strings.Cut(configs[k].GetData(), "\\")
+			a.Name(name)
+		}
+	}
+}
+
+// Rewrite prints, returning the ones that should be kept
+func (a *Anonymizer) prints(prints []*pb.Print) []*pb.Print {
+	var out []*pb.Print
+	for _, pr := range prints {
+		if pr.GetLevel() == message.PrintLevelChat {
+			if a.DropChat {
+				continue
+			}
+			if a.Chat != "" {
+				// chat is "name: text", keep who said it
+				if who, _, found := strings.Cut(pr.GetData(), ": "); found {
+					pr.Data = who + ": " + a.Chat + "\n"
+				}
+			}
+		}
+		pr.Data = a.Text(pr.GetData())
+		out = append(out, pr)
+	}
+	return out
+}
+
+// Rewrite the names and IPs in centerprints ("You fragged claire")
+func (a *Anonymizer) centerprints(cps []*pb.CenterPrint) {
+	for _, cp := range cps {
+		cp.Data = a.Text(cp.GetData())
+	}
+}
+
+// Layouts are drawing commands, but the names in them are what the
+// scoreboard shows
+func (a *Anonymizer) layouts(los []*pb.Layout) {
+	for _, lo := range los {
+		lo.Data = a.Text(lo.GetData())
+	}
+}
+
+// Mask IPs in stuffed text, names are left alone so commands still work
+func (a *Anonymizer) stuffs(stuffs []*pb.StuffText) {
+	for _, st := range stuffs {
+		st.Data = a.maskIPs(st.GetData())
+	}
+}
+
+// Anonymize a regular demo in place
+func (a *Anonymizer) DM2(dm2 *pb.DM2Demo) {
+	a.collect(dm2.GetConfigstrings(), csRemap)
+	for _, k := range util.SortedKeys(dm2.GetFrames()) {
+		a.collect(dm2.GetFrames()[k].GetConfigstrings(), csRemap)
+	}
+	for _, cs := range dm2.GetConfigstrings() {
+		a.configString(cs, csRemap)
+	}
+	for _, fr := range dm2.GetFrames() {
+		for _, cs := range fr.GetConfigstrings() {
+			a.configString(cs, csRemap)
+		}
+		fr.Prints = a.prints(fr.GetPrints())
+		a.centerprints(fr.GetCenterprints())
+		a.layouts(fr.GetLayouts())
+		a.stuffs(fr.GetStufftexts())
+	}
+}
+
+// Anonymize a multi-view demo in place
+func (a *Anonymizer) MVD2(mvd *pb.MvdDemo) {
+	remap := mvd.GetRemap()
+	if remap == nil {
+		remap = csRemap
+	}
+	// names first, the remap can change with each serverdata
+	r := remap
+	for _, packet := range mvd.GetPackets() {
+		if sd := packet.GetServerdata(); sd != nil && sd.GetRemap() != nil {
+			r = sd.GetRemap()
+		}
+		a.collect(packet.GetConfigstrings(), r)
+	}
+	for _, cs := range mvd.GetConfigstrings() {
+		a.configString(cs, remap)
+	}
+	for _, pl := range mvd.GetPlayers() {
+		pl.Name = a.Name(pl.GetName())
+	}
+
+	r = remap
+	for _, packet := range mvd.GetPackets() {
+		if sd := packet.GetServerdata(); sd != nil && sd.GetRemap() != nil {
+			r = sd.GetRemap()
+		}
+		for _, cs := range packet.GetConfigstrings() {
+			a.configString(cs, r)
+		}
+		packet.Prints = a.prints(packet.GetPrints())
+		a.stuffs(packet.GetStuffs())
+		for _, uc := range packet.GetUnicasts() {
+			for _, cs := range uc.GetConfigstrings() {
+				a.configString(cs, r)
+			}
+			uc.Prints = a.prints(uc.GetPrints())
+			a.centerprints(uc.GetCenterprints())
+			a.layouts(uc.GetLayouts())
+			a.stuffs(uc.GetStuffs())
+		}
+		for _, mc := range packet.GetMulticasts() {
+			for _, cs := range mc.GetConfigstrings() {
+				a.configString(cs, r)
+			}
+			mc.Prints = a.prints(mc.GetPrints())
+			a.centerprints(mc.GetCenterprints())
+			a.layouts(mc.GetLayouts())
+			a.stuffs(mc.GetStuffs())
+		}
+	}
+}
+
+// Anonymize the parsed demo and marshal it back into a playable binary demo.
+func (p *DM2Parser) Anonymize(a *Anonymizer) ([]byte, error) {
+	a.DM2(p.textProto)
+	return p.Marshal()
+}
+
+// Anonymize a parsed multi-view demo and marshal it back into a playable
+// binary demo.
+func AnonymizeMVD2(mvd *pb.MvdDemo, a *Anonymizer) ([]byte, error) {
+	a.MVD2(mvd)
+	writer := NewMVD2Writer(&pb.MvdDemo{Packets: mvd.GetPackets()})
+	if err := writer.Marshal(); err != nil {
+		return nil, err
+	}
+	return writer.GetData(), nil
+}
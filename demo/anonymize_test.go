@@ -0,0 +1,318 @@
+package demo
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+func TestAnonymizerText(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		in    string
+		want  string
+	}{
+		{
+			name:  "obituary",
+			names: []string{"claire", "shloo"},
+			in:    "claire was railed by shloo\n",
+			want:  "player1 was railed by player2\n",
+		},
+		{
+			name:  "name containing another",
+			names: []string{"bob", "bobby"},
+			in:    "bobby ate bob's rocket",
+			want:  "player2 ate player1's rocket",
+		},
+		{
+			name:  "whole words only",
+			names: []string{"bob", "ann"},
+			in:    "bobcat: planning a [bob] ann_x\n",
+			want:  "bobcat: planning a [player1] ann_x\n",
+		},
+		{
+			name: "ip address",
+			in:   "connect 192.168.1.20:27910\n",
+			want: "connect 0.0.0.0:27910\n",
+		},
+		{
+			name:  "nothing to replace",
+			names: []string{"claire"},
+			in:    "precache\n",
+			want:  "precache\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := NewAnonymizer()
+			for _, n := range tc.names {
+				a.Name(n)
+			}
+			if got := a.Text(tc.in); got != tc.want {
+				t.Errorf("Text(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnonymizerPrints(t *testing.T) {
+	tests := []struct {
+		name  string
+		level uint32
+		in    string
+		want  string
+	}{
+		{
+			name:  "obituary",
+			level: message.PrintLevelObit,
+			in:    "claire ate shloo's rocket\n",
+			want:  "player1 ate player2's rocket\n",
+		},
+		{
+			name:  "chat",
+			level: message.PrintLevelChat,
+			in:    "shloo: gg claire\n",
+			want:  "player2: gg player1\n",
+		},
+		{
+			name:  "server message",
+			level: message.PrintLevelHigh,
+			in:    "claire connected from 10.0.0.1\n",
+			want:  "player1 connected from 0.0.0.0\n",
+		},
+		{
+			name:  "entered the game",
+			level: message.PrintLevelHigh,
+			in:    "claire entered the game\n",
+			want:  "player1 entered the game\n",
+		},
+		{
+			name:  "name change",
+			level: message.PrintLevelHigh,
+			in:    "shloo changed name to claire\n",
+			want:  "player2 changed name to player1\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := NewAnonymizer()
+			a.Name("claire")
+			a.Name("shloo")
+			got := a.prints([]*pb.Print{{Level: tc.level, Data: tc.in}})
+			if len(got) != 1 || got[0].GetData() != tc.want {
+				t.Errorf("prints(%q) = %v, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+// Stufftext only has IPs masked since it's commands for the client, names
+// are replaced in what's drawn on the screen.
+func TestAnonymizerFrame(t *testing.T) {
+	a := NewAnonymizer()
+	a.Name("claire")
+	fr := &pb.Frame{
+		Stufftexts:   []*pb.StuffText{{Data: "alias claire \"connect 10.0.0.1\"\n"}},
+		Layouts:      []*pb.Layout{{Data: "xv 0 string claire"}},
+		Centerprints: []*pb.CenterPrint{{Data: "You fragged claire\n"}},
+	}
+	a.DM2(&pb.DM2Demo{Frames: map[int32]*pb.Frame{1: fr}})
+	if want := "alias claire \"connect 0.0.0.0\"\n"; fr.GetStufftexts()[0].GetData() != want {
+		t.Errorf("stufftext = %q, want %q", fr.GetStufftexts()[0].GetData(), want)
+	}
+	if want := "xv 0 string player1"; fr.GetLayouts()[0].GetData() != want {
+		t.Errorf("layout = %q, want %q", fr.GetLayouts()[0].GetData(), want)
+	}
+	if want := "You fragged player1\n"; fr.GetCenterprints()[0].GetData() != want {
+		t.Errorf("centerprint = %q, want %q", fr.GetCenterprints()[0].GetData(), want)
+	}
+}
+
+// Multicasts carry the same messages as unicasts
+func TestAnonymizerMulticast(t *testing.T) {
+	a := NewAnonymizer()
+	a.Name("claire")
+	mc := &pb.MvdMulticast{
+		Prints:       []*pb.Print{{Level: message.PrintLevelObit, Data: "claire was railed by bob\n"}},
+		Stuffs:       []*pb.StuffText{{Data: "connect 10.0.0.1\n"}},
+		Centerprints: []*pb.CenterPrint{{Data: "claire wins!\n"}},
+		Layouts:      []*pb.Layout{{Data: "xv 0 string claire"}},
+	}
+	uc := &pb.MvdUnicast{
+		Centerprints: []*pb.CenterPrint{{Data: "You fragged claire\n"}},
+		Layouts:      []*pb.Layout{{Data: "xv 0 string2 claire"}},
+	}
+	a.MVD2(&pb.MvdDemo{Packets: []*pb.MvdPacket{{
+		Multicasts: []*pb.MvdMulticast{mc},
+		Unicasts:   []*pb.MvdUnicast{uc},
+	}}})
+	if want := "player1 was railed by bob\n"; mc.GetPrints()[0].GetData() != want {
+		t.Errorf("multicast print = %q, want %q", mc.GetPrints()[0].GetData(), want)
+	}
+	if want := "player1 wins!\n"; mc.GetCenterprints()[0].GetData() != want {
+		t.Errorf("multicast centerprint = %q, want %q", mc.GetCenterprints()[0].GetData(), want)
+	}
+	if want := "xv 0 string player1"; mc.GetLayouts()[0].GetData() != want {
+		t.Errorf("multicast layout = %q, want %q", mc.GetLayouts()[0].GetData(), want)
+	}
+	if want := "You fragged player1\n"; uc.GetCenterprints()[0].GetData() != want {
+		t.Errorf("unicast centerprint = %q, want %q", uc.GetCenterprints()[0].GetData(), want)
+	}
+	if want := "xv 0 string2 player1"; uc.GetLayouts()[0].GetData() != want {
+		t.Errorf("unicast layout = %q, want %q", uc.GetLayouts()[0].GetData(), want)
+	}
+	if want := "connect 0.0.0.0\n"; mc.GetStuffs()[0].GetData() != want {
+		t.Errorf("multicast stuff = %q, want %q", mc.GetStuffs()[0].GetData(), want)
+	}
+}
+
+func TestAnonymizerSkin(t *testing.T) {
+	a := NewAnonymizer()
+	a.Skin = "male/grunt"
+	cs := &pb.ConfigString{Index: 1312 + 2, Data: "claire\\female/athena"}
+	a.configString(cs, csRemap)
+	if want := "player1\\male/grunt"; cs.GetData() != want {
+		t.Errorf("skin configstring = %q, want %q", cs.GetData(), want)
+	}
+	// running again shouldn't rename the replacement
+	a.configString(cs, csRemap)
+	if want := "player1\\male/grunt"; cs.GetData() != want {
+		t.Errorf("skin configstring = %q, want %q", cs.GetData(), want)
+	}
+}
+
+func TestDM2Anonymize(t *testing.T) {
+	content, err := os.ReadFile("../testdata/testduel.dm2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := NewDM2Parser()
+	if err := parser.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	frames := len(parser.GetTextProto().GetFrames())
+	a := NewAnonymizer()
+	a.DropChat = true
+	data, err := parser.Anonymize(a)
+	if err != nil {
+		t.Fatalf("Anonymize() error: %v", err)
+	}
+
+	out := NewDM2Parser()
+	if err := out.Unmarshal(data); err != nil {
+		t.Fatalf("error parsing anonymized demo: %v", err)
+	}
+	textpb := out.GetTextProto()
+	if got := len(textpb.GetFrames()); got != frames {
+		t.Errorf("frame count = %d, want %d", got, frames)
+	}
+	var text []string
+	for k, cs := range textpb.GetConfigstrings() {
+		if k >= csRemap.GetPlayerSkins() && k < csRemap.GetPlayerSkins()+MaxClients {
+			text = append(text, cs.GetData())
+		}
+	}
+	for _, fr := range textpb.GetFrames() {
+		for _, pr := range fr.GetPrints() {
+			if pr.GetLevel() == message.PrintLevelChat {
+				t.Errorf("chat not dropped: %q", pr.GetData())
+			}
+			text = append(text, pr.GetData())
+		}
+		for _, cp := range fr.GetCenterprints() {
+			text = append(text, cp.GetData())
+		}
+		for _, lo := range fr.GetLayouts() {
+			text = append(text, lo.GetData())
+		}
+	}
+	all := strings.Join(text, "\n")
+	for _, name := range []string{"claire", "shloo"} {
+		if strings.Contains(all, name) {
+			t.Errorf("%q still in anonymized demo", name)
+		}
+	}
+	if !strings.Contains(all, a.Names["claire"]) {
+		t.Errorf("replacement %q not in anonymized demo", a.Names["claire"])
+	}
+}
+
+func TestMVD2Anonymize(t *testing.T) {
+	parser, err := NewMVD2Parser("../testdata/test.mvd2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demos, err := parser.Unmarshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	packets := len(demos[0].GetPackets())
+	a := NewAnonymizer()
+	a.Chat = DefaultChatText
+	data, err := AnonymizeMVD2(demos[0], a)
+	if err != nil {
+		t.Fatalf("AnonymizeMVD2() error: %v", err)
+	}
+
+	stream, err := NewMVD2Stream(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("error reading anonymized demo: %v", err)
+	}
+	count := 0
+	for {
+		packet, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("packet %d: %v", count, err)
+		}
+		count++
+		for _, uc := range packet.GetUnicasts() {
+			for _, pr := range uc.GetPrints() {
+				if strings.Contains(pr.GetData(), "claire") {
+					t.Errorf("name in unicast print: %q", pr.GetData())
+				}
+			}
+			for _, cp := range uc.GetCenterprints() {
+				if strings.Contains(cp.GetData(), "claire") {
+					t.Errorf("name in centerprint: %q", cp.GetData())
+				}
+			}
+			for _, lo := range uc.GetLayouts() {
+				if strings.Contains(lo.GetData(), "claire") {
+					t.Errorf("name in layout: %q", lo.GetData())
+				}
+			}
+		}
+		for _, pr := range packet.GetPrints() {
+			if strings.Contains(pr.GetData(), "claire") {
+				t.Errorf("name in print: %q", pr.GetData())
+			}
+			said := strings.Contains(pr.GetData(), ": ")
+			if pr.GetLevel() == message.PrintLevelChat && said && !strings.HasSuffix(pr.GetData(), ": "+DefaultChatText+"\n") {
+				t.Errorf("chat not replaced: %q", pr.GetData())
+			}
+		}
+		for k, cs := range packet.GetConfigstrings() {
+			skin := k >= csRemap.GetPlayerSkins() && k < csRemap.GetPlayerSkins()+MaxClients
+			if skin && strings.Contains(cs.GetData(), "claire") {
+				t.Errorf("name in configstring %d: %q", cs.GetIndex(), cs.GetData())
+			}
+		}
+	}
+	if count != packets {
+		t.Errorf("packet count = %d, want %d", count, packets)
+	}
+	for _, pl := range stream.GetState().GetPlayers() {
+		if pl.GetName() == "claire" {
+			t.Error("player name not replaced")
+		}
+	}
+}
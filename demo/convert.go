@@ -0,0 +1,212 @@
+package demo
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+)
+
+// Convert a multi-view demo into a regular demo from the point of view of a
+// single player, like it was recorded by that player's client. Use a negative
+// client to follow the first player in the demo that isn't the dummy.
+//
+// Every frame in the result is uncompressed (full playerstate and all
+// entities). Configstrings are moved to the original (protocol 34) layout,
+// any that don't fit are dropped.
+func MVD2ToDM2(mvd *pb.MvdDemo, client int32) (*pb.DM2Demo, error) {
+	var sd *pb.MvdServerData
+	for _, packet := range mvd.GetPackets() {
+		if sd = packet.GetServerdata(); sd != nil {
+			break
+		}
+	}
+	if sd == nil {
+		return nil, fmt.Errorf("no serverdata in demo")
+	}
+	remap := sd.GetRemap()
+	if remap == nil {
+		remap = csRemap
+	}
+	if client < 0 {
+		client = firstPlayer(mvd, sd.GetDummyClient())
+		if client < 0 {
+			return nil, fmt.Errorf("no players in demo")
+		}
+	}
+
+	dm2 := &pb.DM2Demo{
+		Serverinfo: &pb.ServerInfo{
+			Protocol:     34,
+			ServerCount:  uint32(sd.GetIdentity()),
+			Demo:         true,
+			GameDir:      sd.GetGameDirectory(),
+			ClientNumber: uint32(client),
+		},
+		Baselines:     make(map[int32]*pb.PackedEntity),
+		Configstrings: make(map[int32]*pb.ConfigString),
+		Frames:        make(map[int32]*pb.Frame),
+	}
+	for _, cs := range mvd.GetPackets()[0].GetConfigstrings() {
+		if c, ok := remapConfigString(cs, remap, csRemap); ok {
+			dm2.Configstrings[int32(c.GetIndex())] = c
+		}
+	}
+	dm2.Serverinfo.MapName = dm2.GetConfigstrings()[0].GetData()
+
+	players := make(map[int32]*pb.PackedPlayer)
+	entities := make(map[int32]*pb.PackedEntity)
+	pending := &pb.Frame{} // messages waiting for the next frame
+	number := int32(0)
+	for i, packet := range mvd.GetPackets() {
+		if i > 0 {
+			for _, k := range util.SortedKeys(packet.GetConfigstrings()) {
+				if c, ok := remapConfigString(packet.GetConfigstrings()[k], remap, csRemap); ok {
+					if pending.Configstrings == nil {
+						pending.Configstrings = make(map[int32]*pb.ConfigString)
+					}
+					pending.Configstrings[int32(c.GetIndex())] = c
+				}
+			}
+		}
+		pending.Prints = append(pending.Prints, packet.GetPrints()...)
+		pending.Sounds = append(pending.Sounds, packet.GetSounds()...)
+		pending.Stufftexts = append(pending.Stufftexts, packet.GetStuffs()...)
+		for _, uc := range packet.GetUnicasts() {
+			if uc.GetClientNumber() != client {
+				continue
+			}
+			pending.Layouts = append(pending.Layouts, uc.GetLayouts()...)
+			pending.Prints = append(pending.Prints, uc.GetPrints()...)
+			pending.Centerprints = append(pending.Centerprints, uc.GetCenterprints()...)
+			pending.Sounds = append(pending.Sounds, uc.GetSounds()...)
+			pending.TemporaryEntities = append(pending.TemporaryEntities, uc.GetTempEnts()...)
+			pending.Flashes1 = append(pending.Flashes1, uc.GetMuzzleFlashes()...)
+			pending.Flashes2 = append(pending.Flashes2, uc.GetMuzzleFlashes2()...)
+			for _, cs := range uc.GetConfigstrings() {
+				if c, ok := remapConfigString(cs, remap, csRemap); ok {
+					if pending.Configstrings == nil {
+						pending.Configstrings = make(map[int32]*pb.ConfigString)
+					}
+					pending.Configstrings[int32(c.GetIndex())] = c
+				}
+			}
+		}
+		for _, mc := range packet.GetMulticasts() {
+			multicastMessages(pending, mc)
+		}
+
+		for _, fr := range packet.GetFrames() {
+			for num, ps := range fr.GetPlayers() {
+				players[num] = ps
+			}
+			for _, num := range fr.GetRemovedPlayers() {
+				delete(players, num)
+			}
+			for num, ent := range fr.GetEntities() {
+				if ent.GetRemove() {
+					delete(entities, num)
+					continue
+				}
+				entities[num] = ent
+			}
+			number++
+			out := pending
+			pending = &pb.Frame{}
+			out.Number = number
+			out.Delta = -1
+			out.ServerTime = number * (1000 / DefaultFrameRate)
+			out.Sounds = append(out.Sounds, fr.GetSounds()...)
+			if ps, ok := players[client]; ok {
+				out.PlayerState = proto.Clone(ps).(*pb.PackedPlayer)
+			}
+			out.Entities = make(map[int32]*pb.PackedEntity)
+			for num, ent := range entities {
+				out.Entities[num] = proto.Clone(ent).(*pb.PackedEntity)
+			}
+			dm2.Frames[number] = out
+		}
+	}
+	return dm2, nil
+}
+
+// The lowest numbered player, ignoring the dummy. -1 if there are none.
+func firstPlayer(mvd *pb.MvdDemo, dummy int32) int32 {
+	first := int32(-1)
+	for _, packet := range mvd.GetPackets() {
+		for _, fr := range packet.GetFrames() {
+			for num := range fr.GetPlayers() {
+				if num != dummy && (first < 0 || num < first) {
+					first = num
+				}
+			}
+		}
+		if first >= 0 {
+			return first
+		}
+	}
+	return first
+}
+
+// Move a configstring from one layout to another. The first few (name, sky,
+// statusbar, etc) have the same index in every layout, the rest are offsets
+// into their range. Anything past the end of a range in the new layout
+// doesn't fit.
+func remapConfigString(cs *pb.ConfigString, from, to *pb.MvdConfigStringRemap) (*pb.ConfigString, bool) {
+	idx := int32(cs.GetIndex())
+	var out int32
+	ranges := []struct{ from, to, size int32 }{
+		{from.GetModels(), to.GetModels(), to.GetMaxModels()},
+		{from.GetSounds(), to.GetSounds(), to.GetMaxSounds()},
+		{from.GetImages(), to.GetImages(), to.GetMaxImages()},
+		{from.GetLights(), to.GetLights(), to.GetItems() - to.GetLights()},
+		{from.GetItems(), to.GetItems(), to.GetPlayerSkins() - to.GetItems()},
+		{from.GetPlayerSkins(), to.GetPlayerSkins(), to.GetGeneral() - to.GetPlayerSkins()},
+		{from.GetGeneral(), to.GetGeneral(), to.GetEnd() - to.GetGeneral()},
+	}
+	switch {
+	case idx == from.GetAirAccel():
+		out = to.GetAirAccel()
+	case idx == from.GetMaxClients():
+		out = to.GetMaxClients()
+	case idx == from.GetMapChecksum():
+		out = to.GetMapChecksum()
+	case idx < from.GetAirAccel():
+		if idx >= to.GetAirAccel() {
+			return nil, false
+		}
+		out = idx
+	default:
+		out = -1
+		for n, r := range ranges {
+			end := from.GetEnd()
+			if n+1 < len(ranges) {
+				end = ranges[n+1].from
+			}
+			if idx >= r.from && idx < end {
+				if idx-r.from < r.size {
+					out = r.to + idx - r.from
+				}
+				break
+			}
+		}
+	}
+	if out < 0 {
+		return nil, false
+	}
+	return &pb.ConfigString{Index: uint32(out), Data: cs.GetData()}, true
+}
+
+// Multicasts are regular server messages passed through. Anything the parser
+// couldn't decode is lost.
+func multicastMessages(fr *pb.Frame, mc *pb.MvdMulticast) {
+	fr.Flashes1 = append(fr.Flashes1, mc.GetMuzzleFlashes()...)
+	fr.Flashes2 = append(fr.Flashes2, mc.GetMuzzleFlashes2()...)
+	fr.TemporaryEntities = append(fr.TemporaryEntities, mc.GetTempEnts()...)
+	fr.Sounds = append(fr.Sounds, mc.GetSounds()...)
+	fr.Prints = append(fr.Prints, mc.GetPrints()...)
+	fr.Centerprints = append(fr.Centerprints, mc.GetCenterprints()...)
+	fr.Layouts = append(fr.Layouts, mc.GetLayouts()...)
+}
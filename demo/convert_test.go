@@ -0,0 +1,88 @@
+package demo
+
+import (
+	"testing"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+func TestMVD2ToDM2(t *testing.T) {
+	parser, err := NewMVD2Parser("../testdata/test.mvd2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demos, err := parser.Unmarshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mvd := demos[0]
+	frames := 0
+	for _, packet := range mvd.GetPackets() {
+		frames += len(packet.GetFrames())
+	}
+
+	dm2, err := MVD2ToDM2(mvd, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(dm2.GetFrames()); got != frames {
+		t.Errorf("MVD2ToDM2() made %d frames, want %d", got, frames)
+	}
+	client := int32(dm2.GetServerinfo().GetClientNumber())
+	if client == mvd.GetPackets()[0].GetServerdata().GetDummyClient() {
+		t.Errorf("MVD2ToDM2() followed the dummy client %d", client)
+	}
+	if name := dm2.GetConfigstrings()[csRemap.GetPlayerSkins()+client].GetData(); name == "" {
+		t.Errorf("MVD2ToDM2() missing skin configstring for client %d", client)
+	}
+	last := dm2.GetFrames()[int32(frames)]
+	if last.GetPlayerState() == nil || len(last.GetEntities()) == 0 {
+		t.Errorf("last frame missing state: %d entities, playerstate %v", len(last.GetEntities()), last.GetPlayerState())
+	}
+	if errs := VerifyDM2(dm2); len(errs) > 0 {
+		t.Errorf("VerifyDM2() = %v", errs)
+	}
+
+	// it should be playable
+	p := NewDM2Parser()
+	p.textProto = dm2
+	data, err := p.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewDM2Parser()
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(got.GetTextProto().GetFrames()); n != frames {
+		t.Errorf("re-parsed %d frames, want %d", n, frames)
+	}
+}
+
+func TestRemapConfigString(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     uint32
+		want   uint32
+		wantOK bool
+	}{
+		{name: "map name", in: 0, want: 0, wantOK: true},
+		{name: "statusbar", in: 5, want: 5, wantOK: true},
+		{name: "maxclients", in: 60, want: 30, wantOK: true},
+		{name: "first model", in: 62, want: 32, wantOK: true},
+		{name: "model out of range", in: 62 + 300, wantOK: false},
+		{name: "player skin", in: 12862 + 3, want: 1312 + 3, wantOK: true},
+		{name: "general", in: 13118 + 10, want: 1568 + 10, wantOK: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := remapConfigString(&pb.ConfigString{Index: tc.in, Data: "x"}, csRemapNew, csRemap)
+			if ok != tc.wantOK {
+				t.Fatalf("remapConfigString(%d) ok = %v, want %v", tc.in, ok, tc.wantOK)
+			}
+			if ok && got.GetIndex() != tc.want {
+				t.Errorf("remapConfigString(%d) = %d, want %d", tc.in, got.GetIndex(), tc.want)
+			}
+		})
+	}
+}
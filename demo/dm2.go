@@ -38,7 +38,10 @@ type DM2Parser struct {
 	currentFrame   int32             // index of frames map
 	callbacks      map[int]func(any) // index is svc_msg type
 	frameCount     int               // how many frames in total
-	fps            int               // only supports 10
+	fps            int               // server frame rate in hz
+	frameTime      int32             // server time of the current frame (msecs)
+	rateFrame      int32             // frame number when the rate last changed
+	rateTime       int32             // server time when the rate last changed
 }
 
 // Create a new DM2 parser to hold a parsed demo.
@@ -46,7 +49,7 @@ func NewDM2Parser() *DM2Parser {
 	return &DM2Parser{
 		callbacks:  make(map[int]func(any)),
 		frameCount: 0,
-		fps:        10,
+		fps:        DefaultFrameRate,
 		textProto: &pb.DM2Demo{
 			Baselines:     make(map[int32]*pb.PackedEntity),
 			Configstrings: make(map[int32]*pb.ConfigString),
@@ -86,6 +89,21 @@ func (d *DM2Parser) ApplyPacket(packet *pb.Packet) error {
 	if sd := packet.GetServerData(); sd != nil {
 		d.textProto.Serverinfo = sd
 	}
+	settings := packet.GetSettings()
+	if len(settings) > 0 {
+		for _, s := range settings {
+			if s.GetIndex() == message.SettingFPS && s.GetValue() > 0 {
+				d.rateFrame, d.rateTime = d.currentFrame, d.frameTime
+				d.fps = int(s.GetValue())
+				d.textProto.FrameRate = s.GetValue()
+			}
+		}
+		if cbFunc, found := d.callbacks[message.SVCSetting]; found {
+			for _, s := range settings {
+				cbFunc(s)
+			}
+		}
+	}
 	cstrings := packet.GetConfigStrings()
 	if len(cstrings) > 0 {
 		if d.currentFrame > 0 {
@@ -126,6 +144,10 @@ func (d *DM2Parser) ApplyPacket(packet *pb.Packet) error {
 			})
 		}
 		for _, fr := range frames {
+			// frame numbers can skip, time is based on how many passed since
+			// the rate changed so rounding errors don't add up
+			d.frameTime = d.rateTime + (fr.GetNumber()-d.rateFrame)*1000/int32(d.fps)
+			fr.ServerTime = d.frameTime
 			d.textProto.Frames[int32(fr.GetNumber())] = fr
 			d.currentFrame = fr.GetNumber()
 			d.frameCount++
@@ -234,6 +256,12 @@ func (demo *DM2Parser) GetTextProto() *pb.DM2Demo {
 	return demo.textProto
 }
 
+// The server frame rate in hz. This is 10 unless the demo was recorded from
+// a server that said otherwise.
+func (demo *DM2Parser) GetFrameRate() int {
+	return demo.fps
+}
+
 // Convert the textproto demo back into a quake 2 playable binary demo. The
 // returned byte slice just needs to be written to a file as is.
 func (demo *DM2Parser) Marshal() ([]byte, error) {
@@ -241,43 +269,56 @@ func (demo *DM2Parser) Marshal() ([]byte, error) {
 	packet := message.Buffer{} // the current packet
 
 	textpb := demo.GetTextProto()
+	marshalGamestate(&out, &packet, textpb)
 
-	packet.Append(message.MarshalServerData(textpb.Serverinfo))
+	frameNum := int32(0)
+	total := 0
+	for total < len(textpb.GetFrames()) {
+		frameNum++
+		fr, ok := textpb.Frames[frameNum]
+		if !ok {
+			continue
+		}
+		tmp := message.MarshalFrame(fr)
+		buildDemoPacket(&out, &packet, tmp, true)
+		total++
+	}
+	if len(packet.Data) > 0 {
+		out.WriteLong(len(packet.Data))
+		out.Append(packet)
+	}
+	out.WriteLong(-1) // end of demo
+	return out.Data, nil
+}
+
+// Write the demo header: serverdata, the frame rate if it's not the original,
+// configstrings, baselines and finally the precache command that tells the
+// client to load everything. The last packet is left in packet.
+func marshalGamestate(final, packet *message.Buffer, textpb *pb.DM2Demo) {
+	packet.Append(message.MarshalServerData(textpb.GetServerinfo()))
+	if fps := textpb.GetFrameRate(); fps > 0 && fps != DefaultFrameRate {
+		packet.Append(message.MarshalSetting(&pb.Setting{Index: message.SettingFPS, Value: fps}))
+	}
 	for i := range MaxConfigStrings {
-		cs, ok := textpb.Configstrings[int32(i)]
+		cs, ok := textpb.GetConfigstrings()[int32(i)]
 		if !ok {
 			continue
 		}
 		tmp := message.MarshalConfigstring(cs)
-		buildDemoPacket(&out, &packet, tmp, false)
+		buildDemoPacket(final, packet, tmp, false)
 	}
 	for i := range MaxEdicts {
-		bl, ok := textpb.Baselines[int32(i)]
+		bl, ok := textpb.GetBaselines()[int32(i)]
 		if !ok {
 			continue
 		}
 		tmp := message.Buffer{Data: []byte{SvcSpawnBaseline}}
 		tmp.Append(message.WriteDeltaEntity(nil, bl))
-		buildDemoPacket(&out, &packet, tmp, false)
+		buildDemoPacket(final, packet, tmp, false)
 	}
 	tmp := message.Buffer{Data: []byte{SvcStuffText}}
 	tmp.Append(message.MarshalStuffText(&pb.StuffText{Data: "precache\n"}))
-	buildDemoPacket(&out, &packet, tmp, false)
-
-	frameNum := int32(0)
-	total := 0
-	for total < len(textpb.GetFrames()) {
-		frameNum++
-		fr, ok := textpb.Frames[frameNum]
-		if !ok {
-			continue
-		}
-		tmp := message.MarshalFrame(fr)
-		buildDemoPacket(&out, &packet, tmp, true)
-		total++
-	}
-	out.WriteLong(-1) // end of demo
-	return out.Data, nil
+	buildDemoPacket(final, packet, tmp, false)
 }
 
 // Append msg to packet until it can't fit anymore, then append packet to final.
@@ -0,0 +1,89 @@
+package demo
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/packetflinger/libq2/message"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// DM2Recorder writes a .dm2 demo from a live connection the same way the
+// client's `record` command does. The header is made up from the gamestate
+// at the moment recording starts, after that each packet received from the
+// server is written as is.
+//
+// Frames are delta compressed against earlier frames, so nothing is written
+// until the server sends an uncompressed frame. Until then Waiting() is true
+// and the client should ask for one (a lastframe of -1 in its moves).
+type DM2Recorder struct {
+	w       io.Writer
+	waiting bool // for an uncompressed frame
+	packets int  // how many have been written, not counting the header
+}
+
+// Start recording a demo. The gamestate needs the serverdata, configstrings
+// and baselines as they are right now, the frames are ignored.
+func NewDM2Recorder(w io.Writer, gamestate *pb.DM2Demo) (*DM2Recorder, error) {
+	if gamestate.GetServerinfo() == nil {
+		return nil, fmt.Errorf("can't record without serverdata")
+	}
+	out := message.Buffer{}
+	packet := message.Buffer{}
+	marshalGamestate(&out, &packet, gamestate)
+	out.WriteLong(len(packet.Data))
+	out.Append(packet)
+	if _, err := w.Write(out.Data); err != nil {
+		return nil, fmt.Errorf("writing demo header: %v", err)
+	}
+	return &DM2Recorder{w: w, waiting: true}, nil
+}
+
+// Is the recorder still waiting for an uncompressed frame?
+func (r *DM2Recorder) Waiting() bool {
+	return r.waiting
+}
+
+// How many packets were recorded
+func (r *DM2Recorder) Packets() int {
+	return r.packets
+}
+
+// Record a packet from the server. `data` is the packet's messages (without
+// the netchan header) and `packet` is the parsed version of it, used to find
+// the first uncompressed frame. Packets before that are dropped.
+func (r *DM2Recorder) WritePacket(data []byte, packet *pb.Packet) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if r.waiting {
+		for _, fr := range packet.GetFrames() {
+			if fr.GetDelta() <= 0 {
+				r.waiting = false
+			}
+		}
+		if r.waiting {
+			return nil
+		}
+	}
+	out := message.Buffer{}
+	out.WriteLong(len(data))
+	out.Data = append(out.Data, data...)
+	if _, err := r.w.Write(out.Data); err != nil {
+		return fmt.Errorf("writing demo packet: %v", err)
+	}
+	r.packets++
+	return nil
+}
+
+// Write the end of demo marker. The writer isn't closed, that's up to the
+// caller.
+func (r *DM2Recorder) Close() error {
+	out := message.Buffer{}
+	out.WriteLong(-1)
+	if _, err := r.w.Write(out.Data); err != nil {
+		return fmt.Errorf("writing end of demo: %v", err)
+	}
+	return nil
+}
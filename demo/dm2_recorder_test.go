@@ -0,0 +1,116 @@
+package demo
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+func TestDM2Recorder(t *testing.T) {
+	tests := []struct {
+		name       string
+		fileIn     string
+		wantFrames int
+	}{
+		{
+			name:       "test 1",
+			fileIn:     "../testdata/test.dm2",
+			wantFrames: 23,
+		},
+		{
+			name:       "duel",
+			fileIn:     "../testdata/testduel.dm2",
+			wantFrames: 3199,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := os.ReadFile(tc.fileIn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			orig := NewDM2Parser()
+			if err := orig.Unmarshal(content); err != nil {
+				t.Fatal(err)
+			}
+
+			// replay the original packets like they came from a server
+			var out bytes.Buffer
+			rec, err := NewDM2Recorder(&out, orig.GetTextProto())
+			if err != nil {
+				t.Fatal(err)
+			}
+			live := NewDM2Parser()
+			live.binaryData = content
+			for {
+				data, length, err := live.NextPacket()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if length == 0 {
+					break
+				}
+				raw := data.Data
+				packet, err := data.ParsePacket(live.textProto.GetFrames())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := live.ApplyPacket(packet); err != nil {
+					t.Fatal(err)
+				}
+				if len(packet.GetFrames()) == 0 && rec.Waiting() {
+					continue // still the gamestate
+				}
+				if err := rec.WritePacket(raw, packet); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := rec.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Waiting() {
+				t.Fatal("recorder never saw an uncompressed frame")
+			}
+
+			got := NewDM2Parser()
+			if err := got.Unmarshal(out.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(got.GetTextProto().GetFrames()); n != tc.wantFrames {
+				t.Errorf("recorded %d frames, want %d", n, tc.wantFrames)
+			}
+			if n, want := len(got.GetTextProto().GetConfigstrings()), len(orig.GetTextProto().GetConfigstrings()); n != want {
+				t.Errorf("recorded %d configstrings, want %d", n, want)
+			}
+			if diffs, err := Diff(orig.GetTextProto(), got.GetTextProto()); err != nil || len(diffs) > 0 {
+				t.Errorf("Diff() = %v, %v, want no differences", diffs, err)
+			}
+		})
+	}
+}
+
+func TestDM2RecorderWaiting(t *testing.T) {
+	rec, err := NewDM2Recorder(&bytes.Buffer{}, &pb.DM2Demo{Serverinfo: &pb.ServerInfo{Protocol: 34}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	packets := []*pb.Packet{
+		{Prints: []*pb.Print{{Data: "hi\n"}}},
+		{Frames: []*pb.Frame{{Number: 10, Delta: 9}}},
+		{Frames: []*pb.Frame{{Number: 11, Delta: -1}}},
+		{Frames: []*pb.Frame{{Number: 12, Delta: 11}}},
+	}
+	for _, p := range packets {
+		if err := rec.WritePacket([]byte{1}, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := rec.Packets(); got != 2 {
+		t.Errorf("Packets() = %d, want 2", got)
+	}
+	if _, err := NewDM2Recorder(&bytes.Buffer{}, &pb.DM2Demo{}); err == nil {
+		t.Error("NewDM2Recorder() without serverdata should fail")
+	}
+}
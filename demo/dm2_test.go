@@ -3,6 +3,11 @@ package demo
 import (
 	"os"
 	"testing"
+
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/util"
+
+	pb "github.com/packetflinger/libq2/proto"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -179,3 +184,68 @@ func TestDemoDebug(t *testing.T) {
 			}
 	*/
 }
+
+func TestFrameRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fps     int32 // 0 leaves the demo alone
+		wantFPS int
+	}{
+		{
+			name:    "original 10hz",
+			wantFPS: 10,
+		},
+		{
+			name:    "40hz",
+			fps:     40,
+			wantFPS: 40,
+		},
+		{
+			name:    "60hz doesn't divide evenly",
+			fps:     60,
+			wantFPS: 60,
+		},
+	}
+	content, err := os.ReadFile("../testdata/test.dm2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := NewDM2Parser()
+			if err := orig.Unmarshal(content); err != nil {
+				t.Fatal(err)
+			}
+			orig.textProto.FrameRate = tc.fps
+			data, err := orig.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			demo := NewDM2Parser()
+			var settings []*pb.Setting
+			demo.RegisterCallback(message.SVCSetting, func(a any) {
+				settings = append(settings, a.(*pb.Setting))
+			})
+			if err := demo.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			if got := demo.GetFrameRate(); got != tc.wantFPS {
+				t.Errorf("GetFrameRate() = %d, want %d", got, tc.wantFPS)
+			}
+			if tc.fps > 0 && len(settings) != 1 {
+				t.Errorf("got %d setting callbacks, want 1", len(settings))
+			}
+			frames := demo.textProto.GetFrames()
+			for _, num := range util.SortedKeys(frames) {
+				if got, want := frames[num].GetServerTime(), num*1000/int32(tc.wantFPS); got != want {
+					t.Errorf("frame %d server time = %d, want %d", num, got, want)
+				}
+			}
+			for _, ev := range DM2Events(demo.textProto, DefaultFrameRate) {
+				if got, want := ev.GetServerTime(), ev.GetFrame()*1000/int32(tc.wantFPS); got != want {
+					t.Errorf("frame %d event time = %d, want %d", ev.GetFrame(), got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package demo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Text formats demos can be exported to. Both are one DemoEvent per line.
+type ExportFormat int
+
+const (
+	ExportJSON ExportFormat = iota // newline-delimited JSON
+	ExportText                     // prototext
+)
+
+const (
+	MaxEventLineLength = 1024 * 1024 // for reading exports back in
+	DefaultFrameRate   = 10          // hz
+)
+
+// Export the demo as a stream of events, one per server message.
+func (p *DM2Parser) Export(w io.Writer, format ExportFormat) error {
+	return WriteEvents(w, DM2Events(p.textProto, p.fps), format)
+}
+
+// Export a multi-view demo as a stream of events, one per server message.
+// Timestamps are based on fps, see MVD2Events().
+func ExportMVD2(w io.Writer, demo *pb.MvdDemo, fps int, format ExportFormat) error {
+	return WriteEvents(w, MVD2Events(demo, fps), format)
+}
+
+// Read an exported DM2 demo and rebuild the binary version of it. The
+// returned data can be written to a file as-is.
+func ImportDM2(r io.Reader, format ExportFormat) ([]byte, error) {
+	events, err := ReadEvents(r, format)
+	if err != nil {
+		return nil, err
+	}
+	textpb, err := DM2FromEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	parser := NewDM2Parser()
+	parser.textProto = textpb
+	return parser.Marshal()
+}
+
+// Read an exported multi-view demo and rebuild the binary version of it.
+func ImportMVD2(r io.Reader, format ExportFormat) ([]byte, error) {
+	events, err := ReadEvents(r, format)
+	if err != nil {
+		return nil, err
+	}
+	writer := NewMVD2Writer(MVD2FromEvents(events))
+	if err := writer.Marshal(); err != nil {
+		return nil, err
+	}
+	return writer.GetData(), nil
+}
+
+// Write events to w, one per line. JSON uses the original proto field names so
+// the schema stays the same as demo_event.proto.
+func WriteEvents(w io.Writer, events []*pb.DemoEvent, format ExportFormat) error {
+	bw := bufio.NewWriter(w)
+	for _, ev := range events {
+		var line []byte
+		var err error
+		switch format {
+		case ExportJSON:
+			line, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(ev)
+		case ExportText:
+			line, err = prototext.MarshalOptions{}.Marshal(ev)
+		default:
+			return fmt.Errorf("unknown export format: %d", format)
+		}
+		if err != nil {
+			return err
+		}
+		bw.Write(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// Read events written by WriteEvents(). Blank lines are skipped.
+func ReadEvents(r io.Reader, format ExportFormat) ([]*pb.DemoEvent, error) {
+	var events []*pb.DemoEvent
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxEventLineLength)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if strings.TrimSpace(string(line)) == "" {
+			continue
+		}
+		ev := &pb.DemoEvent{}
+		var err error
+		switch format {
+		case ExportJSON:
+			err = protojson.Unmarshal(line, ev)
+		case ExportText:
+			err = prototext.Unmarshal(line, ev)
+		default:
+			return nil, fmt.Errorf("unknown export format: %d", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Flatten a DM2 demo into events. Everything from the gamestate (serverdata,
+// configstrings, baselines) is frame 0, the rest are in frame order. Messages
+// within a frame are always ordered the same way.
+//
+// The frame rate recorded in the demo is used for timestamps when there is
+// one, fps is only a fallback for demos that don't say.
+func DM2Events(demo *pb.DM2Demo, fps int) []*pb.DemoEvent {
+	if demo.GetFrameRate() > 0 {
+		fps = int(demo.GetFrameRate())
+	}
+	if fps <= 0 {
+		fps = DefaultFrameRate
+	}
+	var events []*pb.DemoEvent
+	if demo.GetServerinfo() != nil {
+		events = append(events, &pb.DemoEvent{Event: &pb.DemoEvent_Serverdata{Serverdata: demo.GetServerinfo()}})
+	}
+	if demo.GetFrameRate() > 0 {
+		events = append(events, &pb.DemoEvent{Event: &pb.DemoEvent_Setting{Setting: &pb.Setting{
+			Index: message.SettingFPS,
+			Value: demo.GetFrameRate(),
+		}}})
+	}
+	for _, k := range util.SortedKeys(demo.GetConfigstrings()) {
+		events = append(events, &pb.DemoEvent{Event: &pb.DemoEvent_Configstring{Configstring: demo.GetConfigstrings()[k]}})
+	}
+	for _, k := range util.SortedKeys(demo.GetBaselines()) {
+		events = append(events, &pb.DemoEvent{Event: &pb.DemoEvent_Baseline{Baseline: demo.GetBaselines()[k]}})
+	}
+	for _, num := range util.SortedKeys(demo.GetFrames()) {
+		fr := demo.GetFrames()[num]
+		frame := fr.GetNumber()
+		time := fr.GetServerTime()
+		if time == 0 {
+			time = frame * 1000 / int32(fps)
+		}
+		add := func(ev *pb.DemoEvent) {
+			ev.Frame = frame
+			ev.ServerTime = time
+			events = append(events, ev)
+		}
+
+		// just the frame itself, the other messages get their own events
+		bare := &pb.Frame{
+			Number:      fr.GetNumber(),
+			Delta:       fr.GetDelta(),
+			Suppressed:  fr.GetSuppressed(),
+			AreaBytes:   fr.GetAreaBytes(),
+			AreaBits:    fr.GetAreaBits(),
+			PlayerState: fr.GetPlayerState(),
+			Entities:    fr.GetEntities(),
+			ServerTime:  fr.GetServerTime(),
+		}
+		add(&pb.DemoEvent{Event: &pb.DemoEvent_Dm2Frame{Dm2Frame: bare}})
+		for _, k := range util.SortedKeys(fr.GetConfigstrings()) {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Configstring{Configstring: fr.GetConfigstrings()[k]}})
+		}
+		for _, m := range fr.GetPrints() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Print{Print: m}})
+		}
+		for _, m := range fr.GetCenterprints() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Centerprint{Centerprint: m}})
+		}
+		for _, m := range fr.GetStufftexts() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Stufftext{Stufftext: m}})
+		}
+		for _, m := range fr.GetSounds() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Sound{Sound: m}})
+		}
+		for _, m := range fr.GetTemporaryEntities() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_TempEntity{TempEntity: m}})
+		}
+		for _, m := range fr.GetFlashes1() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_MuzzleFlash{MuzzleFlash: m}})
+		}
+		for _, m := range fr.GetFlashes2() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_MuzzleFlash2{MuzzleFlash2: m}})
+		}
+		for _, m := range fr.GetLayouts() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Layout{Layout: m}})
+		}
+	}
+	return events
+}
+
+// Rebuild a DM2 demo from events. Messages before the first frame are part
+// of the gamestate.
+func DM2FromEvents(events []*pb.DemoEvent) (*pb.DM2Demo, error) {
+	demo := &pb.DM2Demo{
+		Baselines:     make(map[int32]*pb.PackedEntity),
+		Configstrings: make(map[int32]*pb.ConfigString),
+		Frames:        make(map[int32]*pb.Frame),
+	}
+	var current *pb.Frame
+	for i, ev := range events {
+		if ev.GetMvdServerdata() != nil || ev.GetMvdFrame() != nil || ev.GetUnicast() != nil || ev.GetMulticast() != nil {
+			return nil, fmt.Errorf("event %d: multi-view event in a dm2 demo", i)
+		}
+		if sd := ev.GetServerdata(); sd != nil {
+			demo.Serverinfo = sd
+			continue
+		}
+		if s := ev.GetSetting(); s != nil {
+			if s.GetIndex() == message.SettingFPS {
+				demo.FrameRate = s.GetValue()
+			}
+			continue
+		}
+		if bl := ev.GetBaseline(); bl != nil {
+			demo.Baselines[int32(bl.GetNumber())] = bl
+			continue
+		}
+		if fr := ev.GetDm2Frame(); fr != nil {
+			current = fr
+			demo.Frames[fr.GetNumber()] = fr
+			continue
+		}
+		if cs := ev.GetConfigstring(); cs != nil {
+			if current == nil {
+				demo.Configstrings[int32(cs.GetIndex())] = cs
+				continue
+			}
+			if current.Configstrings == nil {
+				current.Configstrings = make(map[int32]*pb.ConfigString)
+			}
+			current.Configstrings[int32(cs.GetIndex())] = cs
+			continue
+		}
+		if current == nil {
+			continue // the gamestate only has the above
+		}
+		if m := ev.GetPrint(); m != nil {
+			current.Prints = append(current.Prints, m)
+		}
+		if m := ev.GetCenterprint(); m != nil {
+			current.Centerprints = append(current.Centerprints, m)
+		}
+		if m := ev.GetStufftext(); m != nil {
+			current.Stufftexts = append(current.Stufftexts, m)
+		}
+		if m := ev.GetSound(); m != nil {
+			current.Sounds = append(current.Sounds, m)
+		}
+		if m := ev.GetTempEntity(); m != nil {
+			current.TemporaryEntities = append(current.TemporaryEntities, m)
+		}
+		if m := ev.GetMuzzleFlash(); m != nil {
+			current.Flashes1 = append(current.Flashes1, m)
+		}
+		if m := ev.GetMuzzleFlash2(); m != nil {
+			current.Flashes2 = append(current.Flashes2, m)
+		}
+		if m := ev.GetLayout(); m != nil {
+			current.Layouts = append(current.Layouts, m)
+		}
+	}
+	return demo, nil
+}
+
+// Flatten a multi-view demo into events. Each event keeps the index of the
+// packet it came from so the packets can be rebuilt. Messages in a packet
+// are ordered the same way the MVD2Writer writes them and all belong to the
+// first frame in that packet.
+//
+// MVD2 demos don't record the server's frame rate, so timestamps are based
+// on fps. Less than 1 means DefaultFrameRate.
+func MVD2Events(demo *pb.MvdDemo, fps int) []*pb.DemoEvent {
+	if fps <= 0 {
+		fps = DefaultFrameRate
+	}
+	var events []*pb.DemoEvent
+	frame := int32(0)
+	for i, packet := range demo.GetPackets() {
+		pframe := frame + 1
+		add := func(ev *pb.DemoEvent, fr int32) {
+			ev.Packet = int32(i)
+			ev.Frame = fr
+			ev.ServerTime = fr * 1000 / int32(fps)
+			events = append(events, ev)
+		}
+		frames := packet.GetFrames()
+		if sd := packet.GetServerdata(); sd != nil {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_MvdServerdata{MvdServerdata: sd}}, pframe)
+		}
+		for _, k := range util.SortedKeys(packet.GetConfigstrings()) {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Configstring{Configstring: packet.GetConfigstrings()[k]}}, pframe)
+		}
+		for _, m := range packet.GetUnicasts() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Unicast{Unicast: m}}, pframe)
+		}
+		for _, m := range packet.GetMulticasts() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Multicast{Multicast: m}}, pframe)
+		}
+		for _, m := range packet.GetSounds() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Sound{Sound: m}}, pframe)
+		}
+		for _, m := range packet.GetPrints() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Print{Print: m}}, pframe)
+		}
+		for _, m := range packet.GetStuffs() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Stufftext{Stufftext: m}}, pframe)
+		}
+		for _, fr := range frames {
+			frame++
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_MvdFrame{MvdFrame: fr}}, frame)
+		}
+		if packet.GetDisconnect() {
+			add(&pb.DemoEvent{Event: &pb.DemoEvent_Disconnect{Disconnect: true}}, pframe)
+		}
+	}
+	return events
+}
+
+// Rebuild a multi-view demo from events, grouping them back into packets.
+func MVD2FromEvents(events []*pb.DemoEvent) *pb.MvdDemo {
+	demo := &pb.MvdDemo{}
+	var packet *pb.MvdPacket
+	last := int32(-1)
+	for _, ev := range events {
+		if packet == nil || ev.GetPacket() != last {
+			packet = &pb.MvdPacket{}
+			demo.Packets = append(demo.Packets, packet)
+			last = ev.GetPacket()
+		}
+		if sd := ev.GetMvdServerdata(); sd != nil {
+			packet.Serverdata = sd
+			demo.Identity = sd.GetIdentity()
+		}
+		if cs := ev.GetConfigstring(); cs != nil {
+			if packet.Configstrings == nil {
+				packet.Configstrings = make(map[int32]*pb.ConfigString)
+			}
+			packet.Configstrings[int32(cs.GetIndex())] = cs
+		}
+		if m := ev.GetMvdFrame(); m != nil {
+			packet.Frames = append(packet.Frames, m)
+		}
+		if m := ev.GetUnicast(); m != nil {
+			packet.Unicasts = append(packet.Unicasts, m)
+		}
+		if m := ev.GetMulticast(); m != nil {
+			packet.Multicasts = append(packet.Multicasts, m)
+		}
+		if m := ev.GetSound(); m != nil {
+			packet.Sounds = append(packet.Sounds, m)
+		}
+		if m := ev.GetPrint(); m != nil {
+			packet.Prints = append(packet.Prints, m)
+		}
+		if m := ev.GetStufftext(); m != nil {
+			packet.Stuffs = append(packet.Stuffs, m)
+		}
+		if ev.GetDisconnect() {
+			packet.Disconnect = true
+		}
+	}
+	return demo
+}
@@ -0,0 +1,167 @@
+package demo
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/packetflinger/libq2/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestDM2ExportImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		fileIn string
+		format ExportFormat
+	}{
+		{
+			name:   "json",
+			fileIn: "../testdata/test.dm2",
+			format: ExportJSON,
+		},
+		{
+			name:   "prototext",
+			fileIn: "../testdata/test.dm2",
+			format: ExportText,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := os.ReadFile(tc.fileIn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parser := NewDM2Parser()
+			if err := parser.Unmarshal(content); err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			if err := parser.Export(&out, tc.format); err != nil {
+				t.Fatalf("Export() error: %v", err)
+			}
+			lines := strings.Count(out.String(), "\n")
+			if want := len(DM2Events(parser.GetTextProto(), 10)); lines != want {
+				t.Errorf("Export() wrote %d lines, want %d", lines, want)
+			}
+
+			events, err := ReadEvents(bytes.NewReader(out.Bytes()), tc.format)
+			if err != nil {
+				t.Fatalf("ReadEvents() error: %v", err)
+			}
+			got, err := DM2FromEvents(events)
+			if err != nil {
+				t.Fatalf("DM2FromEvents() error: %v", err)
+			}
+			if diff := cmp.Diff(parser.GetTextProto(), got, protocmp.Transform()); diff != "" {
+				t.Errorf("DM2FromEvents() mismatch (-want +got):\n%s", diff)
+			}
+
+			data, err := ImportDM2(bytes.NewReader(out.Bytes()), tc.format)
+			if err != nil {
+				t.Fatalf("ImportDM2() error: %v", err)
+			}
+			reparsed := NewDM2Parser()
+			if err := reparsed.Unmarshal(data); err != nil {
+				t.Fatalf("error parsing imported demo: %v", err)
+			}
+			if len(reparsed.GetTextProto().GetFrames()) != len(parser.GetTextProto().GetFrames()) {
+				t.Errorf("imported demo has %d frames, want %d", len(reparsed.GetTextProto().GetFrames()), len(parser.GetTextProto().GetFrames()))
+			}
+		})
+	}
+}
+
+func TestMVD2ExportImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		demofile string
+		format   ExportFormat
+	}{
+		{
+			name:     "json",
+			demofile: "../testdata/ziptest.mvd2.gz",
+			format:   ExportJSON,
+		},
+		{
+			name:     "prototext",
+			demofile: "../testdata/ziptest.mvd2.gz",
+			format:   ExportText,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, err := NewMVD2Parser(tc.demofile)
+			if err != nil {
+				t.Fatalf("error creating parser: %v", err)
+			}
+			demos, err := parser.Unmarshal()
+			if err != nil {
+				t.Fatalf("error unmarshalling: %v", err)
+			}
+			var out bytes.Buffer
+			if err := ExportMVD2(&out, demos[0], DefaultFrameRate, tc.format); err != nil {
+				t.Fatalf("ExportMVD2() error: %v", err)
+			}
+
+			data, err := ImportMVD2(bytes.NewReader(out.Bytes()), tc.format)
+			if err != nil {
+				t.Fatalf("ImportMVD2() error: %v", err)
+			}
+			stream, err := NewMVD2Stream(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("error reading imported demo: %v", err)
+			}
+			ignore := protocmp.IgnoreFields(&pb.MvdUnicast{}, "player")
+			for i, want := range demos[0].GetPackets() {
+				got, err := stream.Next()
+				if err != nil {
+					t.Fatalf("packet %d: %v", i, err)
+				}
+				if diff := cmp.Diff(want, got, protocmp.Transform(), ignore); diff != "" {
+					t.Fatalf("packet %d mismatch (-want +got):\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMVD2EventsFrameTime(t *testing.T) {
+	demo := &pb.MvdDemo{
+		Packets: []*pb.MvdPacket{
+			{
+				Serverdata: &pb.MvdServerData{Protocol: 2010},
+				Frames:     []*pb.MvdFrame{{}},
+			},
+			{
+				Prints: []*pb.Print{{Level: 2, Data: "hi\n"}},
+				Frames: []*pb.MvdFrame{{}},
+			},
+		},
+	}
+	tests := []struct {
+		name  string
+		fps   int
+		msecs int32 // per frame
+	}{
+		{name: "default", fps: 0, msecs: 100},
+		{name: "10hz", fps: 10, msecs: 100},
+		{name: "40hz", fps: 40, msecs: 25},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			want := []*pb.DemoEvent{
+				{Frame: 1, ServerTime: tc.msecs, Packet: 0, Event: &pb.DemoEvent_MvdServerdata{MvdServerdata: &pb.MvdServerData{Protocol: 2010}}},
+				{Frame: 1, ServerTime: tc.msecs, Packet: 0, Event: &pb.DemoEvent_MvdFrame{MvdFrame: &pb.MvdFrame{}}},
+				{Frame: 2, ServerTime: 2 * tc.msecs, Packet: 1, Event: &pb.DemoEvent_Print{Print: &pb.Print{Level: 2, Data: "hi\n"}}},
+				{Frame: 2, ServerTime: 2 * tc.msecs, Packet: 1, Event: &pb.DemoEvent_MvdFrame{MvdFrame: &pb.MvdFrame{}}},
+			}
+			got := MVD2Events(demo, tc.fps)
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("MVD2Events() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
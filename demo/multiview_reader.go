@@ -26,6 +26,7 @@ type MVD2Parser struct {
 	debug          bool              // print stuff while parsing
 	zipped         bool              // file is gzipped
 	remap          *pb.MvdConfigStringRemap
+	removed        []int32 // players removed in the current frame
 }
 
 // Read the contents of the multi-view demo file and setup a receiver for
@@ -187,13 +188,16 @@ func (p *MVD2Parser) ParsePacket(msg *message.Buffer) (*pb.MvdPacket, error) {
 				return nil, err
 			}
 			packet.Serverdata = data
+			p.demo.EntityStateFlags = data.GetEntitystateFlags()
+			p.demo.PlayerStateFlags = data.GetPlayerstateFlags()
+			p.demo.Entities = nil // new gamestate, nothing to delta from
 			p.demo.Configstrings = p.ParseConfigStrings(msg, p.remap)
+			packet.Configstrings = p.demo.Configstrings
 			frame, err := p.ParseFrame(msg)
 			if err != nil {
 				return nil, err
 			}
 			packet.Frames = append(packet.Frames, frame)
-			p.demo.Entities = frame.GetEntities()
 
 		case MVDSvcConfigString:
 			cs, err := p.ParseConfigString(msg, p.remap)
@@ -209,11 +213,18 @@ func (p *MVD2Parser) ParsePacket(msg *message.Buffer) (*pb.MvdPacket, error) {
 			}
 
 			// is it a player skin? then add the player
-			playerNum := int32(cs.GetIndex()) - p.remap.PlayerSkins
+			playerNum := int32(cs.GetIndex()) - p.remap.GetPlayerSkins()
 			if 0 <= playerNum && playerNum <= p.demo.MaxPlayers {
-				p.demo.Players[playerNum] = &pb.MvdPlayer{
-					Name: cs.Data[:strings.Index(cs.Data, "\\")],
+				if p.demo.Players == nil {
+					p.demo.Players = make(map[int32]*pb.MvdPlayer)
 				}
+				pl, ok := p.demo.Players[playerNum]
+				if !ok {
+					pl = &pb.MvdPlayer{}
+					p.demo.Players[playerNum] = pl
+				}
+				// keep the playerstate, it's needed for delta decompression
+				pl.Name, _, _ = strings.Cut(cs.Data, "\\")
 			}
 
 		case MVDSvcFrame:
@@ -225,7 +236,6 @@ func (p *MVD2Parser) ParsePacket(msg *message.Buffer) (*pb.MvdPacket, error) {
 				cbFunc(frame)
 			}
 			packet.Frames = append(packet.Frames, frame)
-			p.demo.Entities = frame.GetEntities() // maybe??
 
 		case MVDSvcSound:
 			sound := p.ParseSound(msg, extra)
@@ -234,6 +244,16 @@ func (p *MVD2Parser) ParsePacket(msg *message.Buffer) (*pb.MvdPacket, error) {
 				cbFunc(sound)
 			}
 
+		case MVDSvcStuffText:
+			stuff := &pb.StuffText{Data: msg.ReadString()}
+			packet.Stuffs = append(packet.Stuffs, stuff)
+			if cbFunc, found := p.callbacks[MVDSvcStuffText]; found {
+				cbFunc(stuff)
+			}
+
+		case MVDSvcDisconnect:
+			packet.Disconnect = true
+
 		case MVDSvcPrint:
 			print := &pb.Print{
 				Level: uint32(msg.ReadByte()),
@@ -354,8 +374,9 @@ func (p *MVD2Parser) ParseConfigStrings(msg *message.Buffer, remap *pb.MvdConfig
 		for i := int32(0); i < p.demo.MaxPlayers; i++ {
 			cs, ok := out[remap.GetPlayerSkins()+i]
 			if ok {
+				name, _, _ := strings.Cut(cs.Data, "\\")
 				p.demo.Players[i] = &pb.MvdPlayer{
-					Name: cs.Data[:strings.Index(cs.Data, "\\")],
+					Name: name,
 				}
 			}
 		}
@@ -365,11 +386,14 @@ func (p *MVD2Parser) ParseConfigStrings(msg *message.Buffer, remap *pb.MvdConfig
 
 // Parse a single configstring from MVD data
 func (p *MVD2Parser) ParseConfigString(msg *message.Buffer, remap *pb.MvdConfigStringRemap) (*pb.ConfigString, error) {
+	if remap == nil {
+		return nil, fmt.Errorf("ParseConfigString() error - no serverdata yet")
+	}
 	if msg.Index >= msg.Length {
 		return nil, fmt.Errorf("ParseConfigString() error - end of buffer")
 	}
 	idx := msg.ReadShort()
-	if idx >= int(int16(p.remap.End)) {
+	if idx >= int(int16(remap.GetEnd())) {
 		return nil, fmt.Errorf("ParseConfigString() error - index out of bounds: %d", idx)
 	}
 	str := msg.ReadString()
@@ -393,6 +417,8 @@ func (p *MVD2Parser) ParseFrame(msg *message.Buffer) (*pb.MvdFrame, error) {
 		return nil, err
 	}
 	frame.Players = players
+	frame.RemovedPlayers = p.removed
+	p.removed = nil
 	ents, err := p.ParseDeltaEntities(msg)
 	if err != nil {
 		return nil, err
@@ -409,19 +435,26 @@ func (p *MVD2Parser) ParsePacketPlayers(msg *message.Buffer) (map[int32]*pb.Pack
 	var bits uint32
 	out := make(map[int32]*pb.PackedPlayer)
 	for {
+		if msg.Index >= msg.Length {
+			return nil, fmt.Errorf("ParsePacketPlayers() error - end of buffer")
+		}
 		number := int32(msg.ReadByte())
 		if number == ClientNumNone {
 			break
 		}
+		if p.demo.Players == nil {
+			p.demo.Players = make(map[int32]*pb.MvdPlayer)
+		}
 		pl, ok := p.demo.Players[number]
 		if !ok {
 			pl = &pb.MvdPlayer{
 				Name: "unknown",
 			}
+			p.demo.Players[number] = pl
 		}
 		// check num bounds later
 		bits = uint32(msg.ReadWord())
-		ps, err := p.ParseDeltaPlayer(msg, bits, p.demo.PlayerStateFlags)
+		ps, err := p.ParseDeltaPlayer(msg, bits, pl.GetPlayerState(), p.demo.PlayerStateFlags)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing player: %v", err)
 		}
@@ -429,6 +462,7 @@ func (p *MVD2Parser) ParsePacketPlayers(msg *message.Buffer) (map[int32]*pb.Pack
 
 		if (bits & MvdPlayerRemove) != 0 {
 			pl.InUse = false
+			p.removed = append(p.removed, number)
 			continue
 		}
 		pl.InUse = true
@@ -446,9 +480,18 @@ func (p *MVD2Parser) ParsePacketPlayers(msg *message.Buffer) (map[int32]*pb.Pack
 
 // Parse a compressed player. Parsing delta players from regular DM2 demos is
 // similar but not identical, so a separate func is needed.
-func (p *MVD2Parser) ParseDeltaPlayer(msg *message.Buffer, bits uint32, flags int32) (*pb.PackedPlayer, error) {
+//
+// Only the fields flagged in bits are transmitted, the rest are copied from
+// the player's previous state (from), which can be nil for a new player.
+func (p *MVD2Parser) ParseDeltaPlayer(msg *message.Buffer, bits uint32, from *pb.PackedPlayer, flags int32) (*pb.PackedPlayer, error) {
 	to := &pb.PackedPlayer{}
 	pm := &pb.PlayerMove{}
+	if from != nil {
+		to = proto.Clone(from).(*pb.PackedPlayer)
+		if from.GetMovestate() != nil {
+			pm = to.GetMovestate()
+		}
+	}
 	if (bits & MvdPlayerType) != 0 {
 		pm.Type = msg.ReadByteP()
 	}
@@ -542,7 +585,12 @@ func (p *MVD2Parser) ParseDeltaPlayer(msg *message.Buffer, bits uint32, flags in
 	}
 	if (bits & MvdPlayerStats) != 0 {
 		stats := p.ParsePlayerStats(msg, flags)
-		to.Stats = stats
+		if to.Stats == nil {
+			to.Stats = stats
+		}
+		for k, v := range stats {
+			to.Stats[k] = v
+		}
 	}
 	to.Movestate = pm
 	return to, nil
@@ -577,7 +625,9 @@ func (p *MVD2Parser) ParsePlayerStats(msg *message.Buffer, flags int32) map[uint
 	return stats
 }
 
-// Parse all the entities from a frame. These come directly after all the playerstates.
+// Parse all the entities from a frame. These come directly after all the
+// playerstates. Each entity is merged with its previous state and the map
+// returned contains only the entities that were transmitted in this frame.
 func (p *MVD2Parser) ParseDeltaEntities(msg *message.Buffer) (map[int32]*pb.PackedEntity, error) {
 	var bits int64
 	var num int32
@@ -586,8 +636,12 @@ func (p *MVD2Parser) ParseDeltaEntities(msg *message.Buffer) (map[int32]*pb.Pack
 	if p.demo.Entities == nil {
 		p.demo.Entities = make(map[int32]*pb.PackedEntity)
 	}
+	out := make(map[int32]*pb.PackedEntity)
 
 	for {
+		if msg.Index >= msg.Length {
+			return nil, fmt.Errorf("ParseDeltaEntities() error - end of buffer")
+		}
 		num, bits = p.ParseEntityBits(msg)
 		if num == 0 {
 			break
@@ -600,21 +654,15 @@ func (p *MVD2Parser) ParseDeltaEntities(msg *message.Buffer) (map[int32]*pb.Pack
 		if err != nil {
 			return nil, err
 		}
-		if (bits & message.EntityRemove) != 0 {
-			if (ent.RenderFx & message.RFBeam) == 0 {
-				ent.OldOriginX = ent.GetOriginX()
-				ent.OldOriginY = ent.GetOriginY()
-				ent.OldOriginZ = ent.GetOriginZ()
-			}
-			// set inuse false
-		}
+		ent.Remove = (bits & message.EntityRemove) != 0
 		ent.Number = uint32(num)
 		p.demo.Entities[num] = ent
+		out[num] = ent
 	}
 	if p.debug {
 		fmt.Printf("entities\n")
 	}
-	return nil, nil
+	return out, nil
 }
 
 // Each entity is prefixed with up to 5 bytes of bitmask followed by the entity
@@ -819,28 +867,26 @@ func (p *MVD2Parser) ParseUnicast(msg *message.Buffer, reliable bool, extra int)
 		player = &pb.MvdPlayer{Name: "[unknown]", InUse: true}
 	}
 	out.ClientNumber = clientNum
-	out.Player = player
-
-	readStart := msg.Index
-	for {
-		if (msg.Index - readStart) >= int(len) {
-			break
-		}
-		cmd := msg.ReadByte()
-		switch cmd {
-		case SvcLayout:
-			layout := &pb.Layout{Data: msg.ReadString()}
-			out.Layouts = append(out.Layouts, layout)
-		case SvcConfigString:
-			cs := &pb.ConfigString{Index: uint32(msg.ReadWord()), Data: msg.ReadString()}
-			out.Configstrings = append(out.Configstrings, cs)
-		case SvcPrint:
-			p := &pb.Print{Level: uint32(msg.ReadByte()), Data: msg.ReadString()}
-			out.Prints = append(out.Prints, p)
-		case SvcStuffText:
-			st := &pb.StuffText{Data: msg.ReadString()}
-			out.Stuffs = append(out.Stuffs, st)
-		}
+	out.Player = proto.Clone(player).(*pb.MvdPlayer) // as of this unicast
+	out.Reliable = reliable
+
+	messages, order, unknown := parsePayload(msg.ReadData(int(len)))
+	out.Layouts = messages.GetLayouts()
+	out.Configstrings = messages.GetConfigStrings()
+	out.Prints = messages.GetPrints()
+	out.Stuffs = messages.GetStuffs()
+	out.Centerprints = messages.GetCenterprints()
+	out.Sounds = messages.GetSounds()
+	out.TempEnts = messages.GetTempEnts()
+	out.MuzzleFlashes = messages.GetMuzzleFlashes()
+	out.MuzzleFlashes2 = messages.GetMuzzleFlashes2()
+	out.Inventories = messages.GetInventories()
+	out.Disconnect = messages.GetDisconnect()
+	out.Reconnect = messages.GetReconnect()
+	out.Order = order
+	out.Unknown = unknown
+	if p.debug && unknown != nil {
+		fmt.Printf("unicast - [%d] unknown message %d, keeping the rest as is\n", clientNum, unknown[0])
 	}
 	if p.debug {
 		fmt.Printf("unicast - [%d] %q\n", clientNum, player.Name)
@@ -856,6 +902,9 @@ func (p *MVD2Parser) ParseUnicast(msg *message.Buffer, reliable bool, extra int)
 		for _, p := range out.GetPrints() {
 			fmt.Printf("  print - [%d] %q\n", p.GetLevel(), p.GetData())
 		}
+		for _, cp := range out.GetCenterprints() {
+			fmt.Printf("  centerprint - %q\n", cp.GetData())
+		}
 	}
 	return out, nil
 }
@@ -865,7 +914,7 @@ func (p *MVD2Parser) ParseSound(msg *message.Buffer, extra int) *pb.PackedSound
 	s := &pb.PackedSound{}
 	flags := msg.ReadByteP()
 	s.Flags = flags
-	if p.remap.Extended && ((flags & message.SoundIndex16) != 0) {
+	if p.remap.GetExtended() && ((flags & message.SoundIndex16) != 0) {
 		index = msg.ReadWordP()
 	} else {
 		index = msg.ReadByteP()
@@ -883,14 +932,13 @@ func (p *MVD2Parser) ParseSound(msg *message.Buffer, extra int) *pb.PackedSound
 	}
 
 	sendchan := msg.ReadWordP()
-	entnum := int32(sendchan >> 3)
-	ent := p.demo.Entities[entnum]
-	s.Entity = ent.GetNumber()
+	s.Entity = sendchan >> 3
+	s.Channel = sendchan & 7
 	if p.debug {
 		fmt.Printf(
 			"sound - [%d] %q\n",
 			s.GetIndex(),
-			p.demo.GetConfigstrings()[p.remap.GetSounds()+int32(s.GetIndex())].Data,
+			p.demo.GetConfigstrings()[p.remap.GetSounds()+int32(s.GetIndex())].GetData(),
 		)
 	}
 	return s
@@ -898,23 +946,72 @@ func (p *MVD2Parser) ParseSound(msg *message.Buffer, extra int) *pb.PackedSound
 
 // ParseMulticast is used to parse all 6 multicast cmd types
 func (p *MVD2Parser) ParseMulticast(msg *message.Buffer, to int, extra int) *pb.MvdMulticast {
-	out := &pb.MvdMulticast{}
+	out := &pb.MvdMulticast{Type: int32(to)}
 	len := msg.ReadByteP()
 	len |= uint32(extra) << 8
-	if to != 0 {
+	if to%3 != 0 { // MulticastAll(R) have no leaf
 		out.Leaf = int32(msg.ReadWordP())
 	}
-	out.Data = msg.ReadData(int(len))
+	messages, order, unknown := parsePayload(msg.ReadData(int(len)))
+	out.TempEnts = messages.GetTempEnts()
+	out.MuzzleFlashes = messages.GetMuzzleFlashes()
+	out.MuzzleFlashes2 = messages.GetMuzzleFlashes2()
+	out.Sounds = messages.GetSounds()
+	out.Prints = messages.GetPrints()
+	out.Centerprints = messages.GetCenterprints()
+	out.Layouts = messages.GetLayouts()
+	out.Stuffs = messages.GetStuffs()
+	out.Configstrings = messages.GetConfigStrings()
+	out.Inventories = messages.GetInventories()
+	out.Disconnect = messages.GetDisconnect()
+	out.Reconnect = messages.GetReconnect()
+	out.Order = order
+	out.Unknown = unknown
 
 	if cbFunc, found := p.callbacks[to]; found {
 		cbFunc(out)
 	}
 	if p.debug {
-		fmt.Printf("multicast - %v\n", out.GetData())
+		fmt.Printf("multicast - %v\n", order)
+		if unknown != nil {
+			fmt.Printf("  unknown message %d, keeping the rest as is\n", unknown[0])
+		}
 	}
 	return out
 }
 
+// Parse the payload of a unicast or multicast. These are regular server
+// messages, parsed the same way as in a DM2 packet. The command of each one
+// is returned in order so they can be written back the same way. Parsing
+// stops at the first message that doesn't belong in a payload, it and
+// everything after it is returned as is.
+func parsePayload(data []byte) (*pb.Packet, []int32, []byte) {
+	out := &pb.Packet{}
+	var order []int32
+	msg := message.NewBuffer(data)
+	for msg.Index < msg.Length {
+		start := msg.Index
+		cmd := msg.ReadByte()
+		if !payloadMessage(cmd) || !msg.ParseMessage(cmd, out, nil) {
+			return out, order, data[start:]
+		}
+		order = append(order, int32(cmd))
+	}
+	return out, order, nil
+}
+
+// Server messages that can be in a unicast or multicast payload and have
+// somewhere to go in their protos.
+func payloadMessage(cmd int) bool {
+	switch cmd {
+	case SvcMuzzleFlash, SvcMuzzleFlash2, SvcTemporaryEntity, SvcLayout,
+		SvcInventory, SvcNoOperation, SvcDisconnect, SvcReconnect, SvcSound,
+		SvcPrint, SvcStuffText, SvcConfigString, SvcCenterprint:
+		return true
+	}
+	return false
+}
+
 // RegisterCallback allows for a custom function to be called at specific
 // points while a demo is being parsed.
 //
@@ -959,6 +1056,12 @@ func (p *MVD2Parser) GetDemos() []*pb.MvdDemo {
 	return p.allDemos
 }
 
+// The state of the demo currently being parsed. This is what the next packet
+// will be delta decompressed against.
+func (p *MVD2Parser) GetState() *pb.MvdDemo {
+	return p.demo
+}
+
 // Private member accessor
 func (p *MVD2Parser) GetDebug() bool {
 	return p.debug
@@ -1,6 +1,7 @@
 package demo
 
 import (
+	"bytes"
 	"encoding/hex"
 	"strings"
 	"testing"
@@ -490,7 +491,7 @@ func TestMvdUnmarshal(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			parser, err := NewMVD2Parser(tc.demofile)
 			if err != nil {
-				t.Errorf("error creating parser: %v", err)
+				t.Fatalf("error creating parser: %v", err)
 			}
 			parser.debug = false
 			demos, err := parser.Unmarshal()
@@ -506,3 +507,208 @@ func TestMvdUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+// Unicast payloads are regular server messages, everything written should be
+// parsed back the same.
+func TestMvdParseUnicast(t *testing.T) {
+	tests := []struct {
+		name string
+		uc   *pb.MvdUnicast
+	}{
+		{
+			name: "chat",
+			uc: &pb.MvdUnicast{
+				ClientNumber: 3,
+				Prints:       []*pb.Print{{Level: message.PrintLevelChat, Data: "(bob): quad\n"}},
+			},
+		},
+		{
+			name: "centerprint and layout",
+			uc: &pb.MvdUnicast{
+				ClientNumber: 1,
+				Reliable:     true,
+				Layouts:      []*pb.Layout{{Data: "xv 0 yv 0 string \"hi\""}},
+				Centerprints: []*pb.CenterPrint{{Data: "Fight!"}},
+			},
+		},
+		{
+			name: "effects",
+			uc: &pb.MvdUnicast{
+				ClientNumber:   7,
+				Sounds:         []*pb.PackedSound{{Flags: message.SoundEntity, Index: 12, Volume: 1, Attenuation: 1, Entity: 5, Channel: 2}},
+				TempEnts:       []*pb.TemporaryEntity{{Type: message.TentBlood, Position1X: 100, Position1Y: 200, Position1Z: 300, Direction: 4}},
+				MuzzleFlashes:  []*pb.MuzzleFlash{{Entity: 5, Weapon: 7}},
+				MuzzleFlashes2: []*pb.MuzzleFlash{{Entity: 9, Weapon: 2}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := NewMVD2Writer(&pb.MvdDemo{})
+			buf, err := writer.MarshalUnicast(tc.uc)
+			if err != nil {
+				t.Fatalf("MarshalUnicast(%v) error: %v", tc.uc, err)
+			}
+			parser := MVD2Parser{demo: &pb.MvdDemo{}, remap: csRemap}
+			buf.Index = 0
+			cmd := buf.ReadByte()
+			reliable := cmd&CommandMask == MVDSvcUnicastReliable
+			got, err := parser.ParseUnicast(&buf, reliable, cmd>>CommandBits)
+			if err != nil {
+				t.Fatalf("ParseUnicast() error: %v", err)
+			}
+			ignore := protocmp.IgnoreFields(&pb.MvdUnicast{}, "player", "order")
+			if diff := cmp.Diff(tc.uc, got, protocmp.Transform(), ignore); diff != "" {
+				t.Errorf("ParseUnicast() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	flashes := message.Buffer{}
+	flashes.WriteByte(message.SVCMuzzleFlash)
+	flashes.Append(message.MarshalFlash(&pb.MuzzleFlash{Entity: 3, Weapon: 1}))
+	flashes.WriteByte(message.SVCMuzzleFlash2)
+	flashes.Append(message.MarshalFlash(&pb.MuzzleFlash{Entity: 4, Weapon: 2}))
+	flashes.WriteByte(message.SVCCenterPrint)
+	flashes.WriteString("Fight!")
+	bad := message.Buffer{}
+	bad.WriteByte(message.SVCMuzzleFlash)
+	bad.Append(message.MarshalFlash(&pb.MuzzleFlash{Entity: 3, Weapon: 1}))
+	bad.WriteByte(200)
+	bad.WriteByte(SvcMuzzleFlash)
+	inventory := message.Buffer{}
+	inventory.WriteByte(message.SVCInventory)
+	inventory.Append(message.MarshalInventory(&pb.Inventory{Items: make([]int32, message.MaxItems)}))
+	inventory.WriteByte(message.SVCNOP)
+	inventory.WriteByte(SvcDisconnect)
+	frame := message.Buffer{}
+	frame.WriteByte(SvcFrame) // belongs in a packet, not a payload
+	frame.WriteByte(1)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		want        *pb.Packet
+		wantOrder   []int32
+		wantUnknown []byte
+	}{
+		{
+			name: "flashes and centerprint",
+			data: flashes.Data,
+			want: &pb.Packet{
+				MuzzleFlashes:  []*pb.MuzzleFlash{{Entity: 3, Weapon: 1}},
+				MuzzleFlashes2: []*pb.MuzzleFlash{{Entity: 4, Weapon: 2}},
+				Centerprints:   []*pb.CenterPrint{{Data: "Fight!"}},
+			},
+			wantOrder: []int32{SvcMuzzleFlash, SvcMuzzleFlash2, SvcCenterprint},
+		},
+		{
+			name:        "unknown message",
+			data:        bad.Data,
+			want:        &pb.Packet{MuzzleFlashes: []*pb.MuzzleFlash{{Entity: 3, Weapon: 1}}},
+			wantOrder:   []int32{SvcMuzzleFlash},
+			wantUnknown: []byte{200, SvcMuzzleFlash},
+		},
+		{
+			name: "inventory, nop and disconnect",
+			data: inventory.Data,
+			want: &pb.Packet{
+				Inventories: []*pb.Inventory{{Items: make([]int32, message.MaxItems)}},
+				Disconnect:  true,
+			},
+			wantOrder: []int32{SvcInventory, SvcNoOperation, SvcDisconnect},
+		},
+		{
+			name:        "not a payload message",
+			data:        frame.Data,
+			want:        &pb.Packet{},
+			wantUnknown: frame.Data,
+		},
+		{
+			name: "empty",
+			want: &pb.Packet{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, order, unknown := parsePayload(tc.data)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("parsePayload() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOrder, order); diff != "" {
+				t.Errorf("parsePayload() order mismatch (-want +got):\n%s", diff)
+			}
+			if !bytes.Equal(unknown, tc.wantUnknown) {
+				t.Errorf("parsePayload() unknown = %v, want %v", unknown, tc.wantUnknown)
+			}
+		})
+	}
+}
+
+// Payloads written back out unchanged should be byte for byte the same as
+// they were read, whatever order the messages were in and even if some
+// couldn't be parsed.
+func TestMvdPayloadRoundTrip(t *testing.T) {
+	payload := message.Buffer{}
+	payload.WriteByte(message.SVCPrint)
+	payload.Append(message.MarshalPrint(&pb.Print{Level: message.PrintLevelHigh, Data: "hi\n"}))
+	payload.WriteByte(message.SVCInventory)
+	payload.Append(message.MarshalInventory(&pb.Inventory{Items: []int32{0, 1, 2, 100}}))
+	payload.WriteByte(message.SVCNOP)
+	payload.WriteByte(message.SVCLayout)
+	payload.Append(message.MarshalLayout(&pb.Layout{Data: "xv 0 yv 0 string \"hi\""}))
+	payload.WriteByte(message.SVCMuzzleFlash)
+	payload.Append(message.MarshalFlash(&pb.MuzzleFlash{Entity: 3, Weapon: 1}))
+	payload.WriteByte(message.SVCPrint)
+	payload.Append(message.MarshalPrint(&pb.Print{Level: message.PrintLevelChat, Data: "bob: hi\n"}))
+	payload.WriteByte(200) // unknown, kept with everything after it
+	payload.WriteData([]byte{1, 2, 3})
+
+	unicast := message.Buffer{}
+	unicast.WriteByte(MVDSvcUnicastReliable | ((payload.Size() >> 8) << CommandBits))
+	unicast.WriteByte(payload.Size() & 0xff)
+	unicast.WriteByte(5) // client number
+	unicast.Append(payload)
+
+	multicast := message.Buffer{}
+	multicast.WriteByte(MVDSvcMulticastPHS | ((payload.Size() >> 8) << CommandBits))
+	multicast.WriteByte(payload.Size() & 0xff)
+	multicast.WriteWordP(260) // leaf
+	multicast.Append(payload)
+
+	parser := MVD2Parser{demo: &pb.MvdDemo{}, remap: csRemap}
+	writer := NewMVD2Writer(&pb.MvdDemo{})
+
+	unicast.Index = 0
+	cmd := unicast.ReadByte()
+	uc, err := parser.ParseUnicast(&unicast, cmd&CommandMask == MVDSvcUnicastReliable, cmd>>CommandBits)
+	if err != nil {
+		t.Fatalf("ParseUnicast() error: %v", err)
+	}
+	if len(uc.GetInventories()) != 1 || uc.GetUnknown() == nil {
+		t.Errorf("ParseUnicast() = %v, want an inventory and unknown bytes", uc)
+	}
+	out, err := writer.MarshalUnicast(uc)
+	if err != nil {
+		t.Fatalf("MarshalUnicast() error: %v", err)
+	}
+	if got, want := hex.EncodeToString(out.Data), hex.EncodeToString(unicast.Data); got != want {
+		t.Errorf("unicast round trip = %s, want %s", got, want)
+	}
+
+	multicast.Index = 0
+	cmd = multicast.ReadByte()
+	mc := parser.ParseMulticast(&multicast, cmd&CommandMask-MVDSvcMulticastAll, cmd>>CommandBits)
+	if len(mc.GetInventories()) != 1 || mc.GetUnknown() == nil {
+		t.Errorf("ParseMulticast() = %v, want an inventory and unknown bytes", mc)
+	}
+	mout, err := writer.MarshalMulticast(mc)
+	if err != nil {
+		t.Fatalf("MarshalMulticast() error: %v", err)
+	}
+	if got, want := hex.EncodeToString(mout.Data), hex.EncodeToString(multicast.Data); got != want {
+		t.Errorf("multicast round trip = %s, want %s", got, want)
+	}
+}
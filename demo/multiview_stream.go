@@ -0,0 +1,140 @@
+package demo
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// MVD2Stream reads a multi-view demo incrementally from any io.Reader, one
+// packet at a time. Unlike the MVD2Parser, the whole demo is never held in
+// memory; only the state needed to decompress the next packet (players,
+// entities, configstrings) is kept.
+type MVD2Stream struct {
+	reader io.Reader
+	parser *MVD2Parser
+	zipped bool
+	count  int // packets read so far
+}
+
+// Setup a parser that is fed individual packets directly with ParsePacket()
+// rather than reading them from a file. This is useful when the packets are
+// coming from somewhere other than a demo file, like a GTV connection.
+func NewMVD2PacketParser() *MVD2Parser {
+	return &MVD2Parser{
+		allDemos: []*pb.MvdDemo{},
+		demo:     &pb.MvdDemo{},
+		index:    -1,
+	}
+}
+
+// Create a new stream from a reader. The magic value is read and checked
+// immediately. GZIP compressed data is detected using the header and is
+// decompressed on the fly.
+func NewMVD2Stream(r io.Reader) (*MVD2Stream, error) {
+	if r == nil {
+		return nil, fmt.Errorf("nil reader")
+	}
+	zipped := false
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %v", err)
+	}
+	var reader io.Reader = br
+	if binary.LittleEndian.Uint16(header) == GZIPMagic {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("error creating gzip reader: %v", err)
+		}
+		reader = zr
+		zipped = true
+	}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return nil, fmt.Errorf("error reading magic: %v", err)
+	}
+	if binary.LittleEndian.Uint32(magic) != MVDMagic {
+		return nil, fmt.Errorf("invalid multi-view demo")
+	}
+	parser := NewMVD2PacketParser()
+	parser.zipped = zipped
+	return &MVD2Stream{
+		reader: reader,
+		parser: parser,
+		zipped: zipped,
+	}, nil
+}
+
+// Read and parse the next packet from the stream. When the end-of-demo marker
+// is reached io.EOF is returned. A stream ending without the marker results in
+// io.ErrUnexpectedEOF.
+func (s *MVD2Stream) Next() (*pb.MvdPacket, error) {
+	data, err := s.NextRaw()
+	if err != nil {
+		return nil, err
+	}
+	packet, err := s.parser.ParsePacket(&data)
+	if err != nil {
+		return nil, fmt.Errorf("packet %d: %v", s.count-1, err)
+	}
+	return packet, nil
+}
+
+// Read the next packet from the stream without parsing it. Skipping parsing
+// will break delta decompression for any packets parsed afterward, this is
+// meant for relaying packets as-is.
+func (s *MVD2Stream) NextRaw() (message.Buffer, error) {
+	size := make([]byte, 2)
+	if _, err := io.ReadFull(s.reader, size); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return message.Buffer{}, err
+	}
+	length := binary.LittleEndian.Uint16(size)
+	if length == 0 { // EoD
+		return message.Buffer{}, io.EOF
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return message.Buffer{}, err
+	}
+	s.count++
+	return message.NewBuffer(data), nil
+}
+
+// Callbacks work the same as they do for the file-based parser.
+func (s *MVD2Stream) RegisterCallback(event int, dofunc func(any)) {
+	s.parser.RegisterCallback(event, dofunc)
+}
+
+// Dynamically remove a particular callback
+func (s *MVD2Stream) UnregisterCallback(msgtype int) {
+	s.parser.UnregisterCallback(msgtype)
+}
+
+// The current state of the demo being read. Only the values needed for
+// decompressing the next packet are populated, no packets are stored.
+func (s *MVD2Stream) GetState() *pb.MvdDemo {
+	return s.parser.GetState()
+}
+
+// Private member accessor
+func (s *MVD2Stream) IsZipped() bool {
+	return s.zipped
+}
+
+// Private member accessor
+func (s *MVD2Stream) GetCount() int {
+	return s.count
+}
@@ -0,0 +1,170 @@
+package demo
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/message"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestMvdStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		demofile string
+		zipped   bool
+	}{
+		{
+			name:     "test1",
+			demofile: "../testdata/test.mvd2",
+		},
+		{
+			name:     "gzip test",
+			demofile: "../testdata/ziptest.mvd2.gz",
+			zipped:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, err := NewMVD2Parser(tc.demofile)
+			if err != nil {
+				t.Fatalf("error creating parser: %v", err)
+			}
+			demos, err := parser.Unmarshal()
+			if err != nil {
+				t.Fatalf("error unmarshalling: %v", err)
+			}
+			want := demos[0].GetPackets()
+
+			f, err := os.Open(tc.demofile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			stream, err := NewMVD2Stream(f)
+			if err != nil {
+				t.Fatalf("NewMVD2Stream() error: %v", err)
+			}
+			if stream.IsZipped() != tc.zipped {
+				t.Errorf("IsZipped() = %t, want %t", stream.IsZipped(), tc.zipped)
+			}
+			i := 0
+			for {
+				packet, err := stream.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error: %v", err)
+				}
+				if i >= len(want) {
+					t.Fatalf("too many packets, want %d", len(want))
+				}
+				if diff := cmp.Diff(want[i], packet, protocmp.Transform()); diff != "" {
+					t.Fatalf("packet %d mismatch (-want +got):\n%s", i, diff)
+				}
+				i++
+			}
+			if i != len(want) {
+				t.Errorf("packet count = %d, want %d", i, len(want))
+			}
+		})
+	}
+}
+
+func TestMvdStreamErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		next bool // error comes from Next() instead of NewMVD2Stream()
+		want error
+	}{
+		{
+			name: "empty",
+			data: []byte{},
+		},
+		{
+			name: "bad magic",
+			data: []byte{'I', 'D', 'M', '2', 0, 0},
+		},
+		{
+			name: "end of demo",
+			data: []byte{'M', 'V', 'D', '2', 0, 0},
+			next: true,
+			want: io.EOF,
+		},
+		{
+			name: "truncated",
+			data: []byte{'M', 'V', 'D', '2', 10, 0, 1, 1},
+			next: true,
+			want: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "missing end of demo",
+			data: []byte{'M', 'V', 'D', '2'},
+			next: true,
+			want: io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stream, err := NewMVD2Stream(bytes.NewReader(tc.data))
+			if !tc.next {
+				if err == nil {
+					t.Errorf("NewMVD2Stream(%v) wanted error, got nil", tc.data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMVD2Stream(%v) error: %v", tc.data, err)
+			}
+			if _, err := stream.Next(); err != tc.want {
+				t.Errorf("Next() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+// Packets from the network can be anything, bad ones should be errors rather
+// than panics or hangs.
+func TestMvdPacketParserMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "configstring before serverdata",
+			data: []byte{MVDSvcConfigString, 1, 0, 'x', 0},
+		},
+		{
+			name: "truncated frame",
+			data: []byte{MVDSvcFrame, 1, 0, 'x', 0},
+		},
+		{
+			name: "frame missing entities",
+			data: []byte{MVDSvcFrame, 0, ClientNumNone},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() {
+				msg := message.NewBuffer(tc.data)
+				_, err := NewMVD2PacketParser().ParsePacket(&msg)
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				if err == nil {
+					t.Errorf("ParsePacket(%v) wanted error, got nil", tc.data)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("ParsePacket(%v) didn't return", tc.data)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package demo
 
 import (
+	"compress/gzip"
+	"fmt"
 	"os"
-	"slices"
+	"strings"
 
 	"github.com/packetflinger/libq2/message"
 	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
 )
 
 type MVD2Writer struct {
@@ -15,6 +19,10 @@ type MVD2Writer struct {
 
 // Creates a new writer struct. All the proto-to-binary writing funcs use this
 // struct as a receiver.
+//
+// The Players and Entities maps of the demo are used as the delta state while
+// writing (the last state written for each player/entity), the same way the
+// parser uses them while reading.
 func NewMVD2Writer(mvd *pb.MvdDemo) *MVD2Writer {
 	return &MVD2Writer{
 		demo: mvd,
@@ -23,20 +31,132 @@ func NewMVD2Writer(mvd *pb.MvdDemo) *MVD2Writer {
 
 // This is the final step when writing a demo. It will write all the binary
 // data generated to a file named from the argument.
+//
+// If the filename ends in ".gz" the data is compressed with GZIP on the way
+// out, the same as q2pro does when recording to a .mvd2.gz file.
 func (w *MVD2Writer) Finalize(name string) error {
-	err := os.WriteFile(name, w.data.Data, 0644)
+	if !strings.HasSuffix(name, ".gz") {
+		return os.WriteFile(name, w.data.Data, 0644)
+	}
+	file, err := os.Create(name)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer file.Close()
+	zw := gzip.NewWriter(file)
+	if _, err := zw.Write(w.data.Data); err != nil {
+		return fmt.Errorf("error compressing demo: %v", err)
+	}
+	return zw.Close()
 }
 
 // This is the top level function for converting a textproto-based multi-view
-// demo to it's proper binary format.
+// demo to it's proper binary format. The result is held in the writer until
+// Finalize() is called.
+//
+// Each MvdPacket is written as a single length-prefixed packet, same as they
+// were read by the parser.
 func (w *MVD2Writer) Marshal() error {
+	out := message.NewBuffer(nil)
+	out.WriteLong(MVDMagic)
+	for i, packet := range w.demo.GetPackets() {
+		msg, err := w.MarshalPacket(packet)
+		if err != nil {
+			return fmt.Errorf("packet %d: %v", i, err)
+		}
+		if msg.Size() > 0xffff {
+			return fmt.Errorf("packet %d: too large (%d bytes)", i, msg.Size())
+		}
+		out.WriteWord(msg.Size())
+		out.Append(msg)
+	}
+	out.WriteShort(0) // end of demo
+	w.data = out
 	return nil
 }
 
+// Write all the messages in a single MvdPacket. When the packet contains
+// serverdata, it's written first along with the configstrings and the first
+// frame as the complete gamestate. The rest of the messages follow in the
+// order: configstrings, unicasts, multicasts, sounds, prints, stuffs and
+// finally frames, the same way q2pro records them.
+func (w *MVD2Writer) MarshalPacket(packet *pb.MvdPacket) (message.Buffer, error) {
+	out := message.NewBuffer(nil)
+	frames := packet.GetFrames()
+	if sd := packet.GetServerdata(); sd != nil {
+		if len(frames) == 0 {
+			return out, fmt.Errorf("serverdata without a frame")
+		}
+		w.demo.Version = sd.GetProtocol()
+		w.demo.Flags = sd.GetFlags()
+		w.demo.Identity = sd.GetIdentity()
+		w.demo.GameDir = sd.GetGameDirectory()
+		w.demo.Dummy = sd.GetDummyClient()
+		w.demo.EntityStateFlags = sd.GetEntitystateFlags()
+		w.demo.PlayerStateFlags = sd.GetPlayerstateFlags()
+		w.demo.Remap = sd.GetRemap()
+		if w.demo.Remap == nil {
+			w.demo.Remap = csRemap
+		}
+		w.demo.Configstrings = packet.GetConfigstrings()
+
+		// the gamestate isn't compressed against anything
+		w.demo.Players = make(map[int32]*pb.MvdPlayer)
+		w.demo.Entities = make(map[int32]*pb.PackedEntity)
+
+		extra := 0
+		if sd.GetProtocol() < ProtocolPlusPlus {
+			extra = int(sd.GetFlags()) << CommandBits
+		}
+		out.WriteByte(MVDSvcServerData | extra)
+		out.Append(w.MarshalServerData())
+		out.Append(w.MarshalConfigstrings(packet.GetConfigstrings()))
+		out.Append(w.MarshalFrame(frames[0]))
+		frames = frames[1:]
+	} else {
+		for _, k := range util.SortedKeys(packet.GetConfigstrings()) {
+			out.WriteByte(MVDSvcConfigString)
+			out.Append(w.MarshalConfigstring(packet.GetConfigstrings()[k]))
+		}
+	}
+	for _, uc := range packet.GetUnicasts() {
+		msg, err := w.MarshalUnicast(uc)
+		if err != nil {
+			return out, err
+		}
+		out.Append(msg)
+	}
+	for _, mc := range packet.GetMulticasts() {
+		msg, err := w.MarshalMulticast(mc)
+		if err != nil {
+			return out, err
+		}
+		out.Append(*msg)
+	}
+	for _, snd := range packet.GetSounds() {
+		out.WriteByte(MVDSvcSound)
+		msg, err := w.MarshalSound(snd)
+		if err != nil {
+			return out, err
+		}
+		out.Append(msg)
+	}
+	for _, pr := range packet.GetPrints() {
+		out.Append(w.MarshalPrint(pr))
+	}
+	for _, st := range packet.GetStuffs() {
+		out.Append(w.MarshalStuffText(st))
+	}
+	for _, fr := range frames {
+		out.WriteByte(MVDSvcFrame)
+		out.Append(w.MarshalFrame(fr))
+	}
+	if packet.GetDisconnect() {
+		out.Append(w.MarshalDisconnect())
+	}
+	return out, nil
+}
+
 func (w *MVD2Writer) MarshalServerData() message.Buffer {
 	out := message.NewBuffer(nil)
 	out.WriteLongP(37)
@@ -52,8 +172,8 @@ func (w *MVD2Writer) MarshalServerData() message.Buffer {
 
 func (w *MVD2Writer) MarshalConfigstrings(data map[int32]*pb.ConfigString) message.Buffer {
 	out := message.NewBuffer(nil)
-	for _, cs := range data {
-		out.Append(w.MarshalConfigstring(cs))
+	for _, k := range util.SortedKeys(data) {
+		out.Append(w.MarshalConfigstring(data[k]))
 	}
 	out.WriteShortP(w.demo.GetRemap().GetEnd())
 	return out
@@ -66,73 +186,448 @@ func (w *MVD2Writer) MarshalConfigstring(data *pb.ConfigString) message.Buffer {
 	return out
 }
 
-// Generate a binary buffer from a PackedSound proto
+// Generate a binary buffer from a PackedSound proto. Multi-view sounds are
+// always attached to an entity, there is no position.
 func (w *MVD2Writer) MarshalSound(sound *pb.PackedSound) (message.Buffer, error) {
 	out := message.Buffer{}
-	out.WriteByte(int(sound.Flags))
-	if sound.Index > 255 {
-		out.WriteWordP(sound.Index)
+	flags := sound.GetFlags()
+	if sound.GetIndex() > 255 {
+		if !w.demo.GetRemap().GetExtended() {
+			return out, fmt.Errorf("sound index %d out of range", sound.GetIndex())
+		}
+		flags |= message.SoundIndex16
+	}
+	out.WriteByteP(flags)
+	if (flags & message.SoundIndex16) != 0 {
+		out.WriteWordP(sound.GetIndex())
 	} else {
-		out.WriteByteP(sound.Index)
+		out.WriteByteP(sound.GetIndex())
 	}
+	if (flags & message.SoundVolume) != 0 {
+		out.WriteByteP(sound.GetVolume())
+	}
+	if (flags & message.SoundAttenuation) != 0 {
+		out.WriteByteP(sound.GetAttenuation())
+	}
+	if (flags & message.SoundOffset) != 0 {
+		out.WriteByteP(sound.GetTimeOffset())
+	}
+	out.WriteWordP((sound.GetEntity() << 3) | (sound.GetChannel() & 7))
 	return out, nil
 }
 
-// Generate a binary buffer from a Multicast proto
+// Generate a binary buffer from a Multicast proto, including the command. The
+// upper bits of the length are multiplexed into the command byte. Only the
+// PHS/PVS types include a leaf number.
 func (w *MVD2Writer) MarshalMulticast(mc *pb.MvdMulticast) (*message.Buffer, error) {
 	out := message.Buffer{}
-	out.WriteByteP(uint32(len(mc.Data)))
-	if mc.Leaf != 0 {
-		out.WriteWordP(uint32(mc.Leaf))
+	if mc.GetType() < 0 || mc.GetType() > MVDSvcMulticastPVSR-MVDSvcMulticastAll {
+		return nil, fmt.Errorf("invalid multicast type: %d", mc.GetType())
 	}
-	out.WriteData(mc.Data)
+	payload := marshalPayload(mc)
+	length := payload.Size()
+	if length >= 1<<(8+8-CommandBits) {
+		return nil, fmt.Errorf("multicast too large: %d bytes", length)
+	}
+	out.WriteByte((MVDSvcMulticastAll + int(mc.GetType())) | ((length >> 8) << CommandBits))
+	out.WriteByte(length & 0xff)
+	if mc.GetType()%3 != 0 {
+		out.WriteWordP(uint32(mc.GetLeaf()))
+	}
+	out.Append(payload)
 	return &out, nil
 }
 
+// Generate a binary buffer from a Unicast proto, including the command. The
+// payload is a regular server message stream meant for a single client. Like
+// multicasts, the upper bits of the length are multiplexed into the command.
+func (w *MVD2Writer) MarshalUnicast(uc *pb.MvdUnicast) (message.Buffer, error) {
+	out := message.NewBuffer(nil)
+	payload := marshalPayload(uc)
+	length := payload.Size()
+	if length >= 1<<(8+8-CommandBits) {
+		return out, fmt.Errorf("unicast too large: %d bytes", length)
+	}
+	cmd := MVDSvcUnicast
+	if uc.GetReliable() {
+		cmd = MVDSvcUnicastReliable
+	}
+	out.WriteByte(cmd | ((length >> 8) << CommandBits))
+	out.WriteByte(length & 0xff)
+	out.WriteByte(int(uc.GetClientNumber()))
+	out.Append(payload)
+	return out, nil
+}
+
+// The messages unicasts and multicasts can both carry
+type payloadMessages interface {
+	GetLayouts() []*pb.Layout
+	GetConfigstrings() []*pb.ConfigString
+	GetPrints() []*pb.Print
+	GetStuffs() []*pb.StuffText
+	GetCenterprints() []*pb.CenterPrint
+	GetSounds() []*pb.PackedSound
+	GetTempEnts() []*pb.TemporaryEntity
+	GetMuzzleFlashes() []*pb.MuzzleFlash
+	GetMuzzleFlashes2() []*pb.MuzzleFlash
+	GetInventories() []*pb.Inventory
+	GetDisconnect() bool
+	GetReconnect() bool
+	GetOrder() []int32
+	GetUnknown() []byte
+}
+
+// The order messages that weren't parsed from a payload are written in
+var payloadOrder = []int32{
+	SvcLayout, SvcConfigString, SvcPrint, SvcStuffText, SvcCenterprint,
+	SvcSound, SvcTemporaryEntity, SvcMuzzleFlash, SvcMuzzleFlash2,
+	SvcInventory, SvcDisconnect, SvcReconnect,
+}
+
+// Write the messages of a unicast or multicast payload in the order they
+// were parsed, so an unchanged payload is written exactly as it was read.
+// Messages that aren't in that order (added or left over after editing) go
+// after them in payloadOrder, and anything the parser couldn't read goes
+// last as it was.
+func marshalPayload(p payloadMessages) message.Buffer {
+	out := message.NewBuffer(nil)
+	written := make(map[int32]int)
+	write := func(cmd int32) bool {
+		i := written[cmd]
+		var body message.Buffer
+		switch cmd {
+		case SvcLayout:
+			if i >= len(p.GetLayouts()) {
+				return false
+			}
+			body = message.MarshalLayout(p.GetLayouts()[i])
+		case SvcConfigString:
+			if i >= len(p.GetConfigstrings()) {
+				return false
+			}
+			cs := message.MarshalConfigstring(p.GetConfigstrings()[i])
+			body = message.NewBuffer(cs.Data[1:]) // without the command
+		case SvcPrint:
+			if i >= len(p.GetPrints()) {
+				return false
+			}
+			body = message.MarshalPrint(p.GetPrints()[i])
+		case SvcStuffText:
+			if i >= len(p.GetStuffs()) {
+				return false
+			}
+			body = message.MarshalStuffText(p.GetStuffs()[i])
+		case SvcCenterprint:
+			if i >= len(p.GetCenterprints()) {
+				return false
+			}
+			body = message.MarshalCenterPrint(p.GetCenterprints()[i])
+		case SvcSound:
+			if i >= len(p.GetSounds()) {
+				return false
+			}
+			body = message.MarshalSound(p.GetSounds()[i])
+		case SvcTemporaryEntity:
+			if i >= len(p.GetTempEnts()) {
+				return false
+			}
+			body = message.MarshalTempEntity(p.GetTempEnts()[i])
+		case SvcMuzzleFlash:
+			if i >= len(p.GetMuzzleFlashes()) {
+				return false
+			}
+			body = message.MarshalFlash(p.GetMuzzleFlashes()[i])
+		case SvcMuzzleFlash2:
+			if i >= len(p.GetMuzzleFlashes2()) {
+				return false
+			}
+			body = message.MarshalFlash(p.GetMuzzleFlashes2()[i])
+		case SvcInventory:
+			if i >= len(p.GetInventories()) {
+				return false
+			}
+			body = message.MarshalInventory(p.GetInventories()[i])
+		case SvcDisconnect:
+			if i > 0 || !p.GetDisconnect() {
+				return false
+			}
+		case SvcReconnect:
+			if i > 0 || !p.GetReconnect() {
+				return false
+			}
+		case SvcNoOperation:
+			// nothing else to write
+		default:
+			return false
+		}
+		out.WriteByte(int(cmd))
+		out.Append(body)
+		written[cmd]++
+		return true
+	}
+	for _, cmd := range p.GetOrder() {
+		write(cmd)
+	}
+	for _, cmd := range payloadOrder {
+		for write(cmd) {
+		}
+	}
+	out.WriteData(p.GetUnknown())
+	return out
+}
+
+// Generate a binary buffer from a Print proto, including the command.
+func (w *MVD2Writer) MarshalPrint(pr *pb.Print) message.Buffer {
+	out := message.NewBuffer(nil)
+	out.WriteByte(MVDSvcPrint)
+	out.Append(message.MarshalPrint(pr))
+	return out
+}
+
+// Generate a binary buffer from a StuffText proto, including the command.
+// These are commands sent to all spectators of the stream.
+func (w *MVD2Writer) MarshalStuffText(st *pb.StuffText) message.Buffer {
+	out := message.NewBuffer(nil)
+	out.WriteByte(MVDSvcStuffText)
+	out.Append(message.MarshalStuffText(st))
+	return out
+}
+
+// A nop has no payload, it's just the command.
+func (w *MVD2Writer) MarshalNop() message.Buffer {
+	return message.NewBuffer([]byte{MVDSvcNop})
+}
+
+// A disconnect has no payload, it's just the command.
+func (w *MVD2Writer) MarshalDisconnect() message.Buffer {
+	return message.NewBuffer([]byte{MVDSvcDisconnect})
+}
+
+// Each frame contains portal data, then all the player POVs (delta
+// compressed), and then all the compressed entities.
 func (w *MVD2Writer) MarshalFrame(frame *pb.MvdFrame) message.Buffer {
 	out := message.NewBuffer(nil)
 	out.WriteByte(len(frame.GetPortalData()))
 	out.WriteData(frame.GetPortalData())
-	out.Append(w.MarshalPlayers(frame.GetPlayers()))
+	out.Append(w.marshalPlayers(frame.GetPlayers(), frame.GetRemovedPlayers()))
+	out.Append(w.MarshalEntities(frame.GetEntities()))
 	return out
 }
 
+// Write all the players in the frame in numeric order followed by any that
+// were removed during the frame.
 func (w *MVD2Writer) MarshalPlayers(players map[int32]*pb.PackedPlayer) message.Buffer {
+	return w.marshalPlayers(players, nil)
+}
+
+func (w *MVD2Writer) marshalPlayers(players map[int32]*pb.PackedPlayer, removed []int32) message.Buffer {
 	out := message.NewBuffer(nil)
-	for num, pl := range players {
-		out.Append(w.MarshalPlayer(num, pl))
+	for _, num := range util.SortedKeys(players) {
+		out.Append(w.MarshalPlayer(num, players[num]))
+	}
+	for _, num := range removed {
+		out.WriteByte(int(num))
+		out.WriteWord(MvdPlayerRemove)
 	}
 	out.WriteByte(ClientNumNone)
 	return out
 }
 
+// Write a single player delta compressed against the last state written for
+// that player number. The player is always written, even when nothing
+// changed.
 func (w *MVD2Writer) MarshalPlayer(num int32, player *pb.PackedPlayer) message.Buffer {
 	out := message.NewBuffer(nil)
-
-	from := w.demo.GetPlayers()[num]
-	bits := message.DeltaPlayerBitmask(from.GetPlayerState(), player)
-
+	if w.demo.Players == nil {
+		w.demo.Players = make(map[int32]*pb.MvdPlayer)
+	}
+	pl, ok := w.demo.GetPlayers()[num]
+	if !ok {
+		pl = &pb.MvdPlayer{}
+		w.demo.Players[num] = pl
+	}
+	from := pl.GetPlayerState()
+	bits := DeltaMvdPlayerBitmask(from, player)
 	out.WriteByte(int(num))
 	out.WriteWord(bits)
-	out.Append(message.WriteDeltaPlayerstate(from.GetPlayerState(), player))
+	out.Append(w.WriteDeltaPlayer(from, player, bits))
+	pl.PlayerState = player
+	pl.InUse = true
 	return out
 }
 
+// DeltaMvdPlayerBitmask returns the multi-view bitmask representing the
+// differences between two playerstates. This is not the same set of bits
+// used by regular (DM2) playerstates.
+func DeltaMvdPlayerBitmask(from *pb.PackedPlayer, to *pb.PackedPlayer) int {
+	mask := 0
+	mf := from.GetMovestate()
+	mt := to.GetMovestate()
+
+	if mf.GetType() != mt.GetType() {
+		mask |= MvdPlayerType
+	}
+	if mf.GetOriginX() != mt.GetOriginX() || mf.GetOriginY() != mt.GetOriginY() {
+		mask |= MvdPlayerOrigin
+	}
+	if mf.GetOriginZ() != mt.GetOriginZ() {
+		mask |= MvdPlayerOrigin2
+	}
+	if from.GetViewOffsetX() != to.GetViewOffsetX() || from.GetViewOffsetY() != to.GetViewOffsetY() || from.GetViewOffsetZ() != to.GetViewOffsetZ() {
+		mask |= MvdPlayerViewOffset
+	}
+	if from.GetViewAnglesX() != to.GetViewAnglesX() || from.GetViewAnglesY() != to.GetViewAnglesY() {
+		mask |= MvdPlayerViewAngles
+	}
+	if from.GetViewAnglesZ() != to.GetViewAnglesZ() {
+		mask |= MvdPlayerViewAngles2
+	}
+	if from.GetKickAnglesX() != to.GetKickAnglesX() || from.GetKickAnglesY() != to.GetKickAnglesY() || from.GetKickAnglesZ() != to.GetKickAnglesZ() {
+		mask |= MvdPlayerKickAngles
+	}
+	if from.GetBlendW() != to.GetBlendW() || from.GetBlendX() != to.GetBlendX() || from.GetBlendY() != to.GetBlendY() || from.GetBlendZ() != to.GetBlendZ() {
+		mask |= MvdPlayerBlend
+	}
+	if from.GetFov() != to.GetFov() {
+		mask |= MvdPlayerFov
+	}
+	if from.GetRdFlags() != to.GetRdFlags() {
+		mask |= MvdPlayerRdFlags
+	}
+	if from.GetGunIndex() != to.GetGunIndex() {
+		mask |= MvdPlayerWeaponIndex
+	}
+	if from.GetGunFrame() != to.GetGunFrame() {
+		mask |= MvdPlayerWeaponFrame
+	}
+	if from.GetGunOffsetX() != to.GetGunOffsetX() || from.GetGunOffsetY() != to.GetGunOffsetY() || from.GetGunOffsetZ() != to.GetGunOffsetZ() {
+		mask |= MvdPlayerGunOffset
+	}
+	if from.GetGunAnglesX() != to.GetGunAnglesX() || from.GetGunAnglesY() != to.GetGunAnglesY() || from.GetGunAnglesZ() != to.GetGunAnglesZ() {
+		mask |= MvdPlayerGunAngles
+	}
+	if deltaStatsBitmask(from.GetStats(), to.GetStats()) != 0 {
+		mask |= MvdPlayerStats
+	}
+	return mask
+}
+
+// Which stats are different between two playerstates. A stat present in the
+// `to` map is also included if it's missing from `from`, even if it's zero.
+func deltaStatsBitmask(from, to map[uint32]int32) uint32 {
+	bits := uint32(0)
+	for i := uint32(0); i < MaxStats; i++ {
+		fv, fok := from[i]
+		tv, tok := to[i]
+		if fv != tv || (tok && !fok) {
+			bits |= 1 << i
+		}
+	}
+	return bits
+}
+
+// WriteDeltaPlayer writes the fields of a multi-view playerstate flagged in
+// bits. This is the inverse of MVD2Parser.ParseDeltaPlayer().
+func (w *MVD2Writer) WriteDeltaPlayer(from *pb.PackedPlayer, to *pb.PackedPlayer, bits int) message.Buffer {
+	out := message.NewBuffer(nil)
+	pm := to.GetMovestate()
+	if (bits & MvdPlayerType) != 0 {
+		out.WriteByteP(pm.GetType())
+	}
+	if (bits & MvdPlayerOrigin) != 0 {
+		out.WriteShortP(pm.GetOriginX())
+		out.WriteShortP(pm.GetOriginY())
+	}
+	if (bits & MvdPlayerOrigin2) != 0 {
+		out.WriteShortP(pm.GetOriginZ())
+	}
+	if (bits & MvdPlayerViewOffset) != 0 {
+		out.WriteCharP(to.GetViewOffsetX())
+		out.WriteCharP(to.GetViewOffsetY())
+		out.WriteCharP(to.GetViewOffsetZ())
+	}
+	if (bits & MvdPlayerViewAngles) != 0 {
+		out.WriteShortP(to.GetViewAnglesX())
+		out.WriteShortP(to.GetViewAnglesY())
+	}
+	if (bits & MvdPlayerViewAngles2) != 0 {
+		out.WriteShortP(to.GetViewAnglesZ())
+	}
+	if (bits & MvdPlayerKickAngles) != 0 {
+		out.WriteCharP(to.GetKickAnglesX())
+		out.WriteCharP(to.GetKickAnglesY())
+		out.WriteCharP(to.GetKickAnglesZ())
+	}
+	if (bits & MvdPlayerWeaponIndex) != 0 {
+		if (w.demo.GetPlayerStateFlags() & MvdPlayerFlagExtensions) != 0 {
+			out.WriteWordP(to.GetGunIndex())
+		} else {
+			out.WriteByteP(to.GetGunIndex())
+		}
+	}
+	if (bits & MvdPlayerWeaponFrame) != 0 {
+		out.WriteByteP(to.GetGunFrame())
+	}
+	if (bits & MvdPlayerGunOffset) != 0 {
+		out.WriteCharP(to.GetGunOffsetX())
+		out.WriteCharP(to.GetGunOffsetY())
+		out.WriteCharP(to.GetGunOffsetZ())
+	}
+	if (bits & MvdPlayerGunAngles) != 0 {
+		out.WriteCharP(to.GetGunAnglesX())
+		out.WriteCharP(to.GetGunAnglesY())
+		out.WriteCharP(to.GetGunAnglesZ())
+	}
+	if (bits & MvdPlayerBlend) != 0 {
+		out.WriteByte(int(to.GetBlendW()))
+		out.WriteByte(int(to.GetBlendX()))
+		out.WriteByte(int(to.GetBlendY()))
+		out.WriteByte(int(to.GetBlendZ()))
+	}
+	if (bits & MvdPlayerFov) != 0 {
+		out.WriteByteP(to.GetFov())
+	}
+	if (bits & MvdPlayerRdFlags) != 0 {
+		out.WriteByteP(to.GetRdFlags())
+	}
+	if (bits & MvdPlayerStats) != 0 {
+		statbits := deltaStatsBitmask(from.GetStats(), to.GetStats())
+		out.WriteLong(int(statbits))
+		for i := uint32(0); i < MaxStats; i++ {
+			if (statbits & (1 << i)) != 0 {
+				out.WriteShortP(to.GetStats()[i])
+			}
+		}
+	}
+	return out
+}
+
+// Write all the entities transmitted in the frame, delta compressed against
+// the last state written for each.
 func (w *MVD2Writer) MarshalEntities(ents map[int32]*pb.PackedEntity) message.Buffer {
 	out := message.NewBuffer(nil)
+	if w.demo.Entities == nil {
+		w.demo.Entities = make(map[int32]*pb.PackedEntity)
+	}
 
 	// ents need to be in numeric order and maps are not guaranteed to give
 	// their values in the order they were added. So export the keys and
 	// sort them.
-	var keys []int32
-	for k := range ents {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
+	for _, k := range util.SortedKeys(ents) {
+		from := w.demo.GetEntities()[k]
+		out.Append(message.WriteDeltaEntity(from, ents[k]))
 
-	for _, k := range keys {
-		out.Append(message.WriteDeltaEntity(ents[k], w.demo.GetEntities()[k]))
+		// events only last a single frame, they're not part of the state
+		// the next frame is compressed against
+		last := proto.Clone(ents[k]).(*pb.PackedEntity)
+		last.Event = 0
+		w.demo.Entities[k] = last
 	}
 	out.WriteShort(0) // combined bitmask and number
 	return out
 }
+
+// The binary demo generated by Marshal()
+func (w *MVD2Writer) GetData() []byte {
+	return w.data.Data
+}
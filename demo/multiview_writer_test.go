@@ -2,9 +2,14 @@ package demo
 
 import (
 	"encoding/hex"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/message"
 	pb "github.com/packetflinger/libq2/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestMvdMarshalServerData(t *testing.T) {
@@ -166,7 +171,7 @@ func TestMvdMarshalPlayer(t *testing.T) {
 					1: 100,
 				},
 			},
-			want: "0202001102000a0019000000020000006400",
+			want: "0202400a001900020000006400",
 		},
 		{
 			name:   "player3",
@@ -191,7 +196,7 @@ func TestMvdMarshalPlayer(t *testing.T) {
 					1: 100,
 				},
 			},
-			want: "0302081102080a0019000000690a00000064000000",
+			want: "0302410a001900690a00000064000000",
 		},
 	}
 	for _, tc := range tests {
@@ -272,7 +277,7 @@ func TestMvdMarshalPlayers(t *testing.T) {
 					},
 				},
 			},
-			want: "????",
+			want: "030000040000ff",
 		},
 	}
 	for _, tc := range tests {
@@ -288,3 +293,188 @@ func TestMvdMarshalPlayers(t *testing.T) {
 		})
 	}
 }
+
+func TestMvdMarshalSound(t *testing.T) {
+	tests := []struct {
+		name  string
+		remap *pb.MvdConfigStringRemap
+		sound *pb.PackedSound
+		want  string
+		err   bool
+	}{
+		{
+			name:  "index only",
+			remap: csRemap,
+			sound: &pb.PackedSound{
+				Index:   12,
+				Entity:  5,
+				Channel: 2,
+			},
+			want: "000c2a00",
+		},
+		{
+			name:  "volume and attenuation",
+			remap: csRemap,
+			sound: &pb.PackedSound{
+				Flags:       message.SoundVolume | message.SoundAttenuation,
+				Index:       3,
+				Volume:      128,
+				Attenuation: 64,
+				Entity:      1,
+				Channel:     1,
+			},
+			want: "030380400900",
+		},
+		{
+			name:  "large index standard remap",
+			remap: csRemap,
+			sound: &pb.PackedSound{
+				Index: 300,
+			},
+			err: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := NewMVD2Writer(&pb.MvdDemo{
+				Remap: tc.remap,
+			})
+			msg, err := writer.MarshalSound(tc.sound)
+			if (err != nil) != tc.err {
+				t.Fatalf("MarshalSound(%v) error = %v, want error %t", tc.sound, err, tc.err)
+			}
+			got := hex.EncodeToString(msg.Data)
+			want := tc.want
+			if !tc.err && got != want {
+				t.Errorf("MarshalSound(%v) = %s, want %s\n", tc.sound, got, want)
+			}
+		})
+	}
+}
+
+func TestMvdMarshalMulticast(t *testing.T) {
+	tests := []struct {
+		name string
+		mc   *pb.MvdMulticast
+		want string
+	}{
+		{
+			name: "all, no leaf",
+			mc: &pb.MvdMulticast{
+				Type:      0,
+				Reconnect: true,
+				Order:     []int32{SvcNoOperation, SvcReconnect},
+			},
+			want: "0a020608",
+		},
+		{
+			name: "phs with leaf",
+			mc: &pb.MvdMulticast{
+				Type:       1,
+				Leaf:       260,
+				Disconnect: true,
+			},
+			want: "0b01040107",
+		},
+		{
+			name: "all reliable, no leaf",
+			mc: &pb.MvdMulticast{
+				Type:    3,
+				Unknown: []byte{9},
+			},
+			want: "0d0109",
+		},
+		{
+			name: "in order, then the rest",
+			mc: &pb.MvdMulticast{
+				Type:          0,
+				MuzzleFlashes: []*pb.MuzzleFlash{{Entity: 3, Weapon: 1}, {Entity: 4, Weapon: 2}},
+				Layouts:       []*pb.Layout{{Data: "x"}},
+				Order:         []int32{SvcMuzzleFlash},
+			},
+			want: "0a0b01030001047800" + "01040002",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := NewMVD2Writer(&pb.MvdDemo{})
+			msg, err := writer.MarshalMulticast(tc.mc)
+			if err != nil {
+				t.Fatalf("MarshalMulticast(%v) error: %v", tc.mc, err)
+			}
+			got := hex.EncodeToString(msg.Data)
+			if got != tc.want {
+				t.Errorf("MarshalMulticast(%v) = %s, want %s\n", tc.mc, got, tc.want)
+			}
+		})
+	}
+}
+
+// Parse a demo, write it back out and parse the result. The packets should be
+// identical.
+func TestMvdMarshalRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		demofile string
+		outfile  string
+	}{
+		{
+			name:     "test1",
+			demofile: "../testdata/test.mvd2",
+			outfile:  "out.mvd2",
+		},
+		{
+			name:     "gzip test",
+			demofile: "../testdata/ziptest.mvd2.gz",
+			outfile:  "out.mvd2.gz",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, err := NewMVD2Parser(tc.demofile)
+			if err != nil {
+				t.Fatalf("error creating parser: %v", err)
+			}
+			demos, err := parser.Unmarshal()
+			if err != nil {
+				t.Fatalf("error unmarshalling: %v", err)
+			}
+			want := demos[0]
+
+			writer := NewMVD2Writer(&pb.MvdDemo{Packets: want.GetPackets()})
+			if err := writer.Marshal(); err != nil {
+				t.Fatalf("error marshalling: %v", err)
+			}
+			outfile := filepath.Join(t.TempDir(), tc.outfile)
+			if err := writer.Finalize(outfile); err != nil {
+				t.Fatalf("error writing demo: %v", err)
+			}
+
+			parser, err = NewMVD2Parser(outfile)
+			if err != nil {
+				t.Fatalf("error creating parser: %v", err)
+			}
+			if parser.IsZipped() != strings.HasSuffix(outfile, ".gz") {
+				t.Errorf("IsZipped() = %t for %s", parser.IsZipped(), outfile)
+			}
+			got, err := parser.Unmarshal()
+			if err != nil {
+				t.Fatalf("error unmarshalling written demo: %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("demo count mismatch, got %d, want 1", len(got))
+			}
+			if len(got[0].GetPackets()) != len(want.GetPackets()) {
+				t.Fatalf("packet count mismatch, got %d, want %d", len(got[0].GetPackets()), len(want.GetPackets()))
+			}
+			for i, pkt := range got[0].GetPackets() {
+				// the player attached to a unicast is just a snapshot of the
+				// state when the unicast was parsed, it's not written
+				ignore := protocmp.IgnoreFields(&pb.MvdUnicast{}, "player")
+				if diff := cmp.Diff(want.GetPackets()[i], pkt, protocmp.Transform(), ignore); diff != "" {
+					t.Fatalf("packet %d mismatch (-want +got):\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
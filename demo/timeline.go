@@ -0,0 +1,178 @@
+package demo
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/player"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// What kind of message a timeline entry is
+type TimelineKind int
+
+const (
+	TimelineSay         TimelineKind = iota // player chat to everyone
+	TimelineTeamSay                         // player chat to their team
+	TimelineServer                          // server messages and console chat
+	TimelineObituary                        // someone died
+	TimelineMessage                         // other prints (pickups, etc)
+	TimelineCenterprint                     // text in the middle of the screen
+)
+
+// The name the server uses when chatting from the console
+const ConsoleName = "console"
+
+// Something that was said or printed during a demo.
+type TimelineEntry struct {
+	Kind       TimelineKind
+	Frame      int32         // server frame number
+	Time       int32         // msecs since the start of the demo
+	Level      uint32        // print level, 0 for centerprints
+	Text       string        // the message, without the trailing newline
+	Speaker    string        // who said it, for chat
+	Death      *player.Death // for obituaries
+	Recipients []int32       // mvd2, clients a unicast print went to
+}
+
+func (k TimelineKind) String() string {
+	switch k {
+	case TimelineSay:
+		return "say"
+	case TimelineTeamSay:
+		return "say_team"
+	case TimelineServer:
+		return "server"
+	case TimelineObituary:
+		return "obituary"
+	case TimelineMessage:
+		return "message"
+	case TimelineCenterprint:
+		return "centerprint"
+	}
+	return "unknown"
+}
+
+// Build the chat/obituary/centerprint timeline of a DM2 demo. Times come from
+// the demo's frame rate, or the default when it doesn't have one.
+func DM2Timeline(demo *pb.DM2Demo) []TimelineEntry {
+	var out []TimelineEntry
+	for _, ev := range DM2Events(demo, DefaultFrameRate) {
+		if pr := ev.GetPrint(); pr != nil {
+			out = append(out, printEntry(ev, pr))
+		}
+		if cp := ev.GetCenterprint(); cp != nil {
+			out = append(out, centerprintEntry(ev, cp))
+		}
+	}
+	return out
+}
+
+// Build the chat/obituary/centerprint timeline of a multi-view demo.
+//
+// Broadcast prints happen once, but the server sends chat (and most
+// centerprints) to each recipient separately. Identical unicast messages in
+// the same packet are merged into one entry listing all the clients that got
+// it, which also shows who was on the speaker's team for team chat.
+func MVD2Timeline(demo *pb.MvdDemo) []TimelineEntry {
+	var out []TimelineEntry
+	packet := int32(-1)
+	seen := make(map[printKey]int) // index in out, per packet
+	for _, ev := range MVD2Events(demo, DefaultFrameRate) {
+		if ev.GetPacket() != packet {
+			packet = ev.GetPacket()
+			clear(seen)
+		}
+		if pr := ev.GetPrint(); pr != nil {
+			out = append(out, printEntry(ev, pr))
+		}
+		if uc := ev.GetUnicast(); uc != nil {
+			client := uc.GetClientNumber()
+			unicast := func(key printKey, entry TimelineEntry) {
+				if i, ok := seen[key]; ok {
+					if !slices.Contains(out[i].Recipients, client) {
+						out[i].Recipients = append(out[i].Recipients, client)
+					}
+					return
+				}
+				entry.Recipients = []int32{client}
+				seen[key] = len(out)
+				out = append(out, entry)
+			}
+			for _, pr := range uc.GetPrints() {
+				unicast(printKey{level: pr.GetLevel(), text: pr.GetData()}, printEntry(ev, pr))
+			}
+			for _, cp := range uc.GetCenterprints() {
+				unicast(printKey{center: true, text: cp.GetData()}, centerprintEntry(ev, cp))
+			}
+		}
+		if mc := ev.GetMulticast(); mc != nil {
+			for _, cp := range mc.GetCenterprints() {
+				out = append(out, centerprintEntry(ev, cp))
+			}
+		}
+	}
+	return out
+}
+
+type printKey struct {
+	level  uint32
+	center bool
+	text   string
+}
+
+func centerprintEntry(ev *pb.DemoEvent, cp *pb.CenterPrint) TimelineEntry {
+	return TimelineEntry{
+		Kind:  TimelineCenterprint,
+		Frame: ev.GetFrame(),
+		Time:  ev.GetServerTime(),
+		Text:  strings.TrimRight(cp.GetData(), "\n"),
+	}
+}
+
+// Sort out what kind of print it is from the level and the text
+func printEntry(ev *pb.DemoEvent, pr *pb.Print) TimelineEntry {
+	entry := TimelineEntry{
+		Kind:  TimelineMessage,
+		Frame: ev.GetFrame(),
+		Time:  ev.GetServerTime(),
+		Level: pr.GetLevel(),
+		Text:  strings.TrimRight(pr.GetData(), "\n"),
+	}
+	switch pr.GetLevel() {
+	case message.PrintLevelChat:
+		entry.Kind = TimelineSay
+		entry.Speaker, _ = chatSpeaker(entry.Text)
+		if strings.HasPrefix(entry.Text, "(") {
+			entry.Kind = TimelineTeamSay
+		}
+		if entry.Speaker == ConsoleName || entry.Speaker == "" {
+			entry.Kind = TimelineServer
+		}
+	case message.PrintLevelHigh:
+		entry.Kind = TimelineServer
+	case message.PrintLevelObit:
+		if death, err := player.CalculateDeath(strings.TrimSpace(entry.Text)); err == nil {
+			entry.Kind = TimelineObituary
+			entry.Death = &death
+		}
+	}
+	return entry
+}
+
+// Split a chat line into the speaker and what they said. Team chat has the
+// name in parentheses: "(name): text".
+func chatSpeaker(line string) (string, string) {
+	if strings.HasPrefix(line, "(") {
+		if name, text, ok := strings.Cut(line[1:], "): "); ok {
+			return name, text
+		}
+	}
+	name, text, ok := strings.Cut(line, ": ")
+	if !ok {
+		return "", line
+	}
+	return name, text
+}
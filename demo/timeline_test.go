@@ -0,0 +1,140 @@
+package demo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/player"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+func TestPrintEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		print *pb.Print
+		want  TimelineEntry
+	}{
+		{
+			name:  "say",
+			print: &pb.Print{Level: message.PrintLevelChat, Data: "claire: hi: there\n"},
+			want:  TimelineEntry{Kind: TimelineSay, Level: 3, Text: "claire: hi: there", Speaker: "claire"},
+		},
+		{
+			name:  "team say",
+			print: &pb.Print{Level: message.PrintLevelChat, Data: "(claire): quad up\n"},
+			want:  TimelineEntry{Kind: TimelineTeamSay, Level: 3, Text: "(claire): quad up", Speaker: "claire"},
+		},
+		{
+			name:  "console",
+			print: &pb.Print{Level: message.PrintLevelChat, Data: "console: restarting\n"},
+			want:  TimelineEntry{Kind: TimelineServer, Level: 3, Text: "console: restarting", Speaker: "console"},
+		},
+		{
+			name:  "server",
+			print: &pb.Print{Level: message.PrintLevelHigh, Data: "bob entered the game\n"},
+			want:  TimelineEntry{Kind: TimelineServer, Level: 2, Text: "bob entered the game"},
+		},
+		{
+			name:  "obituary",
+			print: &pb.Print{Level: message.PrintLevelObit, Data: "bob ate alice's rocket\n"},
+			want: TimelineEntry{
+				Kind:  TimelineObituary,
+				Level: 1,
+				Text:  "bob ate alice's rocket",
+				Death: &player.Death{Murderer: "alice", Victim: "bob", Means: player.ModRocket},
+			},
+		},
+		{
+			name:  "medium but not a death",
+			print: &pb.Print{Level: message.PrintLevelObit, Data: "something else\n"},
+			want:  TimelineEntry{Kind: TimelineMessage, Level: 1, Text: "something else"},
+		},
+		{
+			name:  "pickup",
+			print: &pb.Print{Level: message.PrintLevelLow, Data: "You got the quad\n"},
+			want:  TimelineEntry{Kind: TimelineMessage, Level: 0, Text: "You got the quad"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := printEntry(&pb.DemoEvent{}, tc.print)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("printEntry() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDM2Timeline(t *testing.T) {
+	demo := &pb.DM2Demo{
+		Serverinfo: &pb.ServerInfo{Protocol: 34},
+		Frames: map[int32]*pb.Frame{
+			1: {Number: 1},
+			2: {
+				Number:       2,
+				Prints:       []*pb.Print{{Level: message.PrintLevelChat, Data: "bob: hi\n"}},
+				Centerprints: []*pb.CenterPrint{{Data: "Fight!"}},
+			},
+		},
+	}
+	got := DM2Timeline(demo)
+	want := []TimelineEntry{
+		{Kind: TimelineSay, Frame: 2, Time: 200, Level: 3, Text: "bob: hi", Speaker: "bob"},
+		{Kind: TimelineCenterprint, Frame: 2, Time: 200, Text: "Fight!"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DM2Timeline() mismatch (-want +got):\n%s", diff)
+	}
+
+	duel := DM2Timeline(loadDM2(t, "../testdata/testduel.dm2"))
+	counts := make(map[TimelineKind]int)
+	for _, e := range duel {
+		counts[e.Kind]++
+	}
+	if counts[TimelineObituary] != 6 {
+		t.Errorf("testduel.dm2 has %d obituaries, want 6", counts[TimelineObituary])
+	}
+	if counts[TimelineSay] == 0 || counts[TimelineServer] == 0 {
+		t.Errorf("testduel.dm2 timeline is missing chat or server messages: %v", counts)
+	}
+}
+
+func TestMVD2Timeline(t *testing.T) {
+	chat := &pb.Print{Level: message.PrintLevelChat, Data: "(bob): incoming\n"}
+	demo := &pb.MvdDemo{
+		Packets: []*pb.MvdPacket{
+			{
+				Unicasts: []*pb.MvdUnicast{
+					{ClientNumber: 1, Prints: []*pb.Print{chat}},
+					{ClientNumber: 3, Prints: []*pb.Print{chat}},
+				},
+				Multicasts: []*pb.MvdMulticast{{Centerprints: []*pb.CenterPrint{{Data: "Fight!"}}}},
+				Frames:     []*pb.MvdFrame{{}},
+			},
+			{
+				Unicasts: []*pb.MvdUnicast{{ClientNumber: 1, Prints: []*pb.Print{chat}}},
+				Prints:   []*pb.Print{{Level: message.PrintLevelObit, Data: "bob cratered\n"}},
+				Frames:   []*pb.MvdFrame{{}},
+			},
+		},
+	}
+	got := MVD2Timeline(demo)
+	want := []TimelineEntry{
+		{Kind: TimelineTeamSay, Frame: 1, Time: 100, Level: 3, Text: "(bob): incoming", Speaker: "bob", Recipients: []int32{1, 3}},
+		{Kind: TimelineCenterprint, Frame: 1, Time: 100, Text: "Fight!"},
+		{Kind: TimelineTeamSay, Frame: 2, Time: 200, Level: 3, Text: "(bob): incoming", Speaker: "bob", Recipients: []int32{1}},
+		{
+			Kind:  TimelineObituary,
+			Frame: 2,
+			Time:  200,
+			Level: 1,
+			Text:  "bob cratered",
+			Death: &player.Death{Victim: "bob", Means: player.ModFalling, Solo: true},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MVD2Timeline() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,298 @@
+package demo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// A problem found while verifying a demo
+type VerifyError struct {
+	Frame  int32 // 0 for the gamestate
+	Packet int   // mvd only, -1 otherwise
+	Reason string
+}
+
+func (e *VerifyError) Error() string {
+	if e.Packet >= 0 {
+		return fmt.Sprintf("packet %d: %s", e.Packet, e.Reason)
+	}
+	return fmt.Sprintf("frame %d: %s", e.Frame, e.Reason)
+}
+
+// Check a parsed demo (a *pb.DM2Demo or *pb.MvdDemo) for structural problems
+// that would stop it from playing properly. All the problems found are
+// returned joined together, nil means the demo looks fine.
+func Verify(demo proto.Message) error {
+	switch d := demo.(type) {
+	case *pb.DM2Demo:
+		return errors.Join(VerifyDM2(d)...)
+	case *pb.MvdDemo:
+		return errors.Join(VerifyMVD2(d)...)
+	}
+	return fmt.Errorf("unsupported demo type %T", demo)
+}
+
+// Check a regular demo:
+//
+//   - serverdata is present
+//   - configstring indexes are in range and match their map keys
+//   - entity numbers are within MaxEdicts and match their map keys
+//   - frame numbers increase and match their map keys
+//   - delta compressed frames reference an earlier frame that exists
+func VerifyDM2(demo *pb.DM2Demo) []error {
+	var errs []error
+	fail := func(frame int32, format string, a ...any) {
+		errs = append(errs, &VerifyError{Frame: frame, Packet: -1, Reason: fmt.Sprintf(format, a...)})
+	}
+	if demo.GetServerinfo() == nil {
+		fail(0, "missing serverdata")
+	}
+	configs := func(frame int32, cs map[int32]*pb.ConfigString) {
+		for _, k := range util.SortedKeys(cs) {
+			if k < 0 || k >= MaxConfigStrings {
+				fail(frame, "configstring index %d out of range", k)
+			}
+			if int32(cs[k].GetIndex()) != k {
+				fail(frame, "configstring %d has index %d", k, cs[k].GetIndex())
+			}
+		}
+	}
+	entities := func(frame int32, what string, ents map[int32]*pb.PackedEntity) {
+		for _, k := range util.SortedKeys(ents) {
+			if k <= 0 || k >= MaxEdicts {
+				fail(frame, "%s number %d out of range", what, k)
+			}
+			if int32(ents[k].GetNumber()) != k {
+				fail(frame, "%s %d has number %d", what, k, ents[k].GetNumber())
+			}
+		}
+	}
+	configs(0, demo.GetConfigstrings())
+	entities(0, "baseline", demo.GetBaselines())
+
+	last := int32(0)
+	for _, num := range util.SortedKeys(demo.GetFrames()) {
+		fr := demo.GetFrames()[num]
+		if fr.GetNumber() != num {
+			fail(num, "frame stored as %d has number %d", num, fr.GetNumber())
+		}
+		if num <= last {
+			fail(num, "frame number not increasing (previous %d)", last)
+		}
+		last = num
+		if delta := fr.GetDelta(); delta > 0 {
+			if delta >= num {
+				fail(num, "delta frame %d is not earlier", delta)
+			} else if _, ok := demo.GetFrames()[delta]; !ok {
+				fail(num, "delta frame %d missing", delta)
+			}
+		}
+		for k := range fr.GetPlayerState().GetStats() {
+			if k >= MaxStats {
+				fail(num, "stat index %d out of range", k)
+			}
+		}
+		configs(num, fr.GetConfigstrings())
+		entities(num, "entity", fr.GetEntities())
+	}
+	return errs
+}
+
+// Check a multi-view demo:
+//
+//   - the first packet has serverdata
+//   - configstring indexes are in range for the current remap
+//   - entity numbers are within the remap's MaxEdicts
+//   - player and unicast client numbers are within MaxClients
+func VerifyMVD2(demo *pb.MvdDemo) []error {
+	var errs []error
+	fail := func(packet int, format string, a ...any) {
+		errs = append(errs, &VerifyError{Packet: packet, Reason: fmt.Sprintf(format, a...)})
+	}
+	var remap *pb.MvdConfigStringRemap
+	for i, packet := range demo.GetPackets() {
+		if sd := packet.GetServerdata(); sd != nil {
+			remap = sd.GetRemap()
+			if remap == nil {
+				remap = csRemap
+			}
+		}
+		if remap == nil {
+			fail(i, "no serverdata before packet")
+			remap = csRemap
+		}
+		configs := func(cs []*pb.ConfigString) {
+			for _, c := range cs {
+				if int32(c.GetIndex()) >= remap.GetEnd() {
+					fail(i, "configstring index %d out of range", c.GetIndex())
+				}
+			}
+		}
+		var cs []*pb.ConfigString
+		for _, k := range util.SortedKeys(packet.GetConfigstrings()) {
+			cs = append(cs, packet.GetConfigstrings()[k])
+			if int32(packet.GetConfigstrings()[k].GetIndex()) != k {
+				fail(i, "configstring %d has index %d", k, packet.GetConfigstrings()[k].GetIndex())
+			}
+		}
+		configs(cs)
+		for _, uc := range packet.GetUnicasts() {
+			if uc.GetClientNumber() < 0 || uc.GetClientNumber() >= MaxClients {
+				fail(i, "unicast client number %d out of range", uc.GetClientNumber())
+			}
+			configs(uc.GetConfigstrings())
+		}
+		for _, fr := range packet.GetFrames() {
+			for _, num := range util.SortedKeys(fr.GetPlayers()) {
+				if num < 0 || num >= MaxClients {
+					fail(i, "player number %d out of range", num)
+				}
+			}
+			for _, num := range fr.GetRemovedPlayers() {
+				if num < 0 || num >= MaxClients {
+					fail(i, "removed player number %d out of range", num)
+				}
+			}
+			for _, num := range util.SortedKeys(fr.GetEntities()) {
+				if num <= 0 || num >= remap.GetMaxEdicts() {
+					fail(i, "entity number %d out of range", num)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// A difference between two demos
+type Difference struct {
+	Frame int32
+	Kind  string        // the type of message, the DemoEvent field name
+	A     *pb.DemoEvent // nil if only in the second demo
+	B     *pb.DemoEvent // nil if only in the first demo
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.A == nil:
+		return fmt.Sprintf("frame %d: %s only in second demo", d.Frame, d.Kind)
+	case d.B == nil:
+		return fmt.Sprintf("frame %d: %s only in first demo", d.Frame, d.Kind)
+	}
+	return fmt.Sprintf("frame %d: %s differs", d.Frame, d.Kind)
+}
+
+// Compare two parsed demos of the same type (*pb.DM2Demo or *pb.MvdDemo)
+// frame by frame. Messages are compared by their contents, not how they were
+// encoded, so a demo that was re-written will still match the original.
+func Diff(a, b proto.Message) ([]Difference, error) {
+	switch da := a.(type) {
+	case *pb.DM2Demo:
+		db, ok := b.(*pb.DM2Demo)
+		if !ok {
+			return nil, fmt.Errorf("can't compare %T to %T", a, b)
+		}
+		return DiffEvents(DM2Events(da, DefaultFrameRate), DM2Events(db, DefaultFrameRate)), nil
+	case *pb.MvdDemo:
+		db, ok := b.(*pb.MvdDemo)
+		if !ok {
+			return nil, fmt.Errorf("can't compare %T to %T", a, b)
+		}
+		return DiffEvents(MVD2Events(da, DefaultFrameRate), MVD2Events(db, DefaultFrameRate)), nil
+	}
+	return nil, fmt.Errorf("unsupported demo type %T", a)
+}
+
+// Compare two event streams frame by frame. Within a frame, messages of the
+// same kind are paired up in order.
+func DiffEvents(a, b []*pb.DemoEvent) []Difference {
+	fa, fb := groupEvents(a), groupEvents(b)
+	frames := make(map[int32]bool)
+	for k := range fa {
+		frames[k] = true
+	}
+	for k := range fb {
+		frames[k] = true
+	}
+	var diffs []Difference
+	for _, frame := range util.SortedKeys(frames) {
+		ka, kb := fa[frame], fb[frame]
+		if ka == nil {
+			ka = &frameEvents{} // missing frames act as empty ones
+		}
+		if kb == nil {
+			kb = &frameEvents{}
+		}
+		var kinds []string
+		seen := make(map[string]bool)
+		for _, k := range slices.Concat(ka.order, kb.order) {
+			if !seen[k] {
+				seen[k] = true
+				kinds = append(kinds, k)
+			}
+		}
+		for _, kind := range kinds {
+			ea, eb := ka.events[kind], kb.events[kind]
+			for i := 0; i < max(len(ea), len(eb)); i++ {
+				d := Difference{Frame: frame, Kind: kind}
+				if i < len(ea) {
+					d.A = ea[i]
+				}
+				if i < len(eb) {
+					d.B = eb[i]
+				}
+				if d.A != nil && d.B != nil && sameEvent(d.A, d.B) {
+					continue
+				}
+				diffs = append(diffs, d)
+			}
+		}
+	}
+	return diffs
+}
+
+// The events in a frame, by kind
+type frameEvents struct {
+	order  []string // kinds in the order first seen
+	events map[string][]*pb.DemoEvent
+}
+
+func groupEvents(events []*pb.DemoEvent) map[int32]*frameEvents {
+	out := make(map[int32]*frameEvents)
+	for _, ev := range events {
+		fe, ok := out[ev.GetFrame()]
+		if !ok {
+			fe = &frameEvents{events: make(map[string][]*pb.DemoEvent)}
+			out[ev.GetFrame()] = fe
+		}
+		kind := eventKind(ev)
+		if _, ok := fe.events[kind]; !ok {
+			fe.order = append(fe.order, kind)
+		}
+		fe.events[kind] = append(fe.events[kind], ev)
+	}
+	return out
+}
+
+// The name of the message field set in an event
+func eventKind(ev *pb.DemoEvent) string {
+	msg := ev.ProtoReflect()
+	fd := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("event"))
+	if fd == nil {
+		return "unknown"
+	}
+	return string(fd.Name())
+}
+
+// Compare just the message in two events
+func sameEvent(a, b *pb.DemoEvent) bool {
+	ca := proto.Clone(a).(*pb.DemoEvent)
+	cb := proto.Clone(b).(*pb.DemoEvent)
+	ca.Frame, ca.ServerTime, ca.Packet = 0, 0, 0
+	cb.Frame, cb.ServerTime, cb.Packet = 0, 0, 0
+	return proto.Equal(ca, cb)
+}
@@ -0,0 +1,224 @@
+package demo
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func loadDM2(t *testing.T, file string) *pb.DM2Demo {
+	t.Helper()
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := NewDM2Parser()
+	if err := parser.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	return parser.GetTextProto()
+}
+
+func TestVerifyFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "dm2", file: "../testdata/test.dm2"},
+		{name: "dm2 duel", file: "../testdata/testduel.dm2"},
+		{name: "mvd2", file: "../testdata/test.mvd2"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var demo proto.Message
+			if tc.name == "mvd2" {
+				parser, err := NewMVD2Parser(tc.file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				demos, err := parser.Unmarshal()
+				if err != nil {
+					t.Fatal(err)
+				}
+				demo = demos[0]
+			} else {
+				demo = loadDM2(t, tc.file)
+			}
+			if err := Verify(demo); err != nil {
+				t.Errorf("Verify() error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyDM2(t *testing.T) {
+	tests := []struct {
+		name string
+		demo *pb.DM2Demo
+		want []string
+	}{
+		{
+			name: "missing serverdata",
+			demo: &pb.DM2Demo{},
+			want: []string{"frame 0: missing serverdata"},
+		},
+		{
+			name: "bad configstrings",
+			demo: &pb.DM2Demo{
+				Serverinfo: &pb.ServerInfo{},
+				Configstrings: map[int32]*pb.ConfigString{
+					5:    {Index: 6},
+					3000: {Index: 3000},
+				},
+			},
+			want: []string{
+				"frame 0: configstring 5 has index 6",
+				"frame 0: configstring index 3000 out of range",
+			},
+		},
+		{
+			name: "bad entities",
+			demo: &pb.DM2Demo{
+				Serverinfo: &pb.ServerInfo{},
+				Baselines:  map[int32]*pb.PackedEntity{2000: {Number: 2000}},
+				Frames: map[int32]*pb.Frame{
+					1: {Number: 1, Entities: map[int32]*pb.PackedEntity{4: {Number: 5}}},
+				},
+			},
+			want: []string{
+				"frame 0: baseline number 2000 out of range",
+				"frame 1: entity 4 has number 5",
+			},
+		},
+		{
+			name: "bad frames",
+			demo: &pb.DM2Demo{
+				Serverinfo: &pb.ServerInfo{},
+				Frames: map[int32]*pb.Frame{
+					1: {Number: 1, Delta: -1},
+					2: {Number: 3, Delta: 1},
+					4: {Number: 4, Delta: 3},
+					5: {Number: 5, Delta: 6},
+				},
+			},
+			want: []string{
+				"frame 2: frame stored as 2 has number 3",
+				"frame 4: delta frame 3 missing",
+				"frame 5: delta frame 6 is not earlier",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range VerifyDM2(tc.demo) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VerifyDM2() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVerifyMVD2(t *testing.T) {
+	tests := []struct {
+		name string
+		demo *pb.MvdDemo
+		want []string
+	}{
+		{
+			name: "no serverdata",
+			demo: &pb.MvdDemo{Packets: []*pb.MvdPacket{{Prints: []*pb.Print{{Data: "hi"}}}}},
+			want: []string{"packet 0: no serverdata before packet"},
+		},
+		{
+			name: "out of range",
+			demo: &pb.MvdDemo{Packets: []*pb.MvdPacket{
+				{
+					Serverdata:    &pb.MvdServerData{Remap: csRemap},
+					Configstrings: map[int32]*pb.ConfigString{2100: {Index: 2100}},
+				},
+				{
+					Unicasts: []*pb.MvdUnicast{{ClientNumber: 300}},
+					Frames: []*pb.MvdFrame{{
+						Players:  map[int32]*pb.PackedPlayer{-1: {}},
+						Entities: map[int32]*pb.PackedEntity{1024: {}},
+					}},
+				},
+			}},
+			want: []string{
+				"packet 0: configstring index 2100 out of range",
+				"packet 1: unicast client number 300 out of range",
+				"packet 1: player number -1 out of range",
+				"packet 1: entity number 1024 out of range",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range VerifyMVD2(tc.demo) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VerifyMVD2() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+	var verr *VerifyError
+	if err := Verify(tests[0].demo); !errors.As(err, &verr) || verr.Packet != 0 {
+		t.Errorf("Verify() = %v, want a VerifyError for packet 0", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	orig := loadDM2(t, "../testdata/testduel.dm2")
+	diffs, err := Diff(orig, proto.Clone(orig))
+	if err != nil {
+		t.Fatalf("Diff() error: %v", err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("Diff() of a copy found %d differences, first: %v", len(diffs), diffs[0])
+	}
+
+	edited := proto.Clone(orig).(*pb.DM2Demo)
+	var printFrame int32
+	for _, num := range util.SortedKeys(edited.GetFrames()) {
+		if len(edited.GetFrames()[num].GetPrints()) > 0 {
+			printFrame = num
+			edited.GetFrames()[num].GetPrints()[0].Data = "changed\n"
+			break
+		}
+	}
+	last := util.SortedKeys(edited.GetFrames())
+	delete(edited.Frames, last[len(last)-1])
+
+	diffs, err = Diff(orig, edited)
+	if err != nil {
+		t.Fatalf("Diff() error: %v", err)
+	}
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.String())
+	}
+	lastNum := last[len(last)-1]
+	want := []string{
+		Difference{Frame: printFrame, Kind: "print", A: &pb.DemoEvent{}, B: &pb.DemoEvent{}}.String(),
+		Difference{Frame: lastNum, Kind: "dm2_frame", A: &pb.DemoEvent{}}.String(),
+		Difference{Frame: lastNum, Kind: "print", A: &pb.DemoEvent{}}.String(),
+		Difference{Frame: lastNum, Kind: "stufftext", A: &pb.DemoEvent{}}.String(),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := Diff(orig, &pb.MvdDemo{}); err == nil {
+		t.Error("Diff() of different demo types succeeded")
+	}
+}
@@ -16,6 +16,7 @@ var (
 	server = flag.String("server", "frag.gr", "Q2 server ip/hostname to connect to")
 	port   = flag.Int("port", 27910, "The port the server is listening on")
 	debug  = flag.Bool("debug", false, "show way more information in the console")
+	record = flag.String("record", "", "record a demo of each map into this directory")
 )
 
 func main() {
@@ -36,7 +37,8 @@ func main() {
 			"version":   "PFBot Test v2",
 			"timescale": "1",
 		},
-		Debug: *debug,
+		Debug:     *debug,
+		RecordDir: *record,
 	}
 
 	bot.RegisterCallback(message.SVCPrint, printCallback)
@@ -1,5 +1,5 @@
-// demoprints simply reads a Quake 2 demo file and prints out any
-// print messages found.
+// demoprints reads a Quake 2 demo file and prints out the chat, obituaries
+// and other messages in it along with when they happened.
 package main
 
 import (
@@ -24,10 +24,8 @@ func main() {
 		return
 	}
 
-	for _, frame := range dm2.GetTextProto().GetFrames() {
-		for _, print := range frame.GetPrints() {
-			// newlines are included in the messages
-			fmt.Print(util.ConvertHighChars(print.GetData()))
-		}
+	for _, entry := range demo.DM2Timeline(dm2.GetTextProto()) {
+		secs := entry.Time / 1000
+		fmt.Printf("[%d:%02d] %-11s %s\n", secs/60, secs%60, entry.Kind, util.ConvertHighChars(entry.Text))
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/packetflinger/libq2/master"
@@ -13,6 +14,17 @@ import (
 var (
 	listenPort = flag.Int("port", 27900, "Port to listen on")
 	listenIP   = flag.String("addr", "[::]", "IP address to listen on")
+	httpAddr   = flag.String("http", "", "Address for the HTTP API (\":8080\"), empty to disable")
+	registry   = flag.String("registry", "", "File to keep known servers in between restarts, empty to disable")
+	upstreams  = flag.String("upstream", "", "Comma separated masters (host:port) to import servers from")
+	revalidate = flag.Bool("revalidate", false, "Ping imported servers before listing them")
+	verify     = flag.Bool("verify", false, "Status probe new servers before listing them")
+	allow      = flag.String("allow", "", "Comma separated networks (CIDR) allowed to register servers, empty for anyone")
+	block      = flag.String("block", "", "Comma separated networks (CIDR) to ignore")
+	rateLimit  = flag.Int("ratelimit", 0, "Max datagrams per minute from one IP, 0 for no limit")
+	maxPerIP   = flag.Int("maxperip", 0, "Max servers per IP, 0 for no limit")
+	workers    = flag.Int("workers", master.DefaultProbeWorkers, "Max servers probed at once")
+	evict      = flag.Int("evict", master.DefaultEvictAfter, "Remove servers after this many missed probes")
 )
 
 func main() {
@@ -22,7 +34,26 @@ func main() {
 	m := master.NewMaster()
 	m.Address = *listenIP
 	m.Port = *listenPort
-	m.Refresh = true
+	m.HTTPAddress = *httpAddr
+	if *upstreams != "" {
+		m.Upstreams = strings.Split(*upstreams, ",")
+	}
+	m.Revalidate = *revalidate
+	m.Verify = *verify
+	m.RateLimit = *rateLimit
+	m.MaxServersPerIP = *maxPerIP
+	m.ProbeWorkers = *workers
+	m.EvictAfter = *evict
+	var err error
+	if m.Allow, err = master.ParseNetworks(strings.Split(*allow, ",")); err != nil {
+		log.Fatal(err)
+	}
+	if m.Block, err = master.ParseNetworks(strings.Split(*block, ",")); err != nil {
+		log.Fatal(err)
+	}
+	if *registry != "" {
+		m.Storage = master.NewFileStorage(*registry)
+	}
 
 	ctx, done := context.WithCancel(context.Background())
 	defer done()
@@ -0,0 +1,313 @@
+package gtv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+const (
+	DefaultPingInterval = 60 // secs
+	DefaultMaxBuffer    = 0  // let the server decide how much to buffer
+	DefaultVersion      = "libq2"
+)
+
+var (
+	// The server wants us to drop and connect again, usually a map change
+	// on an older server or a restart.
+	ErrReconnect = errors.New("server requested reconnect")
+
+	// The server closed the connection on purpose.
+	ErrDisconnect = errors.New("server disconnected")
+)
+
+// A GTV client connection to a single server
+type Client struct {
+	Address      string        // ip:port
+	Username     string        // sent in the hello
+	Password     string        // sv_mvd_password on the server
+	Version      string        // our version string
+	MaxBuffer    int           // how many packets the server should buffer
+	PingInterval int           // seconds
+	Timeout      time.Duration // for connecting and the handshake
+	Flags        int           // the flags the server accepted
+	Conn         net.Conn      // the socket
+	Parser       *demo.MVD2Parser
+	Streaming    bool              // currently subscribed and receiving data
+	Suspended    bool              // server has no game in progress
+	LastPong     time.Time         // last time the server answered a ping
+	RawDataFunc  func([]byte)      // called with stream data before parsing
+	callbacks    map[int]func(any) // index is a Server* op
+	writeLock    sync.Mutex        // pings and requests can come from anywhere
+}
+
+// Setup a new client with defaults. The MVD parser is ready for callbacks to
+// be registered on it before connecting.
+func NewClient(addr string) *Client {
+	return &Client{
+		Address:      addr,
+		Version:      DefaultVersion,
+		MaxBuffer:    DefaultMaxBuffer,
+		PingInterval: DefaultPingInterval,
+		Timeout:      5 * time.Second,
+		Parser:       demo.NewMVD2PacketParser(),
+	}
+}
+
+// Register a function to be called when a particular server op is received.
+// The arg passed to dofunc depends on the op:
+//
+//	ServerHello       - int (accepted flags)
+//	ServerPong        - time.Time
+//	ServerStreamStart - nil
+//	ServerStreamStop  - nil
+//	ServerStreamData  - *pb.MvdPacket (nil when the stream is suspended)
+//	ServerError, ServerBadRequest, ServerNoAccess - string (reason)
+//
+// For callbacks on individual MVD messages (prints, frames, etc), use
+// c.Parser.RegisterCallback().
+func (c *Client) RegisterCallback(op int, dofunc func(any)) {
+	if c.callbacks == nil {
+		c.callbacks = make(map[int]func(any))
+	}
+	c.callbacks[op] = dofunc
+}
+
+// Dynamically remove a particular callback
+func (c *Client) UnregisterCallback(op int) {
+	delete(c.callbacks, op)
+}
+
+func (c *Client) callback(op int, arg any) {
+	if cbFunc, found := c.callbacks[op]; found {
+		cbFunc(arg)
+	}
+}
+
+// Connect to the server and perform the handshake. On success the client is
+// authenticated but not yet subscribed to the stream.
+func (c *Client) Connect() error {
+	conn, err := net.DialTimeout("tcp", c.Address, c.Timeout)
+	if err != nil {
+		return err
+	}
+	c.Conn = conn
+	if err := c.handshake(); err != nil {
+		conn.Close()
+		c.Conn = nil
+		return err
+	}
+	return nil
+}
+
+// Swap magic values, send our hello and wait for the server's hello.
+func (c *Client) handshake() error {
+	c.Conn.SetDeadline(time.Now().Add(c.Timeout))
+	defer c.Conn.SetDeadline(time.Time{})
+
+	if err := WriteMagic(c.Conn); err != nil {
+		return err
+	}
+	hello := message.NewBuffer(nil)
+	hello.WriteShort(ProtocolVersion)
+	hello.WriteLong(FlagStringCmds)
+	hello.WriteLong(0) // reserved
+	hello.WriteString(c.Username)
+	hello.WriteString(c.Password)
+	hello.WriteString(c.Version)
+	if err := c.send(ClientHello, hello.Data); err != nil {
+		return err
+	}
+
+	if err := ReadMagic(c.Conn); err != nil {
+		return err
+	}
+	msg, err := ReadMessage(c.Conn, MaxServerMsgLen)
+	if err != nil {
+		return fmt.Errorf("error reading hello: %v", err)
+	}
+	if msg.Op != ServerHello {
+		return serverError(msg)
+	}
+	if len(msg.Data) < 4 {
+		return fmt.Errorf("short hello from server")
+	}
+	data := message.NewBuffer(msg.Data)
+	c.Flags = int(data.ReadLong())
+	c.LastPong = time.Now()
+	c.callback(ServerHello, c.Flags)
+	return nil
+}
+
+// Convert a refusal into an error
+func serverError(msg Message) error {
+	reason := readString(msg.Data)
+	switch msg.Op {
+	case ServerError:
+		return fmt.Errorf("server error: %q", reason)
+	case ServerBadRequest:
+		return fmt.Errorf("bad request")
+	case ServerNoAccess:
+		return fmt.Errorf("access denied")
+	case ServerDisconnect:
+		return ErrDisconnect
+	case ServerReconnect:
+		return ErrReconnect
+	}
+	return fmt.Errorf("unexpected server op: %d", msg.Op)
+}
+
+func (c *Client) send(op int, data []byte) error {
+	if c.Conn == nil {
+		return fmt.Errorf("not connected")
+	}
+	if len(data)+1 > MaxClientMsgLen {
+		return fmt.Errorf("message too large: %d bytes", len(data)+1)
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return WriteMessage(c.Conn, op, data)
+}
+
+// Ask the server to start sending the stream. The server will respond with a
+// ServerStreamStart followed by the gamestate.
+func (c *Client) StartStream() error {
+	msg := message.NewBuffer(nil)
+	msg.WriteShort(c.MaxBuffer)
+	return c.send(ClientStreamStart, msg.Data)
+}
+
+// Ask the server to stop sending the stream, the connection stays open.
+func (c *Client) StopStream() error {
+	return c.send(ClientStreamStop, nil)
+}
+
+// Keep the connection alive, the server drops clients that go quiet.
+func (c *Client) Ping() error {
+	return c.send(ClientPing, nil)
+}
+
+// Send a command to the server, it's executed as if typed by the dummy MVD
+// client. Only works if the server accepted the FlagStringCmds flag.
+func (c *Client) StringCmd(cmd string) error {
+	if (c.Flags & FlagStringCmds) == 0 {
+		return fmt.Errorf("server doesn't accept stringcmds")
+	}
+	msg := message.NewBuffer(nil)
+	msg.WriteString(cmd)
+	return c.send(ClientStringCmd, msg.Data)
+}
+
+// Close the connection
+func (c *Client) Close() error {
+	if c.Conn == nil {
+		return nil
+	}
+	err := c.Conn.Close()
+	c.Conn = nil
+	return err
+}
+
+// Read and process messages from the server until the context is cancelled
+// or the connection ends. The server is pinged periodically. A nil error is
+// returned when the context is cancelled.
+func (c *Client) Run(ctx context.Context) error {
+	if c.Conn == nil {
+		return fmt.Errorf("not connected")
+	}
+	conn := c.Conn
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		interval := time.Duration(c.PingInterval) * time.Second
+		if interval <= 0 {
+			interval = DefaultPingInterval * time.Second
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close() // unblock the read
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				c.Ping()
+			}
+		}
+	}()
+
+	for {
+		msg, err := ReadMessage(conn, MaxServerMsgLen)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err == io.EOF {
+				return ErrDisconnect
+			}
+			return err
+		}
+		if err := c.ProcessMessage(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// Handle a single message from the server.
+func (c *Client) ProcessMessage(msg Message) error {
+	switch msg.Op {
+	case ServerPong:
+		c.LastPong = time.Now()
+		c.callback(ServerPong, c.LastPong)
+
+	case ServerStreamStart:
+		c.Streaming = true
+		c.Suspended = false
+		c.callback(ServerStreamStart, nil)
+
+	case ServerStreamStop:
+		c.Streaming = false
+		c.callback(ServerStreamStop, nil)
+
+	case ServerStreamData:
+		if c.RawDataFunc != nil {
+			c.RawDataFunc(msg.Data)
+		}
+
+		// no data means there is no game to watch at the moment
+		if len(msg.Data) == 0 {
+			c.Suspended = true
+			c.callback(ServerStreamData, (*pb.MvdPacket)(nil))
+			return nil
+		}
+		c.Suspended = false
+		data := message.NewBuffer(msg.Data)
+		packet, err := c.Parser.ParsePacket(&data)
+		if err != nil {
+			return fmt.Errorf("error parsing stream data: %v", err)
+		}
+		c.callback(ServerStreamData, packet)
+
+	case ServerError, ServerBadRequest, ServerNoAccess:
+		c.callback(msg.Op, readString(msg.Data))
+		return serverError(msg)
+
+	case ServerDisconnect, ServerReconnect:
+		c.Streaming = false
+		return serverError(msg)
+
+	default:
+		return fmt.Errorf("unknown server op: %d", msg.Op)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package gtv
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// A stand-in for a q2pro server. It performs the server side of the
+// handshake, answers pings and streams the packets from a demo file when
+// asked.
+func testServer(t *testing.T, password string, demofile string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if err := ReadMagic(conn); err != nil {
+			return
+		}
+		msg, err := ReadMessage(conn, MaxClientMsgLen)
+		if err != nil || msg.Op != ClientHello {
+			return
+		}
+		hello := message.NewBuffer(msg.Data)
+		hello.ReadShort() // protocol
+		flags := hello.ReadLong()
+		hello.ReadLong() // reserved
+		hello.ReadString()
+		if hello.ReadString() != password {
+			WriteMagic(conn)
+			WriteMessage(conn, ServerNoAccess, nil)
+			return
+		}
+		WriteMagic(conn)
+		reply := message.NewBuffer(nil)
+		reply.WriteLong(int(flags))
+		reply.WriteLong(0)
+		WriteMessage(conn, ServerHello, reply.Data)
+
+		for {
+			msg, err := ReadMessage(conn, MaxClientMsgLen)
+			if err != nil {
+				return
+			}
+			switch msg.Op {
+			case ClientPing:
+				WriteMessage(conn, ServerPong, nil)
+			case ClientStreamStop:
+				WriteMessage(conn, ServerStreamStop, nil)
+				WriteMessage(conn, ServerDisconnect, nil)
+				return
+			case ClientStreamStart:
+				WriteMessage(conn, ServerStreamStart, nil)
+				WriteMessage(conn, ServerStreamData, nil) // suspended
+				f, err := os.Open(demofile)
+				if err != nil {
+					return
+				}
+				stream, err := demo.NewMVD2Stream(f)
+				if err != nil {
+					f.Close()
+					return
+				}
+				for {
+					data, err := stream.NextRaw()
+					if err != nil {
+						break
+					}
+					WriteMessage(conn, ServerStreamData, data.Data)
+				}
+				f.Close()
+				WriteMessage(conn, ServerDisconnect, nil)
+				return
+			}
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestClientStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		demofile string
+	}{
+		{
+			name:     "test1",
+			demofile: "../testdata/test.mvd2",
+		},
+		{
+			name:     "gzip test",
+			demofile: "../testdata/ziptest.mvd2.gz",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, err := demo.NewMVD2Parser(tc.demofile)
+			if err != nil {
+				t.Fatalf("error creating parser: %v", err)
+			}
+			demos, err := parser.Unmarshal()
+			if err != nil {
+				t.Fatalf("error unmarshalling: %v", err)
+			}
+			want := len(demos[0].GetPackets())
+
+			addr := testServer(t, "secret", tc.demofile)
+			client := NewClient(addr)
+			client.Password = "secret"
+
+			started := false
+			suspended := 0
+			packets := 0
+			prints := 0
+			client.RegisterCallback(ServerStreamStart, func(any) {
+				started = true
+			})
+			client.RegisterCallback(ServerStreamData, func(a any) {
+				if a.(*pb.MvdPacket) == nil {
+					suspended++
+					return
+				}
+				packets++
+			})
+			client.Parser.RegisterCallback(demo.MVDSvcPrint, func(any) {
+				prints++
+			})
+
+			if err := client.Connect(); err != nil {
+				t.Fatalf("Connect() error: %v", err)
+			}
+			defer client.Close()
+			if err := client.StartStream(); err != nil {
+				t.Fatalf("StartStream() error: %v", err)
+			}
+			if err := client.Run(context.Background()); err != ErrDisconnect {
+				t.Errorf("Run() error = %v, want %v", err, ErrDisconnect)
+			}
+			if !started {
+				t.Error("stream start not received")
+			}
+			if suspended != 1 {
+				t.Errorf("suspended count = %d, want 1", suspended)
+			}
+			if packets != want {
+				t.Errorf("packet count = %d, want %d", packets, want)
+			}
+			if prints == 0 {
+				t.Error("no prints parsed from stream")
+			}
+		})
+	}
+}
+
+func TestClientPingStop(t *testing.T) {
+	addr := testServer(t, "", "")
+	client := NewClient(addr)
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer client.Close()
+
+	client.Ping()
+	msg, err := ReadMessage(client.Conn, MaxServerMsgLen)
+	if err != nil {
+		t.Fatalf("error reading pong: %v", err)
+	}
+	if err := client.ProcessMessage(msg); err != nil || msg.Op != ServerPong {
+		t.Errorf("expected pong, got op %d (%v)", msg.Op, err)
+	}
+
+	client.Streaming = true
+	client.StopStream()
+	if err := client.Run(context.Background()); err != ErrDisconnect {
+		t.Errorf("Run() error = %v, want %v", err, ErrDisconnect)
+	}
+	if client.Streaming {
+		t.Error("still streaming after stop")
+	}
+}
+
+func TestClientNoAccess(t *testing.T) {
+	addr := testServer(t, "secret", "")
+	client := NewClient(addr)
+	client.Password = "wrong"
+	if err := client.Connect(); err == nil {
+		t.Error("Connect() with bad password succeeded")
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Message
+		wantErr bool
+	}{
+		{
+			name: "pong",
+			data: []byte{1, 0, ServerPong},
+			want: Message{Op: ServerPong, Data: []byte{}},
+		},
+		{
+			name: "with data",
+			data: []byte{3, 0, ServerStreamData, 0xaa, 0xbb},
+			want: Message{Op: ServerStreamData, Data: []byte{0xaa, 0xbb}},
+		},
+		{
+			name:    "zero length",
+			data:    []byte{0, 0},
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			data:    []byte{5, 0, ServerStreamData, 1},
+			wantErr: true,
+		},
+		{
+			name:    "too large",
+			data:    []byte{0xff, 0xff, ServerStreamData},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, w := io.Pipe()
+			go func() {
+				w.Write(tc.data)
+				w.Close()
+			}()
+			got, err := ReadMessage(r, MaxServerMsgLen)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReadMessage(%v) error = %v, wantErr %t", tc.data, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.Op != tc.want.Op || string(got.Data) != string(tc.want.Data) {
+				t.Errorf("ReadMessage(%v) = %v, want %v", tc.data, got, tc.want)
+			}
+		})
+	}
+}
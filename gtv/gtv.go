@@ -0,0 +1,132 @@
+// GTV (Game TV) is the protocol Q2PRO servers use to relay multi-view demo
+// streams over TCP. A GTV client connects to the game port of a server (or to
+// another relay) and subscribes to the live MVD stream of the match being
+// played.
+//
+// Both ends start by exchanging the 4 byte MVD magic value ("MVD2"), after
+// that everything is a framed message:
+//
+//	[2 byte length][1 byte op][payload]
+//
+// The length includes the op byte. Messages from the server start with a
+// GTS_* op, messages from the client use GTC_* ops. The payload of stream
+// data messages is the same MVD data found in .mvd2 demo files, minus the
+// per-packet length prefix.
+//
+// Server config for allowing GTV clients (q2pro):
+//
+//	set sv_mvd_enable 1
+//	set sv_mvd_maxclients 4
+//	set sv_mvd_password "something"
+package gtv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+)
+
+const (
+	ProtocolVersion = 0xed04
+	MaxServerMsgLen = 32768 // MAX_GTS_MSGLEN
+	MaxClientMsgLen = 256   // MAX_GTC_MSGLEN
+
+	// client flags sent in the hello
+	FlagDeflate    = 1 << 0 // zlib compressed stream, not supported
+	FlagStringCmds = 1 << 1 // allow sending stringcmds to the server
+)
+
+// Server -> client ops
+const (
+	ServerHello = iota
+	ServerPong
+	ServerStreamStart
+	ServerStreamStop
+	ServerStreamData
+	ServerError
+	ServerBadRequest
+	ServerNoAccess
+	ServerDisconnect
+	ServerReconnect
+)
+
+// Client -> server ops
+const (
+	ClientHello = iota
+	ClientPing
+	ClientStreamStart
+	ClientStreamStop
+	ClientStringCmd
+)
+
+// A single message in either direction
+type Message struct {
+	Op   int
+	Data []byte
+}
+
+// Write the MVD magic value, both sides send this as the very first thing.
+func WriteMagic(w io.Writer) error {
+	magic := make([]byte, 4)
+	binary.LittleEndian.PutUint32(magic, demo.MVDMagic)
+	_, err := w.Write(magic)
+	return err
+}
+
+// Read and check the MVD magic value from the other side.
+func ReadMagic(r io.Reader) error {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return fmt.Errorf("error reading magic: %v", err)
+	}
+	if binary.LittleEndian.Uint32(magic) != demo.MVDMagic {
+		return fmt.Errorf("invalid magic: %x", magic)
+	}
+	return nil
+}
+
+// Frame and write a message.
+func WriteMessage(w io.Writer, op int, data []byte) error {
+	if len(data)+1 > MaxServerMsgLen {
+		return fmt.Errorf("message too large: %d bytes", len(data)+1)
+	}
+	msg := message.NewBuffer(nil)
+	msg.WriteWord(len(data) + 1)
+	msg.WriteByte(op)
+	msg.WriteData(data)
+	_, err := w.Write(msg.Data)
+	return err
+}
+
+// Read a single framed message. The maximum length allowed depends on which
+// side is reading.
+func ReadMessage(r io.Reader, maxlen int) (Message, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Message{}, err
+	}
+	length := int(binary.LittleEndian.Uint16(header))
+	if length == 0 {
+		return Message{}, fmt.Errorf("zero length message")
+	}
+	if length > maxlen {
+		return Message{}, fmt.Errorf("message too large: %d bytes", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Message{}, err
+	}
+	return Message{Op: int(data[0]), Data: data[1:]}, nil
+}
+
+// Messages with an optional string payload
+func readString(data []byte) string {
+	msg := message.NewBuffer(data)
+	return msg.ReadString()
+}
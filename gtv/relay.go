@@ -0,0 +1,483 @@
+package gtv
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultRelayMaxClients = 32
+	DefaultRelayQueueSize  = 256  // packets, ~25 secs at 10hz
+	DefaultRelayDelayQueue = 8192 // packets waiting out the delay
+)
+
+// A Relay accepts downstream GTV clients and fans out a single upstream MVD
+// stream to all of them. The upstream data is fed in with Feed(), usually
+// from a gtv.Client's RawDataFunc or from a demo file.
+//
+// Newly subscribed clients are first sent a snapshot of the current gamestate
+// (generated with the MVD2Writer), then the live packets as they arrive.
+//
+// Each client has a bounded queue. A client that can't keep up and fills its
+// queue is dropped rather than holding up everyone else. The upstream feed is
+// only ever blocked when the delay queue is full.
+type Relay struct {
+	Address    string        // ip:port to listen on
+	Password   string        // required from downstream clients, if set
+	Delay      time.Duration // hold packets this long before relaying
+	MaxClients int           // downstream connection limit
+	QueueSize  int           // per-client outgoing queue length
+	Verbose    bool          // be extra mouthy
+
+	listener   net.Listener
+	feedLock   sync.Mutex       // held while sending to delayed so Close can close it
+	parseLock  sync.Mutex       // held while a packet is parsed and relayed, taken before lock
+	parser     *demo.MVD2Parser // tracks the state for snapshots, guarded by parseLock
+	lock       sync.Mutex       // guards everything below
+	clients    map[*RelayClient]bool
+	serverdata *pb.MvdServerData
+	configs    map[int32]*pb.ConfigString
+	portal     []byte // from the last frame
+	suspended  bool   // upstream has no game in progress
+	delayed    chan delayedPacket
+	done       chan struct{} // closed along with the relay, stops the delay line
+	closed     bool
+}
+
+// A downstream GTV client connected to the relay
+type RelayClient struct {
+	Address   string
+	Name      string // from the hello
+	Version   string // from the hello
+	Streaming bool
+	conn      net.Conn
+	queue     chan Message
+}
+
+type delayedPacket struct {
+	at   time.Time
+	data []byte
+}
+
+// Setup a new relay with defaults
+func NewRelay(addr string) *Relay {
+	return &Relay{
+		Address:    addr,
+		MaxClients: DefaultRelayMaxClients,
+		QueueSize:  DefaultRelayQueueSize,
+		clients:    make(map[*RelayClient]bool),
+		parser:     demo.NewMVD2PacketParser(),
+		configs:    make(map[int32]*pb.ConfigString),
+	}
+}
+
+// Open the listening socket. This is separate from Serve() so the actual
+// address is known before accepting connections (when using port 0).
+func (r *Relay) Listen() error {
+	listener, err := net.Listen("tcp", r.Address)
+	if err != nil {
+		return err
+	}
+	r.listener = listener
+	return nil
+}
+
+// The address actually being listened on
+func (r *Relay) Addr() net.Addr {
+	if r.listener == nil {
+		return nil
+	}
+	return r.listener.Addr()
+}
+
+// Accept downstream clients until the context is cancelled.
+func (r *Relay) Serve(ctx context.Context) error {
+	if r.listener == nil {
+		if err := r.Listen(); err != nil {
+			return err
+		}
+	}
+	go func() {
+		<-ctx.Done()
+		r.Close()
+	}()
+	if r.Verbose {
+		log.Println("relaying GTV on", r.listener.Addr())
+	}
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go r.handleClient(conn)
+	}
+}
+
+// Tell all the clients we're going away and stop listening. Packets still
+// waiting out the delay are dropped.
+func (r *Relay) Close() error {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return nil
+	}
+	r.closed = true
+	if r.done != nil {
+		close(r.done) // wakes up any Feed() waiting on a full delay queue
+	}
+	for cl := range r.clients {
+		r.enqueue(cl, Message{Op: ServerDisconnect})
+		if _, ok := r.clients[cl]; ok {
+			close(cl.queue)
+			delete(r.clients, cl)
+		}
+	}
+	r.lock.Unlock()
+
+	r.feedLock.Lock()
+	r.lock.Lock()
+	if r.delayed != nil {
+		close(r.delayed) // ends the delay line
+	}
+	r.lock.Unlock()
+	r.feedLock.Unlock()
+
+	if r.listener != nil {
+		return r.listener.Close()
+	}
+	return nil
+}
+
+// Feed the relay a packet of MVD data from upstream. This is the payload of a
+// ServerStreamData message, or a single packet from a demo file. Empty data
+// means the upstream stream is suspended.
+//
+// When the relay has a delay, packets are queued and this only blocks if the
+// delay queue is full. Returns an error once the relay is closed.
+func (r *Relay) Feed(data []byte) error {
+	if r.Delay <= 0 {
+		return r.relay(data)
+	}
+	r.feedLock.Lock()
+	defer r.feedLock.Unlock()
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return fmt.Errorf("relay closed")
+	}
+	if r.delayed == nil {
+		r.delayed = make(chan delayedPacket, DefaultRelayDelayQueue)
+		r.done = make(chan struct{})
+		go r.delayLine(r.delayed, r.done)
+	}
+	delayed, done := r.delayed, r.done
+	r.lock.Unlock()
+
+	select {
+	case delayed <- delayedPacket{at: time.Now().Add(r.Delay), data: data}:
+		return nil
+	case <-done:
+		return fmt.Errorf("relay closed")
+	}
+}
+
+// Release delayed packets in order as their time comes. Runs until the relay
+// is closed.
+func (r *Relay) delayLine(delayed <-chan delayedPacket, done <-chan struct{}) {
+	for pkt := range delayed {
+		wait := time.NewTimer(time.Until(pkt.at))
+		select {
+		case <-wait.C:
+		case <-done:
+			wait.Stop()
+			continue // drain what's left until delayed is closed
+		}
+		if err := r.relay(pkt.data); err != nil && r.Verbose {
+			log.Println("relay error:", err)
+		}
+	}
+}
+
+// Update the gamestate with the packet and send it to all streaming clients.
+// Packets that can't be parsed are dropped. They're parsed without holding the
+// lock so a bad one can't hold up the clients or Close().
+func (r *Relay) relay(data []byte) error {
+	r.parseLock.Lock()
+	defer r.parseLock.Unlock()
+	var packet *pb.MvdPacket
+	if len(data) > 0 {
+		msg := message.NewBuffer(data)
+		p, err := r.parser.ParsePacket(&msg)
+		if err != nil {
+			return fmt.Errorf("dropped bad packet: %v", err)
+		}
+		packet = p
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return fmt.Errorf("relay closed")
+	}
+	if packet == nil {
+		r.suspended = true
+	} else {
+		r.suspended = false
+		r.update(packet)
+	}
+	for cl := range r.clients {
+		if cl.Streaming {
+			r.enqueue(cl, Message{Op: ServerStreamData, Data: data})
+		}
+	}
+	return nil
+}
+
+// Keep the bits of state the parser doesn't hold on to
+func (r *Relay) update(packet *pb.MvdPacket) {
+	if sd := packet.GetServerdata(); sd != nil {
+		r.serverdata = sd
+		r.configs = make(map[int32]*pb.ConfigString)
+	}
+	for k, v := range packet.GetConfigstrings() {
+		r.configs[k] = v
+	}
+	frames := packet.GetFrames()
+	if len(frames) > 0 {
+		r.portal = frames[len(frames)-1].GetPortalData()
+	}
+}
+
+// Generate a complete gamestate from the current state. This is a serverdata
+// packet with all the configstrings and a single uncompressed frame.
+func (r *Relay) Snapshot() ([]byte, error) {
+	r.parseLock.Lock()
+	defer r.parseLock.Unlock()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.snapshot()
+}
+
+// Must be called with both parseLock and the lock held.
+func (r *Relay) snapshot() ([]byte, error) {
+	if r.serverdata == nil {
+		return nil, fmt.Errorf("no gamestate yet")
+	}
+	state := r.parser.GetState()
+	frame := &pb.MvdFrame{
+		PortalData: r.portal,
+		Players:    make(map[int32]*pb.PackedPlayer),
+		Entities:   make(map[int32]*pb.PackedEntity),
+	}
+	// Players and entities that have been removed are included too. If they
+	// show up again later they will be delta compressed against their last
+	// state, so the client needs to know it.
+	for _, num := range util.SortedKeys(state.GetPlayers()) {
+		pl := state.GetPlayers()[num]
+		if pl.GetPlayerState() == nil {
+			continue
+		}
+		frame.Players[num] = pl.GetPlayerState()
+		if !pl.GetInUse() {
+			frame.RemovedPlayers = append(frame.RemovedPlayers, num)
+		}
+	}
+	for num, ent := range state.GetEntities() {
+		ent = proto.Clone(ent).(*pb.PackedEntity)
+		ent.Event = 0 // events are only for the frame they happened in
+		frame.Entities[num] = ent
+	}
+	packet := &pb.MvdPacket{
+		Serverdata:    r.serverdata,
+		Configstrings: r.configs,
+		Frames:        []*pb.MvdFrame{frame},
+	}
+	writer := demo.NewMVD2Writer(&pb.MvdDemo{})
+	msg, err := writer.MarshalPacket(packet)
+	if err != nil {
+		return nil, err
+	}
+	return msg.Data, nil
+}
+
+// Queue a message for a client, dropping the client if its queue is full.
+// Must be called with the lock held.
+func (r *Relay) enqueue(cl *RelayClient, msg Message) {
+	if _, ok := r.clients[cl]; !ok {
+		return // already dropped
+	}
+	select {
+	case cl.queue <- msg:
+	default:
+		if r.Verbose {
+			log.Printf("dropping slow GTV client %s\n", cl.Address)
+		}
+		close(cl.queue)
+		delete(r.clients, cl)
+	}
+}
+
+// Snapshot of the connected clients
+func (r *Relay) Clients() []RelayClient {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var out []RelayClient
+	for cl := range r.clients {
+		out = append(out, RelayClient{
+			Address:   cl.Address,
+			Name:      cl.Name,
+			Version:   cl.Version,
+			Streaming: cl.Streaming,
+		})
+	}
+	return out
+}
+
+// Handshake with a new downstream client and process its requests.
+func (r *Relay) handleClient(conn net.Conn) {
+	defer conn.Close()
+	cl := &RelayClient{
+		Address: conn.RemoteAddr().String(),
+		conn:    conn,
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := ReadMagic(conn); err != nil {
+		return
+	}
+	msg, err := ReadMessage(conn, MaxClientMsgLen)
+	if err != nil || msg.Op != ClientHello {
+		return
+	}
+	hello := message.NewBuffer(msg.Data)
+	protocol := hello.ReadShort() & 0xffff
+	hello.ReadLong() // flags
+	hello.ReadLong() // reserved
+	cl.Name = hello.ReadString()
+	password := hello.ReadString()
+	cl.Version = hello.ReadString()
+	if err := WriteMagic(conn); err != nil {
+		return
+	}
+	if protocol != ProtocolVersion {
+		WriteMessage(conn, ServerBadRequest, nil)
+		return
+	}
+	if r.Password != "" && password != r.Password {
+		WriteMessage(conn, ServerNoAccess, nil)
+		return
+	}
+
+	r.lock.Lock()
+	if r.closed || len(r.clients) >= r.MaxClients {
+		r.lock.Unlock()
+		reason := message.NewBuffer(nil)
+		reason.WriteString("too many clients")
+		WriteMessage(conn, ServerError, reason.Data)
+		return
+	}
+	cl.queue = make(chan Message, max(r.QueueSize, 1))
+	r.clients[cl] = true
+	r.lock.Unlock()
+	if r.Verbose {
+		log.Printf("GTV client %q connected from %s\n", cl.Name, cl.Address)
+	}
+	conn.SetDeadline(time.Time{})
+
+	reply := message.NewBuffer(nil)
+	reply.WriteLong(0) // no deflate, no stringcmds
+	reply.WriteLong(0) // reserved
+	if err := WriteMessage(conn, ServerHello, reply.Data); err != nil {
+		r.remove(cl)
+		return
+	}
+
+	// all writes after the hello happen here so the order is kept
+	go func() {
+		for msg := range cl.queue {
+			if err := WriteMessage(conn, msg.Op, msg.Data); err != nil {
+				conn.Close()
+				return
+			}
+		}
+		conn.Close()
+	}()
+
+	for {
+		msg, err := ReadMessage(conn, MaxClientMsgLen)
+		if err != nil {
+			r.remove(cl)
+			return
+		}
+		if msg.Op == ClientStreamStart {
+			// the snapshot has to match the next packet relayed
+			r.parseLock.Lock()
+		}
+		r.lock.Lock()
+		switch msg.Op {
+		case ClientPing:
+			r.enqueue(cl, Message{Op: ServerPong})
+		case ClientStreamStart:
+			if cl.Streaming {
+				break
+			}
+			snap, err := r.snapshot()
+			if err != nil || r.suspended {
+				snap = nil // nothing to see yet
+			}
+			if len(snap)+1 > MaxServerMsgLen {
+				// clients need the whole gamestate in a single message
+				log.Printf("gamestate snapshot for GTV client %s is %d bytes, more than the %d allowed\n", cl.Address, len(snap), MaxServerMsgLen)
+				reason := message.NewBuffer(nil)
+				reason.WriteString("gamestate too large")
+				r.enqueue(cl, Message{Op: ServerError, Data: reason.Data})
+				r.drop(cl)
+				break
+			}
+			r.enqueue(cl, Message{Op: ServerStreamStart})
+			r.enqueue(cl, Message{Op: ServerStreamData, Data: snap})
+			cl.Streaming = true
+		case ClientStreamStop:
+			if cl.Streaming {
+				cl.Streaming = false
+				r.enqueue(cl, Message{Op: ServerStreamStop})
+			}
+		case ClientStringCmd:
+			// not passed upstream
+		default:
+			r.enqueue(cl, Message{Op: ServerBadRequest})
+		}
+		r.lock.Unlock()
+		if msg.Op == ClientStreamStart {
+			r.parseLock.Unlock()
+		}
+	}
+}
+
+// Forget about a client that went away
+func (r *Relay) remove(cl *RelayClient) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.drop(cl)
+}
+
+// Disconnect a client once its queue is written. Must be called with the
+// lock held.
+func (r *Relay) drop(cl *RelayClient) {
+	if _, ok := r.clients[cl]; ok {
+		delete(r.clients, cl)
+		close(cl.queue)
+	}
+}
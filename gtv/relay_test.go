@@ -0,0 +1,310 @@
+package gtv
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// Read all the raw packets from a demo file
+func readPackets(t *testing.T, demofile string) [][]byte {
+	t.Helper()
+	f, err := os.Open(demofile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stream, err := demo.NewMVD2Stream(f)
+	if err != nil {
+		t.Fatalf("error creating stream: %v", err)
+	}
+	var packets [][]byte
+	for {
+		data, err := stream.NextRaw()
+		if err != nil {
+			break
+		}
+		packets = append(packets, data.Data)
+	}
+	return packets
+}
+
+// The players and entities that are still around, without events
+func liveState(state *pb.MvdDemo) (map[int32]*pb.PackedPlayer, map[int32]*pb.PackedEntity) {
+	players := make(map[int32]*pb.PackedPlayer)
+	for k, v := range state.GetPlayers() {
+		if v.GetInUse() {
+			players[k] = v.GetPlayerState()
+		}
+	}
+	ents := make(map[int32]*pb.PackedEntity)
+	for k, v := range state.GetEntities() {
+		if !v.GetRemove() {
+			ents[k] = v
+		}
+	}
+	return players, ents
+}
+
+func TestRelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		demofile string
+		joinAt   int // how many packets before the client joins
+	}{
+		{
+			name:     "join mid-demo",
+			demofile: "../testdata/test.mvd2",
+			joinAt:   3000,
+		},
+		{
+			name:     "join gzip demo",
+			demofile: "../testdata/ziptest.mvd2.gz",
+			joinAt:   400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			packets := readPackets(t, tc.demofile)
+			ref := demo.NewMVD2PacketParser()
+			for _, p := range packets {
+				buf := message.NewBuffer(p)
+				if _, err := ref.ParsePacket(&buf); err != nil {
+					t.Fatalf("error parsing reference: %v", err)
+				}
+			}
+
+			relay := NewRelay("127.0.0.1:0")
+			relay.QueueSize = len(packets)
+			if err := relay.Listen(); err != nil {
+				t.Fatalf("Listen() error: %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go relay.Serve(ctx)
+
+			for _, p := range packets[:tc.joinAt] {
+				if err := relay.Feed(p); err != nil {
+					t.Fatalf("Feed() error: %v", err)
+				}
+			}
+
+			client := NewClient(relay.Addr().String())
+			started := make(chan bool)
+			client.RegisterCallback(ServerStreamStart, func(any) {
+				close(started)
+			})
+			if err := client.Connect(); err != nil {
+				t.Fatalf("Connect() error: %v", err)
+			}
+			defer client.Close()
+			if got := len(relay.Clients()); got != 1 {
+				t.Errorf("relay client count = %d, want 1", got)
+			}
+			done := make(chan error)
+			go func() {
+				done <- client.Run(context.Background())
+			}()
+			client.StartStream()
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for stream start")
+			}
+
+			for _, p := range packets[tc.joinAt:] {
+				if err := relay.Feed(p); err != nil {
+					t.Fatalf("Feed() error: %v", err)
+				}
+			}
+			relay.Close()
+			if err := <-done; !errors.Is(err, ErrDisconnect) {
+				t.Fatalf("Run() error = %v, want %v", err, ErrDisconnect)
+			}
+
+			wantPlayers, wantEnts := liveState(ref.GetState())
+			gotPlayers, gotEnts := liveState(client.Parser.GetState())
+			if diff := cmp.Diff(wantPlayers, gotPlayers, protocmp.Transform()); diff != "" {
+				t.Errorf("player state mismatch (-want +got):\n%s", diff)
+			}
+			ignore := protocmp.IgnoreFields(&pb.PackedEntity{}, "event")
+			if diff := cmp.Diff(wantEnts, gotEnts, protocmp.Transform(), ignore); diff != "" {
+				t.Errorf("entity state mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRelayDelay(t *testing.T) {
+	packets := readPackets(t, "../testdata/test.mvd2")
+	relay := NewRelay("127.0.0.1:0")
+	relay.Delay = 300 * time.Millisecond
+	if err := relay.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Serve(ctx)
+
+	client := NewClient(relay.Addr().String())
+	started := make(chan bool)
+	received := make(chan time.Time, 10)
+	client.RegisterCallback(ServerStreamStart, func(any) {
+		close(started)
+	})
+	client.RegisterCallback(ServerStreamData, func(a any) {
+		if a.(*pb.MvdPacket) != nil {
+			received <- time.Now()
+		}
+	})
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer client.Close()
+	go client.Run(ctx)
+	client.StartStream()
+	<-started
+
+	fed := time.Now()
+	relay.Feed(packets[0])
+	select {
+	case at := <-received:
+		if at.Sub(fed) < relay.Delay {
+			t.Errorf("packet relayed after %v, want at least %v", at.Sub(fed), relay.Delay)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delayed packet")
+	}
+}
+
+func TestRelayCloseDelayed(t *testing.T) {
+	relay := NewRelay("")
+	relay.Delay = time.Hour
+	blocked := make(chan error)
+	go func() {
+		// the delay line holds one packet, one more fills the queue and the
+		// next blocks
+		for i := 0; i < DefaultRelayDelayQueue+2; i++ {
+			if err := relay.Feed(nil); err != nil {
+				blocked <- err
+				return
+			}
+		}
+		blocked <- nil
+	}()
+	time.Sleep(50 * time.Millisecond)
+	relay.Close()
+	select {
+	case err := <-blocked:
+		if err == nil {
+			t.Error("Feed() never blocked or succeeded after Close()")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Feed() still blocked after Close()")
+	}
+	if err := relay.Feed(nil); err == nil {
+		t.Error("Feed() succeeded after Close()")
+	}
+}
+
+// A packet that can't be parsed is dropped and the relay carries on
+func TestRelayBadPacket(t *testing.T) {
+	packets := readPackets(t, "../testdata/test.mvd2")
+	relay := NewRelay("")
+	if err := relay.Feed(packets[0]); err != nil {
+		t.Fatalf("Feed() gamestate error: %v", err)
+	}
+	if err := relay.Feed([]byte{demo.MVDSvcFrame, 1, 0, 'x', 0}); err == nil {
+		t.Error("Feed() of a truncated frame succeeded")
+	}
+	if err := relay.Feed(packets[1]); err != nil {
+		t.Errorf("Feed() after a bad packet error: %v", err)
+	}
+	if _, err := relay.Snapshot(); err != nil {
+		t.Errorf("Snapshot() error: %v", err)
+	}
+	if err := relay.Close(); err != nil {
+		t.Errorf("Close() error: %v", err)
+	}
+}
+
+func TestRelaySnapshotTooLarge(t *testing.T) {
+	relay := NewRelay("127.0.0.1:0")
+	relay.serverdata = &pb.MvdServerData{GameDirectory: "baseq2"}
+	for i := int32(0); i < 100; i++ {
+		relay.configs[i] = &pb.ConfigString{Index: uint32(i), Data: strings.Repeat("x", 400)}
+	}
+	if err := relay.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Serve(ctx)
+
+	client := NewClient(relay.Addr().String())
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer client.Close()
+	var reason string
+	client.RegisterCallback(ServerError, func(a any) {
+		reason = a.(string)
+	})
+	client.StartStream()
+	if err := client.Run(ctx); err == nil {
+		t.Error("Run() ended without an error")
+	}
+	if reason != "gamestate too large" {
+		t.Errorf("error reason = %q, want the gamestate being too large", reason)
+	}
+	if n := len(relay.Clients()); n != 0 {
+		t.Errorf("%d clients still connected", n)
+	}
+}
+
+func TestRelayPassword(t *testing.T) {
+	relay := NewRelay("127.0.0.1:0")
+	relay.Password = "secret"
+	if err := relay.Listen(); err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Serve(ctx)
+
+	client := NewClient(relay.Addr().String())
+	client.Password = "wrong"
+	if err := client.Connect(); err == nil {
+		t.Error("Connect() with bad password succeeded")
+	}
+	client.Password = "secret"
+	if err := client.Connect(); err != nil {
+		t.Errorf("Connect() error: %v", err)
+	}
+	client.Close()
+}
+
+func TestRelayEnqueue(t *testing.T) {
+	relay := NewRelay("")
+	cl := &RelayClient{queue: make(chan Message, 2)}
+	relay.clients[cl] = true
+	relay.enqueue(cl, Message{Op: ServerPong})
+	relay.enqueue(cl, Message{Op: ServerPong})
+	if _, ok := relay.clients[cl]; !ok {
+		t.Fatal("client dropped before queue was full")
+	}
+	relay.enqueue(cl, Message{Op: ServerPong})
+	if _, ok := relay.clients[cl]; ok {
+		t.Error("slow client not dropped")
+	}
+	relay.enqueue(cl, Message{Op: ServerPong}) // shouldn't panic
+}
@@ -0,0 +1,166 @@
+package heatmap
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/packetflinger/libq2/bsp"
+)
+
+const (
+	DefaultWidth = 512 // pixels
+	SplatRadius  = 2   // pixels around each position that get heat
+)
+
+// A grid of counts projected from the top down (X/Y) onto the bounds of a
+// map. Each cell is a pixel in the rendered image.
+type Heatmap struct {
+	Width    int
+	Height   int
+	Mins     bsp.Vertex // map bounds
+	Maxs     bsp.Vertex
+	cells    []float64
+	geometry []bool // pixels with map vertices, drawn as the background
+}
+
+// Create an empty heatmap for the given bounds. The height is picked to keep
+// the map's aspect ratio.
+func NewHeatmap(mins, maxs bsp.Vertex, width int) (*Heatmap, error) {
+	if maxs.X <= mins.X || maxs.Y <= mins.Y {
+		return nil, fmt.Errorf("invalid bounds: %v - %v", mins, maxs)
+	}
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	height := max(width*(maxs.Y-mins.Y)/(maxs.X-mins.X), 1)
+	return &Heatmap{
+		Width:    width,
+		Height:   height,
+		Mins:     mins,
+		Maxs:     maxs,
+		cells:    make([]float64, width*height),
+		geometry: make([]bool, width*height),
+	}, nil
+}
+
+// Create an empty heatmap sized to a map, with the map's vertices drawn
+// faintly underneath the heat.
+func NewMapHeatmap(b *bsp.BSPFile, width int) (*Heatmap, error) {
+	verts := b.FetchVertices()
+	mins, maxs := bsp.Bounds(verts)
+	h, err := NewHeatmap(mins, maxs, width)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verts {
+		if x, y, ok := h.pixel(v.X, v.Y); ok {
+			h.geometry[y*h.Width+x] = true
+		}
+	}
+	return h, nil
+}
+
+// Convert map coordinates to a pixel. Map Y grows north, image Y grows down.
+func (h *Heatmap) pixel(x, y int) (int, int, bool) {
+	if x < h.Mins.X || x > h.Maxs.X || y < h.Mins.Y || y > h.Maxs.Y {
+		return 0, 0, false
+	}
+	px := (x - h.Mins.X) * (h.Width - 1) / (h.Maxs.X - h.Mins.X)
+	py := (h.Maxs.Y - y) * (h.Height - 1) / (h.Maxs.Y - h.Mins.Y)
+	return px, py, true
+}
+
+// Add some heat at a map location. Locations outside the bounds are ignored.
+func (h *Heatmap) Add(x, y int, weight float64) {
+	px, py, ok := h.pixel(x, y)
+	if !ok {
+		return
+	}
+	for dy := -SplatRadius; dy <= SplatRadius; dy++ {
+		for dx := -SplatRadius; dx <= SplatRadius; dx++ {
+			cx, cy := px+dx, py+dy
+			if cx < 0 || cy < 0 || cx >= h.Width || cy >= h.Height {
+				continue
+			}
+			dist := math.Hypot(float64(dx), float64(dy))
+			if dist > SplatRadius {
+				continue
+			}
+			h.cells[cy*h.Width+cx] += weight * (1 - dist/(SplatRadius+1))
+		}
+	}
+}
+
+// Add every position a player was alive at
+func (h *Heatmap) AddTrack(t *Track) {
+	for _, p := range t.Positions {
+		if p.Alive {
+			h.Add(p.X, p.Y, 1)
+		}
+	}
+}
+
+// Add the places a player died
+func (h *Heatmap) AddDeaths(t *Track) {
+	for _, p := range t.Deaths {
+		h.Add(p.X, p.Y, 1)
+	}
+}
+
+// How much heat is in the cell for a pixel
+func (h *Heatmap) Value(x, y int) float64 {
+	if x < 0 || y < 0 || x >= h.Width || y >= h.Height {
+		return 0
+	}
+	return h.cells[y*h.Width+x]
+}
+
+// Render the heatmap. Heat is scaled logarithmically so places that were
+// only briefly visited still show up.
+func (h *Heatmap) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, h.Width, h.Height))
+	hottest := 0.0
+	for _, c := range h.cells {
+		hottest = max(hottest, c)
+	}
+	for y := 0; y < h.Height; y++ {
+		for x := 0; x < h.Width; x++ {
+			i := y*h.Width + x
+			c := color.RGBA{A: 255}
+			if h.geometry[i] {
+				c = color.RGBA{R: 64, G: 64, B: 64, A: 255}
+			}
+			if h.cells[i] > 0 {
+				c = ramp(math.Log1p(h.cells[i]) / math.Log1p(hottest))
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// Write the heatmap as a PNG image
+func (h *Heatmap) WritePNG(w io.Writer) error {
+	return png.Encode(w, h.Image())
+}
+
+// Blue (cold) through green and yellow to red (hot), for 0.0-1.0
+func ramp(v float64) color.RGBA {
+	v = min(max(v, 0), 1)
+	var r, g, b float64
+	switch {
+	case v < 0.25:
+		g, b = v/0.25, 1
+	case v < 0.5:
+		g, b = 1, 1-(v-0.25)/0.25
+	case v < 0.75:
+		r, g = (v-0.5)/0.25, 1
+	default:
+		r, g = 1, 1-(v-0.75)/0.25
+	}
+	return color.RGBA{R: uint8(r * 255), G: uint8(g * 255), B: uint8(b * 255), A: 255}
+}
@@ -0,0 +1,111 @@
+package heatmap
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/packetflinger/libq2/bsp"
+	"github.com/packetflinger/libq2/demo"
+)
+
+func TestFromMVD2(t *testing.T) {
+	parser, err := demo.NewMVD2Parser("../testdata/test.mvd2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demos, err := parser.Unmarshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracks := FromMVD2(demos[0])
+	claire, ok := tracks["claire"]
+	if !ok {
+		t.Fatal("no track for claire")
+	}
+	if got := len(claire.Deaths); got != 8 {
+		t.Errorf("claire died %d times, want 8", got)
+	}
+	if len(claire.Positions) == 0 {
+		t.Fatal("no positions for claire")
+	}
+	last := int32(-1)
+	for _, p := range claire.Positions {
+		if p.Frame <= last {
+			t.Fatalf("positions out of order: frame %d after %d", p.Frame, last)
+		}
+		last = p.Frame
+	}
+}
+
+func TestHeatmapAdd(t *testing.T) {
+	mins := bsp.Vertex{X: -100, Y: -100}
+	maxs := bsp.Vertex{X: 100, Y: 100}
+	tests := []struct {
+		name   string
+		x, y   int
+		px, py int // the pixel expected to be hottest
+		inside bool
+	}{
+		{name: "center", x: 0, y: 0, px: 49, py: 49, inside: true},
+		{name: "north west", x: -100, y: 100, px: 0, py: 0, inside: true},
+		{name: "south east", x: 100, y: -100, px: 99, py: 99, inside: true},
+		{name: "out of bounds", x: 500, y: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewHeatmap(mins, maxs, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Add(tc.x, tc.y, 1)
+			if !tc.inside {
+				for _, c := range h.cells {
+					if c != 0 {
+						t.Fatal("heat added outside of the bounds")
+					}
+				}
+				return
+			}
+			if got := h.Value(tc.px, tc.py); got != 1 {
+				t.Errorf("Value(%d, %d) = %f, want 1", tc.px, tc.py, got)
+			}
+		})
+	}
+}
+
+func TestNewHeatmapBadBounds(t *testing.T) {
+	if _, err := NewHeatmap(bsp.Vertex{X: 10}, bsp.Vertex{X: 10, Y: 10}, 0); err == nil {
+		t.Error("NewHeatmap() with zero width bounds succeeded")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	b, err := bsp.OpenBSPFile("../testdata/backup.bsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	h, err := NewMapHeatmap(b, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.AddTrack(&Track{Positions: []Position{{X: 0, Y: 0, Alive: true}, {X: 8, Y: 8, Alive: true}}})
+	h.AddDeaths(&Track{Deaths: []Position{{X: 100, Y: 100}}})
+
+	var out bytes.Buffer
+	if err := h.WritePNG(&out); err != nil {
+		t.Fatalf("WritePNG() error: %v", err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatalf("error decoding png: %v", err)
+	}
+	// backup.bsp is 912x1104 units
+	if got, want := img.Bounds().Dx(), 256; got != want {
+		t.Errorf("width = %d, want %d", got, want)
+	}
+	if got, want := img.Bounds().Dy(), 309; got != want {
+		t.Errorf("height = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+// Package heatmap extracts where players were over the course of a demo and
+// renders that as 2D heatmaps over the map.
+//
+// Positions come from playerstates. Regular (.dm2) demos only have the
+// playerstate of the player who recorded it, multi-view demos have everyone.
+package heatmap
+
+import (
+	"strings"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Where a player was at a point in time. Coordinates are whole map units.
+type Position struct {
+	Frame int32
+	Time  int32 // msecs
+	X     int
+	Y     int
+	Z     int
+	Alive bool
+}
+
+// The positions of a single player over time
+type Track struct {
+	Name      string
+	Client    int32
+	Positions []Position
+	Deaths    []Position // where they were when they died
+}
+
+// Get tracks for every player in a regular demo
+func FromDM2(dm2 *pb.DM2Demo) map[string]*Track {
+	return FromEvents(demo.DM2Events(dm2, demo.DefaultFrameRate))
+}
+
+// Get tracks for every player in a multi-view demo
+func FromMVD2(mvd *pb.MvdDemo) map[string]*Track {
+	return FromEvents(demo.MVD2Events(mvd, demo.DefaultFrameRate))
+}
+
+// Get tracks for every player from a stream of demo events, keyed by player
+// name. Players are only tracked while they're in the game, spectators are
+// skipped.
+func FromEvents(events []*pb.DemoEvent) map[string]*Track {
+	tracks := make(map[string]*Track)
+	names := make(map[int32]string)
+	alive := make(map[int32]bool)
+	skins := int32(message.CSPlayerSkins)
+	recorder := int32(-1)
+	dummy := int32(-1)
+
+	add := func(ev *pb.DemoEvent, num int32, ps *pb.PackedPlayer) {
+		name, ok := names[num]
+		if !ok || num == dummy {
+			return
+		}
+		pm := ps.GetMovestate()
+		if pm.GetType() == message.PMSpectator || pm.GetType() == message.PMFreeze {
+			return
+		}
+		track, ok := tracks[name]
+		if !ok {
+			track = &Track{Name: name}
+			tracks[name] = track
+		}
+		track.Client = num
+		pos := Position{
+			Frame: ev.GetFrame(),
+			Time:  ev.GetServerTime(),
+			X:     int(pm.GetOriginX()) / 8, // 1/8 unit precision
+			Y:     int(pm.GetOriginY()) / 8,
+			Z:     int(pm.GetOriginZ()) / 8,
+			Alive: pm.GetType() == message.PMNormal && ps.GetStats()[message.StatHealth] > 0,
+		}
+		if alive[num] && !pos.Alive {
+			track.Deaths = append(track.Deaths, pos)
+		}
+		alive[num] = pos.Alive
+		track.Positions = append(track.Positions, pos)
+	}
+
+	states := make(map[int32]*pb.PackedPlayer)
+	for _, ev := range events {
+		if sd := ev.GetServerdata(); sd != nil {
+			recorder = int32(sd.GetClientNumber())
+		}
+		if sd := ev.GetMvdServerdata(); sd != nil {
+			dummy = sd.GetDummyClient()
+			if remap := sd.GetRemap(); remap != nil {
+				skins = remap.GetPlayerSkins()
+			}
+			states = make(map[int32]*pb.PackedPlayer)
+		}
+		if cs := ev.GetConfigstring(); cs != nil {
+			num := int32(cs.GetIndex()) - skins
+			if num >= 0 && num < demo.MaxClients {
+				name, _, _ := strings.Cut(cs.GetData(), "\\")
+				if name == "" {
+					delete(names, num)
+				} else {
+					names[num] = name
+				}
+			}
+		}
+		if fr := ev.GetDm2Frame(); fr != nil && fr.GetPlayerState() != nil && recorder >= 0 {
+			add(ev, recorder, fr.GetPlayerState())
+		}
+		if fr := ev.GetMvdFrame(); fr != nil {
+			for num, ps := range fr.GetPlayers() {
+				states[num] = ps
+			}
+			for _, num := range fr.GetRemovedPlayers() {
+				delete(states, num)
+				delete(alive, num)
+			}
+			// unchanged players are still somewhere
+			for num, ps := range states {
+				add(ev, num, ps)
+			}
+		}
+	}
+	return tracks
+}
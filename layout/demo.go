@@ -0,0 +1,77 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/packetflinger/libq2/demo"
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Run every layout sent in a regular demo
+func FromDM2(dm2 *pb.DM2Demo) ([]*Scoreboard, error) {
+	return FromEvents(demo.DM2Events(dm2, demo.DefaultFrameRate))
+}
+
+// Run every layout sent to any player in a multi-view demo
+func FromMVD2(mvd *pb.MvdDemo) ([]*Scoreboard, error) {
+	return FromEvents(demo.MVD2Events(mvd, demo.DefaultFrameRate))
+}
+
+// Run every layout in a stream of demo events. Each one is run against the
+// configstrings and the stats of the player it was sent to at that point in
+// the demo.
+func FromEvents(events []*pb.DemoEvent) ([]*Scoreboard, error) {
+	var out []*Scoreboard
+	configs := make(map[int32]string)
+	stats := make(map[int32]map[uint32]int32)
+	in := NewInterpreter(configs, nil)
+	recorder := int32(0)
+
+	run := func(ev *pb.DemoEvent, client int32, lo *pb.Layout) error {
+		in.Stats = stats[client]
+		sb, err := in.Execute(lo.GetData())
+		if err != nil {
+			return fmt.Errorf("frame %d: %v", ev.GetFrame(), err)
+		}
+		sb.Frame = ev.GetFrame()
+		sb.Client = client
+		out = append(out, sb)
+		return nil
+	}
+
+	for _, ev := range events {
+		if sd := ev.GetServerdata(); sd != nil {
+			recorder = int32(sd.GetClientNumber())
+		}
+		if sd := ev.GetMvdServerdata(); sd != nil {
+			if remap := sd.GetRemap(); remap != nil {
+				in.Images = remap.GetImages()
+				in.Items = remap.GetItems()
+				in.PlayerSkins = remap.GetPlayerSkins()
+				in.General = remap.GetGeneral()
+			}
+		}
+		if cs := ev.GetConfigstring(); cs != nil {
+			configs[int32(cs.GetIndex())] = cs.GetData()
+		}
+		if fr := ev.GetDm2Frame(); fr != nil && fr.GetPlayerState() != nil {
+			stats[recorder] = fr.GetPlayerState().GetStats()
+		}
+		if fr := ev.GetMvdFrame(); fr != nil {
+			for num, ps := range fr.GetPlayers() {
+				stats[num] = ps.GetStats()
+			}
+		}
+		if lo := ev.GetLayout(); lo != nil {
+			if err := run(ev, recorder, lo); err != nil {
+				return nil, err
+			}
+		}
+		for _, lo := range ev.GetUnicast().GetLayouts() {
+			if err := run(ev, ev.GetUnicast().GetClientNumber(), lo); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
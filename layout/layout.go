@@ -0,0 +1,353 @@
+// Package layout interprets Quake 2 layout programs. Layouts are little
+// scripts sent by the server (svc_layout and the statusbar configstring) that
+// tell the client what to draw on the screen: scoreboards, the hud, etc.
+//
+// Running a layout against the configstrings and playerstate stats produces a
+// list of the things that would be drawn, and the scoreboard entries pulled
+// out of any `client` and `ctf` commands. No screen scraping required.
+package layout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/packetflinger/libq2/message"
+)
+
+const (
+	VirtualWidth  = 320 // the screen layouts are designed for
+	VirtualHeight = 240
+)
+
+// Kinds of things a layout draws
+const (
+	ElementPic    = iota // pic, picn
+	ElementNumber        // num, hnum, anum, rnum
+	ElementString        // string, string2, cstring, cstring2, stat_string
+	ElementClient        // client, ctf
+)
+
+// Something drawn by a layout. Coordinates are in screen pixels.
+type Element struct {
+	Kind     int
+	X        int
+	Y        int
+	Text     string // image name or text
+	Value    int    // for numbers
+	Width    int    // digits in a number
+	Alt      bool   // highlighted (green) text
+	Centered bool   // text is centered on X
+}
+
+// A player's line on the scoreboard
+type ScoreboardEntry struct {
+	Client int
+	Name   string
+	Score  int
+	Ping   int
+	Time   int // minutes, not in ctf entries
+	X      int
+	Y      int
+	CTF    bool
+}
+
+// Everything a layout draws
+type Scoreboard struct {
+	Frame    int32 // where in the demo, if from a demo
+	Client   int32 // who it was sent to, if from a demo
+	Players  []ScoreboardEntry
+	Elements []Element
+}
+
+// Interpreter holds the state a layout is run against.
+type Interpreter struct {
+	Width         int // screen size
+	Height        int
+	Images        int32 // configstring offsets
+	Items         int32
+	PlayerSkins   int32
+	General       int32
+	Configstrings map[int32]string
+	Stats         map[uint32]int32
+}
+
+// Create an interpreter for a virtual 320x240 screen using the original
+// configstring layout.
+func NewInterpreter(configs map[int32]string, stats map[uint32]int32) *Interpreter {
+	if configs == nil {
+		configs = make(map[int32]string)
+	}
+	if stats == nil {
+		stats = make(map[uint32]int32)
+	}
+	return &Interpreter{
+		Width:         VirtualWidth,
+		Height:        VirtualHeight,
+		Images:        message.CSImages,
+		Items:         message.CSItems,
+		PlayerSkins:   message.CSPlayerSkins,
+		General:       message.CSGeneral,
+		Configstrings: configs,
+		Stats:         stats,
+	}
+}
+
+// Run a layout program using a default interpreter
+func Execute(layout string, configs map[int32]string, stats map[uint32]int32) (*Scoreboard, error) {
+	return NewInterpreter(configs, stats).Execute(layout)
+}
+
+// Run a layout program. Unknown commands are skipped like the client does,
+// mods sometimes add their own.
+func (in *Interpreter) Execute(layout string) (*Scoreboard, error) {
+	sb := &Scoreboard{}
+	tokens := Tokenize(layout)
+	x, y := 0, 0
+	i := 0
+	cmd := ""
+
+	// the next token(s) as arguments
+	next := func() (string, error) {
+		if i >= len(tokens) {
+			return "", fmt.Errorf("%q missing argument", cmd)
+		}
+		i++
+		return tokens[i-1], nil
+	}
+	nextInt := func() (int, error) {
+		tok, err := next()
+		if err != nil {
+			return 0, err
+		}
+		return atoi(tok), nil
+	}
+
+	for i < len(tokens) {
+		cmd = tokens[i]
+		i++
+		switch cmd {
+		case "xl", "xr", "xv", "yt", "yb", "yv":
+			v, err := nextInt()
+			if err != nil {
+				return nil, err
+			}
+			switch cmd {
+			case "xl":
+				x = v
+			case "xr":
+				x = in.Width + v
+			case "xv":
+				x = in.Width/2 - VirtualWidth/2 + v
+			case "yt":
+				y = v
+			case "yb":
+				y = in.Height + v
+			case "yv":
+				y = in.Height/2 - VirtualHeight/2 + v
+			}
+
+		case "pic":
+			stat, err := nextInt()
+			if err != nil {
+				return nil, err
+			}
+			idx := in.stat(stat)
+			if idx == 0 {
+				break // nothing to draw
+			}
+			sb.Elements = append(sb.Elements, Element{
+				Kind: ElementPic,
+				X:    x,
+				Y:    y,
+				Text: in.Configstrings[in.Images+int32(idx)],
+			})
+
+		case "picn":
+			name, err := next()
+			if err != nil {
+				return nil, err
+			}
+			sb.Elements = append(sb.Elements, Element{Kind: ElementPic, X: x, Y: y, Text: name})
+
+		case "client", "ctf":
+			args := 6 // x, y, clientnum, score, ping, time
+			if cmd == "ctf" {
+				args = 5
+			}
+			vals := make([]int, args)
+			for n := range vals {
+				v, err := nextInt()
+				if err != nil {
+					return nil, err
+				}
+				vals[n] = v
+			}
+			entry := ScoreboardEntry{
+				X:      in.Width/2 - VirtualWidth/2 + vals[0],
+				Y:      in.Height/2 - VirtualHeight/2 + vals[1],
+				Client: vals[2],
+				Score:  vals[3],
+				Ping:   vals[4],
+				CTF:    cmd == "ctf",
+			}
+			if cmd == "client" {
+				entry.Time = vals[5]
+			}
+			entry.Name = in.name(entry.Client)
+			sb.Players = append(sb.Players, entry)
+			sb.Elements = append(sb.Elements, Element{
+				Kind:  ElementClient,
+				X:     entry.X,
+				Y:     entry.Y,
+				Text:  entry.Name,
+				Value: entry.Score,
+			})
+
+		case "num", "hnum", "anum", "rnum":
+			width, stat := 3, 0
+			switch cmd {
+			case "num":
+				w, err := nextInt()
+				if err != nil {
+					return nil, err
+				}
+				s, err := nextInt()
+				if err != nil {
+					return nil, err
+				}
+				width, stat = w, s
+			case "hnum":
+				stat = message.StatHealth
+			case "anum":
+				stat = message.StatAmmo
+			case "rnum":
+				stat = message.StatArmor
+			}
+			sb.Elements = append(sb.Elements, Element{
+				Kind:  ElementNumber,
+				X:     x,
+				Y:     y,
+				Value: in.stat(stat),
+				Width: width,
+			})
+
+		case "string", "string2", "cstring", "cstring2":
+			text, err := next()
+			if err != nil {
+				return nil, err
+			}
+			sb.Elements = append(sb.Elements, Element{
+				Kind:     ElementString,
+				X:        x,
+				Y:        y,
+				Text:     text,
+				Alt:      strings.HasSuffix(cmd, "2"),
+				Centered: strings.HasPrefix(cmd, "c"),
+			})
+
+		case "stat_string":
+			stat, err := nextInt()
+			if err != nil {
+				return nil, err
+			}
+			sb.Elements = append(sb.Elements, Element{
+				Kind: ElementString,
+				X:    x,
+				Y:    y,
+				Text: in.Configstrings[in.configIndex(in.stat(stat))],
+			})
+
+		case "if":
+			stat, err := nextInt()
+			if err != nil {
+				return nil, err
+			}
+			if in.stat(stat) != 0 {
+				break
+			}
+			// skip to the next endif like the client does, ifs don't nest.
+			// A missing endif skips the rest.
+			for i < len(tokens) && tokens[i] != "endif" {
+				i++
+			}
+			i++
+
+		case "endif":
+			// end of a true if
+		}
+	}
+	return sb, nil
+}
+
+// The value of a stat, unset stats are zero
+func (in *Interpreter) stat(index int) int {
+	return int(in.Stats[uint32(index)])
+}
+
+// Games put configstring indexes in stats (like STAT_PICKUP_STRING) using the
+// original layout. Move them to wherever those configstrings are for this
+// interpreter, extended MVD demos have them somewhere else.
+func (in *Interpreter) configIndex(index int) int32 {
+	ranges := []struct {
+		start, size, offset int32
+	}{
+		{message.CSImages, 256, in.Images},
+		{message.CSItems, message.MaxItems, in.Items},
+		{message.CSPlayerSkins, 256, in.PlayerSkins},
+		{message.CSGeneral, 512, in.General},
+	}
+	for _, r := range ranges {
+		if int32(index) >= r.start && int32(index) < r.start+r.size {
+			return int32(index) - r.start + r.offset
+		}
+	}
+	return int32(index)
+}
+
+// A player's name from their skin configstring
+func (in *Interpreter) name(client int) string {
+	name, _, _ := strings.Cut(in.Configstrings[in.PlayerSkins+int32(client)], "\\")
+	return name
+}
+
+// Split a layout into tokens. Tokens are separated by whitespace, double
+// quotes group words into a single token.
+func Tokenize(layout string) []string {
+	var tokens []string
+	var tok strings.Builder
+	quoted, inToken := false, false
+	for _, r := range layout {
+		switch {
+		case r == '"':
+			if quoted {
+				tokens = append(tokens, tok.String())
+				tok.Reset()
+				inToken = false
+			}
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if inToken {
+				tokens = append(tokens, tok.String())
+				tok.Reset()
+				inToken = false
+			}
+		default:
+			tok.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken || quoted {
+		tokens = append(tokens, tok.String())
+	}
+	return tokens
+}
+
+// Like C's atoi(), garbage is 0
+func atoi(s string) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,230 @@
+package layout
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   []string
+	}{
+		{
+			name:   "plain",
+			layout: "xv 32 yv 8 picn tag1",
+			want:   []string{"xv", "32", "yv", "8", "picn", "tag1"},
+		},
+		{
+			name:   "quoted",
+			layout: `xv 72 string2 " Team   Frags"  yv  8`,
+			want:   []string{"xv", "72", "string2", " Team   Frags", "yv", "8"},
+		},
+		{
+			name:   "empty quotes",
+			layout: `string ""`,
+			want:   []string{"string", ""},
+		},
+		{
+			name:   "unterminated quote",
+			layout: `string "abc`,
+			want:   []string{"string", "abc"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Tokenize(tc.layout)); diff != "" {
+				t.Errorf("Tokenize(%q) mismatch (-want +got):\n%s", tc.layout, diff)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	configs := map[int32]string{
+		message.CSPlayerSkins + 0: "claire\\female/athena",
+		message.CSPlayerSkins + 3: "shloo\\male/grunt",
+		message.CSImages + 7:      "i_health",
+		message.CSItems + 5:       "Rocket Launcher",
+	}
+	stats := map[uint32]int32{
+		message.StatHealth:       75,
+		message.StatHealthIcon:   7,
+		message.StatPickupString: message.CSItems + 5,
+		message.StatFrags:        12,
+	}
+	tests := []struct {
+		name     string
+		layout   string
+		players  []ScoreboardEntry
+		elements []Element
+		wantErr  bool
+	}{
+		{
+			name:   "deathmatch scoreboard",
+			layout: "xv 32 yv 16 picn tag1 client 32 16 0 12 48 5 client 32 48 3 -1 120 10",
+			players: []ScoreboardEntry{
+				{Client: 0, Name: "claire", Score: 12, Ping: 48, Time: 5, X: 32, Y: 16},
+				{Client: 3, Name: "shloo", Score: -1, Ping: 120, Time: 10, X: 32, Y: 48},
+			},
+			elements: []Element{
+				{Kind: ElementPic, X: 32, Y: 16, Text: "tag1"},
+				{Kind: ElementClient, X: 32, Y: 16, Text: "claire", Value: 12},
+				{Kind: ElementClient, X: 32, Y: 48, Text: "shloo", Value: -1},
+			},
+		},
+		{
+			name:   "ctf",
+			layout: "ctf 0 32 3 7 99",
+			players: []ScoreboardEntry{
+				{Client: 3, Name: "shloo", Score: 7, Ping: 99, X: 0, Y: 32, CTF: true},
+			},
+			elements: []Element{
+				{Kind: ElementClient, X: 0, Y: 32, Text: "shloo", Value: 7},
+			},
+		},
+		{
+			name:   "statusbar",
+			layout: "yb -24 xv 0 hnum xv 50 pic 0 if 2 xv 100 anum endif if 8 xv 150 stat_string 8 endif xr -50 num 3 14",
+			elements: []Element{
+				{Kind: ElementNumber, X: 0, Y: 216, Value: 75, Width: 3},
+				{Kind: ElementPic, X: 50, Y: 216, Text: "i_health"},
+				{Kind: ElementString, X: 150, Y: 216, Text: "Rocket Launcher"},
+				{Kind: ElementNumber, X: 270, Y: 216, Value: 12, Width: 3},
+			},
+		},
+		{
+			name:   "ifs don't nest",
+			layout: "if 3 if 1 string a endif string b endif cstring2 c",
+			elements: []Element{
+				{Kind: ElementString, Text: "b"},
+				{Kind: ElementString, Text: "c", Alt: true, Centered: true},
+			},
+		},
+		{
+			name:   "if without endif",
+			layout: "string a if 2 string b",
+			elements: []Element{
+				{Kind: ElementString, Text: "a"},
+			},
+		},
+		{
+			name:   "unknown commands",
+			layout: "xl 8 yt 4 color 1 string hi",
+			elements: []Element{
+				{Kind: ElementString, X: 8, Y: 4, Text: "hi"},
+			},
+		},
+		{
+			name:    "missing argument",
+			layout:  "client 32 16 0",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Execute(tc.layout, configs, stats)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Execute(%q) error = %v, wantErr %t", tc.layout, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.players, got.Players); diff != "" {
+				t.Errorf("players mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.elements, got.Elements); diff != "" {
+				t.Errorf("elements mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExecuteRemapped(t *testing.T) {
+	// extended mvd layout, stats still use the original indexes
+	in := NewInterpreter(map[int32]string{
+		1568 + 7:            "i_health",
+		5120 + 5:            "Rocket Launcher",
+		7168 + 2:            "dm_flag",
+		message.CSItems + 5: "wrong",
+	}, map[uint32]int32{
+		message.StatHealthIcon:   7,
+		message.StatPickupString: message.CSItems + 5,
+		message.StatAmmoIcon:     message.CSGeneral + 2,
+		message.StatArmor:        message.CSMapname,
+	})
+	in.Images = 1568
+	in.Items = 5120
+	in.General = 7168
+	in.Configstrings[message.CSMapname] = "q2dm1"
+
+	got, err := in.Execute("pic 0 stat_string 8 stat_string 2 stat_string 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Element{
+		{Kind: ElementPic, Text: "i_health"},
+		{Kind: ElementString, Text: "Rocket Launcher"},
+		{Kind: ElementString, Text: "dm_flag"},
+		{Kind: ElementString, Text: "q2dm1"},
+	}
+	if diff := cmp.Diff(want, got.Elements); diff != "" {
+		t.Errorf("elements mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromDM2(t *testing.T) {
+	content, err := os.ReadFile("../testdata/testduel.dm2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := demo.NewDM2Parser()
+	if err := parser.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	boards, err := FromDM2(parser.GetTextProto())
+	if err != nil {
+		t.Fatalf("FromDM2() error: %v", err)
+	}
+	if len(boards) != 2 {
+		t.Fatalf("got %d scoreboards, want 2", len(boards))
+	}
+	want := Element{Kind: ElementString, X: 72, Y: 16, Text: "claire             2"}
+	if diff := cmp.Diff(want, boards[0].Elements[2]); diff != "" {
+		t.Errorf("scoreboard line mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromMVD2(t *testing.T) {
+	parser, err := demo.NewMVD2Parser("../testdata/test.mvd2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demos, err := parser.Unmarshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	boards, err := FromMVD2(demos[0])
+	if err != nil {
+		t.Fatalf("FromMVD2() error: %v", err)
+	}
+	// unicast centerprints used to be misread as an extra empty layout
+	if len(boards) != 205 {
+		t.Fatalf("got %d scoreboards, want 205", len(boards))
+	}
+	// an empty layout clears the screen
+	empty := 0
+	for _, sb := range boards {
+		if len(sb.Elements) == 0 {
+			empty++
+		}
+	}
+	if empty == len(boards) {
+		t.Error("all scoreboards are empty")
+	}
+}
@@ -3,6 +3,9 @@ package master
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/packetflinger/libq2/message"
 )
@@ -12,29 +15,117 @@ type Client struct {
 	Port int
 }
 
+// The classic response is this header followed by 6 byte IPv4+port entries.
+// Clients asking with "getservers ipv6" get the IPv6 header and 18 byte
+// entries instead, IPv4 servers as IPv4-mapped addresses. Long lists are split
+// into several packets of up to the EntriesPerPacket for the format, a packet
+// with fewer is the last one.
+const (
+	ClassicResponseHeader   = "servers "
+	ClassicEntriesPerPacket = (MaxResponseSize - 4 - len(ClassicResponseHeader)) / 6
+	IPv6ResponseHeader      = "servers6 "
+	IPv6EntriesPerPacket    = (MaxResponseSize - 4 - len(IPv6ResponseHeader)) / 18
+)
+
+const (
+	fetchTimeout = time.Second            // master went quiet before the last packet
+	fetchSettle  = 250 * time.Millisecond // wait for late packets after the last one
+)
+
 // FetchPublicServers will ask a master server for the list of servers sending
 // heartbeats to it. The master server knows nothing more about these game
 // servers than their IP and port and that they're alive and active.
+//
+// Lists split over several packets are put back together. Other masters may
+// split their lists into smaller packets than we do, so packets are read
+// until one that isn't full has arrived and no more follow it, or until the
+// master goes quiet for a second. In that case whatever arrived is returned.
+// Only IPv4 servers are listed, see FetchPublicServers6().
 func (m *MasterServer) FetchPublicServers() ([]MasterClient, error) {
-	//var out []MasterClient
-	connectionless := message.ConnectionlessPacket{
-		Data: "getservers",
+	servers, _, err := m.fetchPublicServers()
+	return servers, err
+}
+
+// Same as FetchPublicServers() but using the IPv6 format, IPv6 servers
+// included.
+func (m *MasterServer) FetchPublicServers6() ([]MasterClient, error) {
+	servers, _, err := m.fetchServers("getservers ipv6", IPv6ResponseHeader, IPv6EntriesPerPacket, ParseMasterResponse6)
+	return servers, err
+}
+
+// FetchPublicServers() that also says if the whole list arrived
+func (m *MasterServer) fetchPublicServers() ([]MasterClient, bool, error) {
+	return m.fetchServers("getservers", ClassicResponseHeader, ClassicEntriesPerPacket, ParseMasterResponse)
+}
+
+// Request a server list and read the response packets. The list is complete
+// when the last packet (one with fewer than perPacket entries) arrived.
+func (m *MasterServer) fetchServers(request, header string, perPacket int, parse func(*message.Buffer) []MasterClient) ([]MasterClient, bool, error) {
+	network := "udp4"
+	if ip := net.ParseIP(m.Address); ip != nil && ip.To4() == nil {
+		network = "udp6"
 	}
-	resp, err := connectionless.Send(m.Address, m.Port)
+	conn, err := net.Dial(network, net.JoinHostPort(m.Address, strconv.Itoa(m.Port)))
 	if err != nil {
-		return nil, fmt.Errorf("sending connectionless packet: %s", err)
+		return nil, false, fmt.Errorf("sending connectionless packet: %s", err)
+	}
+	defer conn.Close()
+	req := message.ConnectionlessPacket{
+		Data: request,
+	}
+	if _, err := conn.Write(req.Marshal()); err != nil {
+		return nil, false, fmt.Errorf("sending connectionless packet: %s", err)
+	}
+
+	// some masters end the header with a newline rather than a space
+	prefix := strings.TrimSpace(header)
+	var out []MasterClient
+	seen := make(map[string]bool)
+	packets := 0
+	complete := false
+	d := make([]byte, 1500)
+	for {
+		wait := fetchTimeout
+		if complete {
+			wait = fetchSettle
+		}
+		conn.SetReadDeadline(time.Now().Add(wait))
+		read, err := conn.Read(d)
+		if err != nil {
+			break // done, or lost the rest
+		}
+		resp := message.NewBuffer(d[:read])
+		if resp.ReadLong() != -1 || string(resp.ReadData(len(prefix))) != prefix {
+			continue
+		}
+		if sep := resp.ReadByte(); sep != ' ' && sep != '\n' {
+			continue
+		}
+		packets++
+		servers := parse(&resp)
+		for _, sv := range servers {
+			addr := net.JoinHostPort(sv.IP.String(), strconv.Itoa(sv.Port))
+			if !seen[addr] {
+				seen[addr] = true
+				out = append(out, sv)
+			}
+		}
+		if len(servers) < perPacket {
+			complete = true // packets could arrive out of order, keep reading
+		}
 	}
-	resp.Seek(13) // swallow the header and "servers "
-	return ParseMasterResponse(&resp), nil
+	if packets == 0 {
+		return nil, false, fmt.Errorf("no server list from %s", net.JoinHostPort(m.Address, strconv.Itoa(m.Port)))
+	}
+	return out, complete, nil
 }
 
 // ParseMasterResponse will break up the bytes returned from the master server
 // into a slice of MasterClient structs. The only the `IP` and `Port` fields
 // will be populated.
 //
-// Currently only IPv4 responses are supported. Adding support for IPv6 will
-// mostly likely require a protocol change so clients will know weather to read
-// 4 or 16 bytes.
+// This is the classic IPv4 format, use ParseMasterResponse6() for "servers6"
+// responses.
 func ParseMasterResponse(data *message.Buffer) []MasterClient {
 	var out []MasterClient
 	for !data.AtEnd() {
@@ -48,3 +139,21 @@ func ParseMasterResponse(data *message.Buffer) []MasterClient {
 	}
 	return out
 }
+
+// ParseMasterResponse6 is ParseMasterResponse for the IPv6 format, 16 byte
+// addresses. IPv4-mapped addresses come back as IPv4.
+func ParseMasterResponse6(data *message.Buffer) []MasterClient {
+	var out []MasterClient
+	for data.Length-data.Index >= 18 {
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, data.ReadData(net.IPv6len))
+		if v4 := ip.To4(); v4 != nil {
+			ip = v4
+		}
+		out = append(out, MasterClient{
+			IP:   ip,
+			Port: (data.ReadByte() << 8) + data.ReadByte(),
+		})
+	}
+	return out
+}
@@ -2,10 +2,14 @@ package master
 
 import (
 	"encoding/hex"
+	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/packetflinger/libq2/message"
 )
 
@@ -84,3 +88,200 @@ func TestParseMasterResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalClientsPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		servers int
+		want    []int // entries per page
+	}{
+		{name: "none", servers: 0, want: []int{0}},
+		{name: "one page", servers: 10, want: []int{10}},
+		{name: "exactly full", servers: ClassicEntriesPerPacket, want: []int{ClassicEntriesPerPacket, 0}},
+		{name: "several", servers: 500, want: []int{ClassicEntriesPerPacket, ClassicEntriesPerPacket, 500 - 2*ClassicEntriesPerPacket}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := testServers(tc.servers)
+			var got []int
+			for _, page := range m.MarshalClients() {
+				if 4+len(ClassicResponseHeader)+len(page.Data) > MaxResponseSize {
+					t.Errorf("page of %d bytes won't fit in a response", len(page.Data))
+				}
+				got = append(got, len(page.Data)/6)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MarshalClients() pages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetchPublicServers(t *testing.T) {
+	for _, count := range []int{0, 3, ClassicEntriesPerPacket, 500} {
+		t.Run(strconv.Itoa(count), func(t *testing.T) {
+			m := testServers(count)
+			addr := serve(t, m).(*net.UDPAddr)
+			client := &MasterServer{Address: addr.IP.String(), Port: addr.Port}
+			start := time.Now()
+			got, err := client.FetchPublicServers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != count {
+				t.Errorf("FetchPublicServers() = %d servers, want %d", len(got), count)
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("FetchPublicServers() took %v, it waited for a timeout", elapsed)
+			}
+		})
+	}
+}
+
+// Other masters might page differently, or their packets might arrive out of
+// order or not at all.
+func TestFetchServersPaging(t *testing.T) {
+	full := ClassicEntriesPerPacket
+	tests := []struct {
+		name     string
+		pages    []int // entries in each packet, in the order they're sent
+		complete bool
+	}{
+		{name: "smaller pages", pages: []int{10, 10, 5}, complete: true},
+		{name: "last packet first", pages: []int{5, full, full}, complete: true},
+		{name: "last packet lost", pages: []int{full, full}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := fakeMaster(t, tc.pages)
+			client := &MasterServer{Address: addr.IP.String(), Port: addr.Port}
+			got, complete, err := client.fetchPublicServers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := 0
+			for _, n := range tc.pages {
+				want += n
+			}
+			if len(got) != want || complete != tc.complete {
+				t.Errorf("fetchPublicServers() = %d servers, complete %t, want %d, %t", len(got), complete, want, tc.complete)
+			}
+		})
+	}
+}
+
+// A master that answers getservers with packets of the given sizes
+func fakeMaster(t *testing.T, pages []int) *net.UDPAddr {
+	t.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, 1500)
+		_, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		server := 0
+		for _, n := range pages {
+			msg := message.NewBuffer(nil)
+			msg.WriteLong(-1)
+			msg.WriteData([]byte(ClassicResponseHeader))
+			for i := 0; i < n; i++ {
+				msg.WriteData([]byte{10, 0, byte(server >> 8), byte(server), 0x6d, 0x46})
+				server++
+			}
+			conn.WriteTo(msg.Data, addr)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+// A master with count listed servers
+func testServers(count int) *MasterServer {
+	m := NewMaster()
+	for i := 0; i < count; i++ {
+		addr := &net.UDPAddr{IP: net.IPv4(10, 0, byte(i>>8), byte(i)), Port: 27910}
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+	}
+	return m
+}
+
+func TestPingIPv6(t *testing.T) {
+	tests := []struct {
+		name string
+		addr string
+		ip   string
+		port int
+	}{
+		{name: "ipv4", addr: "192.0.2.1:27910", ip: "192.0.2.1", port: 27910},
+		{name: "ipv6", addr: "[2001:db8::1]:27911", ip: "2001:db8::1", port: 27911},
+		{name: "ipv4 mapped", addr: "[::ffff:192.0.2.2]:27912", ip: "192.0.2.2", port: 27912},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMaster()
+			var addr net.Addr
+			addr, err := net.ResolveUDPAddr("udp", tc.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cl := Ping(m, &addr)
+			if cl == nil {
+				t.Fatalf("Ping(%s) = nil", tc.addr)
+			}
+			if cl.IP.String() != tc.ip || cl.Port != tc.port {
+				t.Errorf("Ping(%s) = %s port %d, want %s port %d", tc.addr, cl.IP, cl.Port, tc.ip, tc.port)
+			}
+		})
+	}
+}
+
+func TestMarshalClients6(t *testing.T) {
+	m := NewMaster()
+	want := []string{"192.0.2.1:27910", "[2001:db8::1]:27910"}
+	for _, a := range want {
+		addr, _ := net.ResolveUDPAddr("udp", a)
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+	}
+	classic := message.NewBuffer(m.MarshalClients()[0].Data)
+	if got := ParseMasterResponse(&classic); len(got) != 1 || got[0].IP.String() != "192.0.2.1" {
+		t.Errorf("classic format = %v, want only the IPv4 server", got)
+	}
+	pages := m.MarshalClients6()
+	if len(pages) != 1 || len(pages[0].Data) != 2*18 {
+		t.Fatalf("MarshalClients6() = %d pages, want one with 2 entries", len(pages))
+	}
+	buf := message.NewBuffer(pages[0].Data)
+	var got []string
+	for _, cl := range ParseMasterResponse6(&buf) {
+		got = append(got, net.JoinHostPort(cl.IP.String(), strconv.Itoa(cl.Port)))
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("IPv6 format mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchPublicServers6(t *testing.T) {
+	m := testServers(IPv6EntriesPerPacket + 5)
+	for i := 0; i < 5; i++ {
+		addr := &net.UDPAddr{IP: net.ParseIP(fmt.Sprintf("2001:db8::%x", i+1)), Port: 27910}
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+	}
+	addr := serve(t, m).(*net.UDPAddr)
+	client := &MasterServer{Address: addr.IP.String(), Port: addr.Port}
+
+	v4, err := client.FetchPublicServers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := client.FetchPublicServers6()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v4) != IPv6EntriesPerPacket+5 || len(all) != IPv6EntriesPerPacket+10 {
+		t.Errorf("fetched %d IPv4 and %d total servers, want %d and %d", len(v4), len(all), IPv6EntriesPerPacket+5, IPv6EntriesPerPacket+10)
+	}
+}
@@ -0,0 +1,187 @@
+package master
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/packetflinger/libq2/message"
+)
+
+// The extended master protocol used by DarkPlaces/dpmaster and Quake 3 style
+// server browsers. Clients ask with:
+//
+//	getserversExt <gamename> <protocol> [empty] [full] [ipv4] [ipv6] [key=value]...
+//
+// and get one or more packets of:
+//
+//	getserversExtResponse\<4 byte ip><2 byte port>/<16 byte ip><2 byte port>...
+//
+// IPv4 entries start with '\' and IPv6 entries with '/', ports are big
+// endian. The last packet ends with "\EOT\0\0\0".
+const (
+	ExtRequest        = "getserversExt"
+	ExtResponseHeader = "getserversExtResponse"
+	ExtEndOfTransfer  = "\\EOT\x00\x00\x00"
+	MaxResponseSize   = 1400 // bytes, a safe UDP payload
+)
+
+// Which servers a getserversExt client wants listed
+type ServerFilter struct {
+	GameName string            // matches the gamedir, empty for any
+	Protocol int               // 0 for any
+	Empty    bool              // include servers without players
+	Full     bool              // include full servers
+	IPv4     bool              // include IPv4 servers
+	IPv6     bool              // include IPv6 servers
+	Info     map[string]string // serverinfo values to match
+}
+
+// Build a filter from the getserversExt arguments. Neither ipv4 nor ipv6
+// means both.
+func ParseServerFilter(args []string) ServerFilter {
+	f := ServerFilter{}
+	for _, arg := range args {
+		switch strings.ToLower(arg) {
+		case "empty":
+			f.Empty = true
+		case "full":
+			f.Full = true
+		case "ipv4":
+			f.IPv4 = true
+		case "ipv6":
+			f.IPv6 = true
+		default:
+			if k, v, ok := strings.Cut(arg, "="); ok {
+				if f.Info == nil {
+					f.Info = make(map[string]string)
+				}
+				f.Info[strings.ToLower(k)] = v
+			} else if p, err := strconv.Atoi(arg); err == nil {
+				f.Protocol = p
+			} else if f.GameName == "" {
+				f.GameName = arg
+			}
+		}
+	}
+	if !f.IPv4 && !f.IPv6 {
+		f.IPv4 = true
+		f.IPv6 = true
+	}
+	return f
+}
+
+// Does the client pass the filter? Servers that haven't told us their
+// protocol or other serverinfo match anything.
+func (f *ServerFilter) Match(cl *MasterClient) bool {
+	if cl.IP.To4() != nil {
+		if !f.IPv4 {
+			return false
+		}
+	} else if !f.IPv6 {
+		return false
+	}
+	if f.GameName != "" && !strings.EqualFold(f.GameName, cl.GameDir) {
+		return false
+	}
+	if p, err := strconv.Atoi(cl.Info["protocol"]); err == nil && f.Protocol != 0 && p != f.Protocol {
+		return false
+	}
+	if !f.Empty && len(cl.Players) == 0 {
+		return false
+	}
+	if !f.Full && cl.MaxPlayers > 0 && len(cl.Players) >= cl.MaxPlayers {
+		return false
+	}
+	for k, v := range f.Info {
+		if have, ok := cl.Info[k]; ok && !strings.EqualFold(have, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Someone asked for servers using the extended protocol
+func ClientListExt(m *MasterServer, recip *net.Addr, filter ServerFilter) {
+	m.updateStats(func(st *MasterServerStats) { st.GetServerHits++ })
+	m.Metrics.listRequest("ext")
+	for _, packet := range m.MarshalClientsExt(filter) {
+		(*m.Conn).WriteTo(packet.Data, *recip)
+	}
+}
+
+// Write all listed clients matching the filter as getserversExtResponse
+// packets, each no bigger than MaxResponseSize. There's always at least one
+// packet, the last ends with EOT.
+func (m *MasterServer) MarshalClientsExt(filter ServerFilter) []*message.Buffer {
+	var packets []*message.Buffer
+	packet := newExtPacket()
+	for _, cl := range m.Clients.Snapshot() {
+		if !cl.listed() || !filter.Match(&cl) {
+			continue
+		}
+		entry := cl.MarshalExt()
+		if len(packet.Data)+len(entry.Data) > MaxResponseSize-len(ExtEndOfTransfer) {
+			packets = append(packets, packet)
+			packet = newExtPacket()
+		}
+		packet.WriteData(entry.Data)
+	}
+	packet.WriteData([]byte(ExtEndOfTransfer))
+	return append(packets, packet)
+}
+
+func newExtPacket() *message.Buffer {
+	msg := message.NewEmptyBuffer()
+	msg.WriteLong(-1)
+	msg.WriteData([]byte(ExtResponseHeader))
+	return &msg
+}
+
+// Write this MasterClient's IP and port as a getserversExtResponse entry
+func (cl *MasterClient) MarshalExt() *message.Buffer {
+	msg := message.NewEmptyBuffer()
+	if v4 := cl.IP.To4(); v4 != nil {
+		msg.WriteByte('\\')
+		msg.WriteData([]byte(v4))
+	} else {
+		msg.WriteByte('/')
+		msg.WriteData([]byte(cl.IP.To16()))
+	}
+	msg.WriteData([]byte{byte((cl.Port >> 8) & 0xff), byte(cl.Port & 0xff)})
+	return &msg
+}
+
+// Read the entries from a getserversExtResponse packet, the header should
+// already be consumed. Only the `IP` and `Port` fields are populated. Also
+// returns whether this was the last packet.
+func ParseMasterResponseExt(data *message.Buffer) ([]MasterClient, bool) {
+	var out []MasterClient
+	rest := data.Data[data.Index:]
+	defer func() { data.Seek(len(data.Data) - len(rest)) }()
+	for len(rest) > 0 {
+		if bytes.HasPrefix(rest, []byte("\\EOT")) {
+			rest = rest[min(len(rest), len(ExtEndOfTransfer)):]
+			return out, true
+		}
+		size := 0
+		switch rest[0] {
+		case '\\':
+			size = net.IPv4len
+		case '/':
+			size = net.IPv6len
+		default:
+			return out, false // garbage
+		}
+		if len(rest) < 1+size+2 {
+			return out, false
+		}
+		ip := make(net.IP, size)
+		copy(ip, rest[1:1+size])
+		port := int(rest[1+size])<<8 | int(rest[2+size])
+		out = append(out, MasterClient{IP: ip, Port: port})
+		rest = rest[3+size:]
+	}
+	return out, false
+}
@@ -0,0 +1,209 @@
+package master
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/message"
+)
+
+func TestParseServerFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want ServerFilter
+	}{
+		{
+			name: "none",
+			want: ServerFilter{IPv4: true, IPv6: true},
+		},
+		{
+			name: "dpmaster",
+			args: []string{"opentdm", "34", "empty", "full"},
+			want: ServerFilter{GameName: "opentdm", Protocol: 34, Empty: true, Full: true, IPv4: true, IPv6: true},
+		},
+		{
+			name: "ipv6 only",
+			args: []string{"baseq2", "ipv6"},
+			want: ServerFilter{GameName: "baseq2", IPv6: true},
+		},
+		{
+			name: "info",
+			args: []string{"34", "Gametype=ctf", "ipv4"},
+			want: ServerFilter{Protocol: 34, IPv4: true, Info: map[string]string{"gametype": "ctf"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ParseServerFilter(tc.args)); diff != "" {
+				t.Errorf("ParseServerFilter(%q) mismatch (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestServerFilterMatch(t *testing.T) {
+	v4 := MasterClient{
+		IP:         net.ParseIP("192.0.2.1"),
+		GameDir:    "opentdm",
+		MaxPlayers: 2,
+		Players:    []MasterClientPlayer{{Name: "claire"}},
+		Info:       map[string]string{"protocol": "34", "gametype": "tdm"},
+	}
+	full := v4
+	full.Players = []MasterClientPlayer{{Name: "claire"}, {Name: "bob"}}
+	empty := v4
+	empty.Players = nil
+	v6 := v4
+	v6.IP = net.ParseIP("2001:db8::1")
+	unknown := MasterClient{IP: net.ParseIP("192.0.2.2"), Players: []MasterClientPlayer{{Name: "claire"}}}
+
+	tests := []struct {
+		name   string
+		args   []string
+		cl     MasterClient
+		wanted bool
+	}{
+		{name: "anything", cl: v4, wanted: true},
+		{name: "gamename", args: []string{"OpenTDM"}, cl: v4, wanted: true},
+		{name: "wrong gamename", args: []string{"baseq2"}, cl: v4, wanted: false},
+		{name: "protocol", args: []string{"34"}, cl: v4, wanted: true},
+		{name: "wrong protocol", args: []string{"35"}, cl: v4, wanted: false},
+		{name: "unknown protocol", args: []string{"35"}, cl: unknown, wanted: true},
+		{name: "empty", cl: empty, wanted: false},
+		{name: "empty wanted", args: []string{"empty"}, cl: empty, wanted: true},
+		{name: "full", cl: full, wanted: false},
+		{name: "full wanted", args: []string{"full"}, cl: full, wanted: true},
+		{name: "ipv6", args: []string{"ipv6"}, cl: v6, wanted: true},
+		{name: "ipv4 only", args: []string{"ipv4"}, cl: v6, wanted: false},
+		{name: "ipv6 only", args: []string{"ipv6"}, cl: v4, wanted: false},
+		{name: "info", args: []string{"gametype=TDM"}, cl: v4, wanted: true},
+		{name: "wrong info", args: []string{"gametype=ctf"}, cl: v4, wanted: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := ParseServerFilter(tc.args)
+			if got := f.Match(&tc.cl); got != tc.wanted {
+				t.Errorf("Match() = %t, want %t", got, tc.wanted)
+			}
+		})
+	}
+}
+
+func TestMarshalClientsExt(t *testing.T) {
+	m := NewMaster()
+	var want []string
+	for i := 0; i < 300; i++ {
+		addr := &net.UDPAddr{IP: net.IPv4(192, 0, 2, byte(i)), Port: 27910 + i}
+		if i%10 == 0 {
+			addr.IP = net.ParseIP(fmt.Sprintf("2001:db8::%x", i))
+		}
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+		want = append(want, addr.String())
+	}
+
+	packets := m.MarshalClientsExt(ParseServerFilter([]string{"empty"}))
+	if len(packets) < 2 {
+		t.Fatalf("%d packets, want the list split", len(packets))
+	}
+	got := map[string]bool{}
+	for i, p := range packets {
+		if len(p.Data) > MaxResponseSize {
+			t.Errorf("packet %d is %d bytes, max is %d", i, len(p.Data), MaxResponseSize)
+		}
+		buf := message.NewBuffer(p.Data)
+		if buf.ReadLong() != -1 || string(buf.ReadData(len(ExtResponseHeader))) != ExtResponseHeader {
+			t.Fatalf("packet %d has a bad header", i)
+		}
+		servers, eot := ParseMasterResponseExt(&buf)
+		if eot != (i == len(packets)-1) {
+			t.Errorf("packet %d EOT = %t", i, eot)
+		}
+		for _, s := range servers {
+			got[(&net.UDPAddr{IP: s.IP, Port: s.Port}).String()] = true
+		}
+	}
+	for _, addr := range want {
+		if !got[addr] {
+			t.Errorf("%s missing from the response", addr)
+		}
+	}
+	if len(got) != len(m.Clients.Snapshot()) {
+		t.Errorf("%d servers in the response, want %d", len(got), m.Clients.Len())
+	}
+}
+
+func TestGetServersExt(t *testing.T) {
+	m := NewMaster()
+	for i, ip := range []string{"192.0.2.1", "2001:db8::1", "192.0.2.2"} {
+		addr := &net.UDPAddr{IP: net.ParseIP(ip), Port: 27910}
+		cl := MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true, GameDir: "baseq2"}
+		if i == 2 {
+			cl.GameDir = "opentdm"
+		}
+		m.Clients.Add(cl)
+	}
+	masterAddr := serve(t, m)
+
+	conn, err := net.Dial("udp4", masterAddr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	req := message.NewEmptyBuffer()
+	req.WriteLong(-1)
+	req.WriteData([]byte("getserversExt baseq2 34 empty\x00"))
+	if _, err := conn.Write(req.Data); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	d := make([]byte, 1500)
+	n, err := conn.Read(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := message.NewBuffer(d[:n])
+	resp.Seek(4 + len(ExtResponseHeader))
+	servers, eot := ParseMasterResponseExt(&resp)
+	if !eot {
+		t.Error("response didn't end with EOT")
+	}
+	var got []string
+	for _, s := range servers {
+		got = append(got, s.IP.String())
+	}
+	if diff := cmp.Diff([]string{"192.0.2.1", "2001:db8::1"}, got); diff != "" {
+		t.Errorf("getserversExt mismatch (-want +got):\n%s", diff)
+	}
+	if !bytes.HasPrefix(d, []byte("\xff\xff\xff\xff"+ExtResponseHeader)) {
+		t.Errorf("response header = %q", d[:4+len(ExtResponseHeader)])
+	}
+}
+
+func TestParseMasterResponseExtTruncated(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+		eot  bool
+	}{
+		{name: "empty", data: "", want: 0},
+		{name: "eot only", data: ExtEndOfTransfer, want: 0, eot: true},
+		{name: "short eot", data: "\\EOT", want: 0, eot: true},
+		{name: "short entry", data: "\\\xc0\x00\x02\x01\x6c\x86\\\xc0\x00", want: 1},
+		{name: "garbage", data: "x\xc0\x00\x02\x01\x6c\x86", want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := message.NewBuffer([]byte(tc.data))
+			got, eot := ParseMasterResponseExt(&buf)
+			if len(got) != tc.want || eot != tc.eot {
+				t.Errorf("ParseMasterResponseExt() = %d servers, eot %t, want %d, %t", len(got), eot, tc.want, tc.eot)
+			}
+		})
+	}
+}
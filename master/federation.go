@@ -0,0 +1,173 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Pull the server lists from all the upstream masters and merge them into
+// ours. This should be run concurrently.
+func (m *MasterServer) UpstreamSyncer(ctx context.Context) {
+	interval := m.SyncInterval
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	m.SyncUpstreams()
+	for {
+		select {
+		case <-ctx.Done():
+			if m.Verbose {
+				log.Println("ending UpstreamSyncer thread")
+			}
+			return
+		case <-ticker.C:
+			m.SyncUpstreams()
+		}
+	}
+}
+
+// Sync with each upstream master once
+func (m *MasterServer) SyncUpstreams() {
+	for _, upstream := range m.Upstreams {
+		count, err := m.SyncFrom(upstream)
+		if err != nil {
+			log.Println("sync failed:", err)
+			continue
+		}
+		if m.Verbose {
+			log.Printf("imported %d new servers from %s\n", count, upstream)
+		}
+	}
+}
+
+// Fetch the server list from another master (host:port, the port defaults to
+// 27900) and merge it into ours. Servers we don't know yet are added tagged
+// with the upstream as their source. Servers heartbeating to us directly are
+// left alone, and servers previously imported from this upstream that are no
+// longer on its list are removed. Nothing is removed when only part of the
+// list arrived, the missing servers could be in the packets that didn't.
+//
+// When revalidating, new servers are pinged and not listed until they ack.
+// Verify implies revalidating, imports would skip verification otherwise.
+// Imported servers are only listed to players, not other masters (see
+// MarshalClients()).
+//
+// Returns how many servers were added.
+func (m *MasterServer) SyncFrom(upstream string) (int, error) {
+	host, port, err := splitUpstream(upstream)
+	if err != nil {
+		return 0, err
+	}
+	up := &MasterServer{Address: host, Port: port}
+	servers, complete, err := up.fetchPublicServers()
+	if err != nil {
+		return 0, fmt.Errorf("fetching servers from %s: %v", upstream, err)
+	}
+
+	now := time.Now()
+	added := 0
+	listed := make(map[string]bool)
+	for _, srv := range servers {
+		var addr net.Addr = &net.UDPAddr{IP: srv.IP, Port: srv.Port}
+		key := addr.String()
+		listed[key] = true
+		if cl, ok := m.Clients.Get(key); ok {
+			if cl.Source != upstream {
+				continue // ours, or someone else's
+			}
+			m.Clients.Update(key, func(c *MasterClient) { c.Synced = now })
+			if cl.Unverified {
+				m.revalidate(&addr) // still hasn't answered, try again
+			}
+			continue
+		}
+		cl := MasterClient{
+			Address:      addr,
+			IP:           srv.IP,
+			Port:         srv.Port,
+			FirstContact: now,
+			Active:       true,
+			Source:       upstream,
+			Synced:       now,
+			Unverified:   m.Revalidate || m.Verify,
+		}
+		if m.GeoIPs != nil {
+			cl.Country = m.GeoIPs.Lookup(srv.IP.String())
+		}
+		if _, ok := m.Clients.Add(cl); !ok {
+			continue
+		}
+		added++
+		if cl.Unverified {
+			m.revalidate(&addr)
+		}
+	}
+
+	if !complete {
+		if m.Verbose {
+			log.Printf("partial server list from %s, not removing any\n", upstream)
+		}
+		return added, nil
+	}
+	for _, cl := range m.Clients.Snapshot() {
+		if cl.Source == upstream && !listed[cl.addressString()] {
+			if m.Verbose {
+				log.Printf("%s dropped %s, removing\n", upstream, cl.addressString())
+			}
+			m.Clients.Remove(cl.addressString())
+		}
+	}
+	return added, nil
+}
+
+// How long since we've heard of this server. For imported servers that's
+// when it was last on the upstream's list, otherwise the last time it
+// contacted us.
+func (cl *MasterClient) Age() time.Duration {
+	seen := cl.LastContact
+	if cl.Source != "" {
+		seen = cl.Synced
+	}
+	if seen.IsZero() {
+		seen = cl.FirstContact
+	}
+	if seen.IsZero() {
+		return 0
+	}
+	return time.Since(seen)
+}
+
+// Ping an imported server, its ack will mark it verified. Needs our socket
+// so the ack comes back to us. When verifying, acks aren't trusted and it's
+// probed instead.
+func (m *MasterServer) revalidate(addr *net.Addr) {
+	if m.Verify {
+		go m.verify(*addr)
+		return
+	}
+	if m.Conn == nil {
+		return
+	}
+	Send("ping", m, addr)
+}
+
+// Break an upstream master's address into host and port
+func splitUpstream(upstream string) (string, int, error) {
+	host, p, err := net.SplitHostPort(upstream)
+	if err != nil {
+		// no port, use the default
+		return upstream, DefaultListenPort, nil
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid upstream port %q: %v", upstream, err)
+	}
+	return host, port, nil
+}
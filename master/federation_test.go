@@ -0,0 +1,239 @@
+package master
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/state"
+)
+
+// Listen on a random local port and handle messages for the master like Run()
+// does, until the test ends.
+func serve(t *testing.T, m *MasterServer) net.Addr {
+	t.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	m.Conn = &conn
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			count, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			data := make([]byte, count)
+			copy(data, buf[:count])
+			processMessage(m, &addr, data)
+		}
+	}()
+	return conn.LocalAddr()
+}
+
+// A game server that acks pings and answers status queries
+func fakeGameServer(t *testing.T) *net.UDPAddr {
+	t.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			count, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req := strings.Trim(string(buf[4:count]), "\x00\n")
+			resp := message.NewEmptyBuffer()
+			resp.WriteLong(-1)
+			switch req {
+			case "ping":
+				resp.WriteData([]byte("ack"))
+			case "status":
+				resp.WriteData([]byte("print\n\\hostname\\Imported\n"))
+			default:
+				continue
+			}
+			conn.WriteTo(resp.Data, addr)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+func TestSyncFrom(t *testing.T) {
+	live := fakeGameServer(t)
+	dead := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9} // never answers
+	direct := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+
+	upstream := NewMaster()
+	for _, addr := range []*net.UDPAddr{live, dead, direct} {
+		upstream.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+	}
+	upstreamAddr := serve(t, upstream).String()
+
+	m := NewMaster()
+	m.Revalidate = true
+	m.Clients.Add(MasterClient{Address: direct, IP: direct.IP, Port: direct.Port, Active: true, LastContact: time.Now()})
+	serve(t, m)
+
+	added, err := m.SyncFrom(upstreamAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 2 {
+		t.Errorf("SyncFrom() added %d servers, want 2", added)
+	}
+	if cl, _ := m.FindClient(direct); cl.Source != "" {
+		t.Errorf("directly registered server was tagged with source %q", cl.Source)
+	}
+	if cl, _ := m.FindClient(dead); cl.Source != upstreamAddr || !cl.Unverified {
+		t.Errorf("imported server = %+v, want unverified from %s", cl, upstreamAddr)
+	}
+
+	// the live server acks our ping and gets listed
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		cl, _ := m.FindClient(live)
+		if !cl.Unverified && cl.Info["hostname"] == "Imported" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("live server never verified: %+v", cl)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// players see imports, other masters don't
+	lists := []struct {
+		name  string
+		pages []*message.Buffer
+		want  []string // sorted by address
+	}{
+		{name: "MarshalAllClients", pages: m.MarshalAllClients(), want: []string{live.String(), direct.String()}},
+		{name: "MarshalClients", pages: m.MarshalClients(), want: []string{direct.String()}},
+	}
+	for _, l := range lists {
+		list := message.NewBuffer(l.pages[0].Data)
+		var got []string
+		for _, cl := range ParseMasterResponse(&list) {
+			got = append(got, net.JoinHostPort(cl.IP.String(), strconv.Itoa(cl.Port)))
+		}
+		if strings.Join(got, " ") != strings.Join(l.want, " ") {
+			t.Errorf("%s listed %v, want %v", l.name, got, l.want)
+		}
+	}
+
+	// dropped upstream, dropped here
+	upstream.Clients.Remove(dead.String())
+	if _, err := m.SyncFrom(upstreamAddr); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.FindClient(dead); ok {
+		t.Error("server dropped by the upstream is still listed")
+	}
+	if m.Clients.Len() != 2 {
+		t.Errorf("%d servers after second sync, want 2", m.Clients.Len())
+	}
+}
+
+// Imports missing from the upstream's list are only removed when the whole
+// list arrived
+func TestSyncFromPartial(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   []int
+		removed bool
+	}{
+		{name: "complete", pages: []int{3}, removed: true},
+		{name: "last packet lost", pages: []int{ClassicEntriesPerPacket}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream := fakeMaster(t, tc.pages).String()
+			old := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+			m := NewMaster()
+			m.Clients.Add(MasterClient{Address: old, IP: old.IP, Port: old.Port, Active: true, Source: upstream})
+			if _, err := m.SyncFrom(upstream); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := m.FindClient(old); ok == tc.removed {
+				t.Errorf("server missing from the list still imported = %t, want %t", ok, !tc.removed)
+			}
+		})
+	}
+}
+
+func TestSyncFromVerify(t *testing.T) {
+	srv := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9}
+	upstream := NewMaster()
+	upstream.Clients.Add(MasterClient{Address: srv, IP: srv.IP, Port: srv.Port, Active: true})
+	upstreamAddr := serve(t, upstream).String()
+
+	m := NewMaster()
+	m.Verify = true // without Revalidate
+	m.VerifyFunc = func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+		return state.ServerInfo{}, fmt.Errorf("no answer")
+	}
+	serve(t, m)
+	if _, err := m.SyncFrom(upstreamAddr); err != nil {
+		t.Fatal(err)
+	}
+	if cl, _ := m.FindClient(srv); !cl.Unverified || cl.listed() {
+		t.Errorf("imported server = %+v, want unverified", cl)
+	}
+}
+
+func TestSplitUpstream(t *testing.T) {
+	tests := []struct {
+		upstream string
+		host     string
+		port     int
+		wantErr  bool
+	}{
+		{upstream: "master.example.com", host: "master.example.com", port: 27900},
+		{upstream: "master.example.com:27901", host: "master.example.com", port: 27901},
+		{upstream: "192.0.2.1:27900", host: "192.0.2.1", port: 27900},
+		{upstream: "[2001:db8::1]:27900", host: "2001:db8::1", port: 27900},
+		{upstream: "master.example.com:q2", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.upstream, func(t *testing.T) {
+			host, port, err := splitUpstream(tc.upstream)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("splitUpstream() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if host != tc.host || port != tc.port {
+				t.Errorf("splitUpstream() = %q, %d, want %q, %d", host, port, tc.host, tc.port)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		cl   MasterClient
+		want time.Duration
+	}{
+		{name: "direct", cl: MasterClient{LastContact: now.Add(-time.Minute)}, want: time.Minute},
+		{name: "imported", cl: MasterClient{Source: "master", LastContact: now, Synced: now.Add(-time.Hour)}, want: time.Hour},
+		{name: "first contact", cl: MasterClient{FirstContact: now.Add(-time.Second)}, want: time.Second},
+		{name: "never", cl: MasterClient{}, want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cl.Age().Round(time.Second); got != tc.want {
+				t.Errorf("Age() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
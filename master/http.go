@@ -0,0 +1,227 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Content types the HTTP API can respond with. JSON is the default, clients
+// wanting protobuf need to ask for it in the Accept header or with
+// "?format=proto".
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Serve the JSON HTTP API on m.HTTPAddress until the context is done.
+//
+// Endpoints:
+//
+//	/GetServers  - all known servers. Can be filtered with gamedir, map,
+//	               country, nonempty=1 and passworded=0|1 query parameters
+//	/ServerInfo  - one server (?address=ip:port) including its players and
+//	               serverinfo
+//	/HealthCheck - uptime and MasterServerStats
+//	/metrics     - counters and gauges in the Prometheus text format, see
+//	               WriteMetrics()
+func (m *MasterServer) RunHTTP(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              m.HTTPAddress,
+		Handler:           m.HTTPHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+	log.Println("Listening for HTTP API requests on", m.HTTPAddress)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// The HTTP API's routes, for using the API with some other http.Server.
+func (m *MasterServer) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/GetServers", m.handleGetServers)
+	mux.HandleFunc("/ServerInfo", m.handleServerInfo)
+	mux.HandleFunc("/HealthCheck", m.handleHealthCheck)
+	mux.HandleFunc("/metrics", m.handleMetrics)
+	return mux
+}
+
+func (m *MasterServer) handleGetServers(w http.ResponseWriter, r *http.Request) {
+	m.updateStats(func(st *MasterServerStats) { st.GetServerHits++ })
+	m.Metrics.listRequest("http")
+	q := r.URL.Query()
+	list := &pb.MasterServerList{}
+	for _, cl := range m.Clients.Snapshot() {
+		if !cl.listed() {
+			continue
+		}
+		if !matchesFilter(&cl, q.Get("gamedir"), q.Get("map"), q.Get("country"), q.Get("nonempty"), q.Get("passworded")) {
+			continue
+		}
+		list.Servers = append(list.Servers, cl.toProto(false))
+	}
+	writeResponse(w, r, list)
+}
+
+func (m *MasterServer) handleServerInfo(w http.ResponseWriter, r *http.Request) {
+	addr := r.URL.Query().Get("address")
+	if addr == "" {
+		http.Error(w, "missing address", http.StatusBadRequest)
+		return
+	}
+	cl, ok := m.Clients.Get(addr)
+	if !ok || !cl.listed() {
+		http.Error(w, "unknown server", http.StatusNotFound)
+		return
+	}
+	writeResponse(w, r, cl.toProto(true))
+}
+
+// Counts are for listed servers only, like the lists themselves
+func (m *MasterServer) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
+	servers, players := 0, 0
+	for _, cl := range m.Clients.Snapshot() {
+		if !cl.listed() {
+			continue
+		}
+		servers++
+		players += len(cl.Players)
+	}
+	stats := m.GetStats()
+	writeResponse(w, r, &pb.MasterServerStatus{
+		Status:        "ok",
+		StartTime:     stats.StartTime.Unix(),
+		Uptime:        int64(time.Since(stats.StartTime).Seconds()),
+		GetServerHits: int32(stats.GetServerHits),
+		ServerCount:   int32(servers),
+		PlayerCount:   int32(players),
+	})
+}
+
+func (m *MasterServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentTypeMetrics)
+	if err := m.WriteMetrics(w); err != nil {
+		log.Println("writing metrics:", err)
+	}
+}
+
+// Does the client match all the given filters? Empty filters match anything.
+func matchesFilter(cl *MasterClient, gamedir, mapname, country, nonempty, passworded string) bool {
+	if gamedir != "" && !strings.EqualFold(cl.GameDir, gamedir) {
+		return false
+	}
+	if mapname != "" && !strings.EqualFold(cl.CurrentMap, mapname) {
+		return false
+	}
+	if country != "" && !strings.EqualFold(cl.Country, country) {
+		return false
+	}
+	if isTrue(nonempty) && len(cl.Players) == 0 {
+		return false
+	}
+	if passworded != "" && cl.Passworded != isTrue(passworded) {
+		return false
+	}
+	return true
+}
+
+func isTrue(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
+
+// Write the response in whatever format the client asked for
+func writeResponse(w http.ResponseWriter, r *http.Request, msg proto.Message) {
+	var data []byte
+	var err error
+	contentType := ContentTypeJSON
+	if r.URL.Query().Get("format") == "proto" || strings.Contains(r.Header.Get("Accept"), "protobuf") {
+		contentType = ContentTypeProtobuf
+		data, err = proto.Marshal(msg)
+	} else {
+		data, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(data)
+}
+
+// The client's address as ip:port
+func (cl *MasterClient) addressString() string {
+	if cl.Address != nil {
+		return cl.Address.String()
+	}
+	return net.JoinHostPort(cl.IP.String(), strconv.Itoa(cl.Port))
+}
+
+// The API's view of the client. Players and serverinfo are only included
+// with details.
+func (cl *MasterClient) toProto(details bool) *pb.MasterServerEntry {
+	out := &pb.MasterServerEntry{
+		Address:     cl.addressString(),
+		Ip:          cl.IP.String(),
+		Port:        int32(cl.Port),
+		Country:     cl.Country,
+		Hostname:    cl.Hostname,
+		Map:         cl.CurrentMap,
+		GameDir:     cl.GameDir,
+		MaxPlayers:  int32(cl.MaxPlayers),
+		PlayerCount: int32(len(cl.Players)),
+		Passworded:  cl.Passworded,
+		Software:    cl.Software,
+		Active:      cl.Active,
+		Heartbeats:  int32(cl.Heartbeats),
+		Source:      cl.Source,
+		Age:         int64(cl.Age().Seconds()),
+		Latency:     int32(cl.Latency.Milliseconds()),
+	}
+	if !cl.FirstContact.IsZero() {
+		out.FirstContact = cl.FirstContact.Unix()
+	}
+	if !cl.LastContact.IsZero() {
+		out.LastContact = cl.LastContact.Unix()
+	}
+	if !details {
+		return out
+	}
+	for _, p := range cl.Players {
+		player := &pb.MasterServerPlayer{
+			Name:    p.Name,
+			Score:   int32(p.Score),
+			Ping:    int32(p.Ping),
+			Country: p.Country,
+		}
+		if !p.ConnectTime.IsZero() {
+			player.ConnectTime = p.ConnectTime.Unix()
+		}
+		out.Players = append(out.Players, player)
+	}
+	if len(cl.Info) > 0 {
+		out.Info = make(map[string]string)
+		for k, v := range cl.Info {
+			out.Info[k] = v
+		}
+	}
+	return out
+}
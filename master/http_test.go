@@ -0,0 +1,205 @@
+package master
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+func testMaster() *MasterServer {
+	m := NewMaster()
+	m.Stats.StartTime = time.Now().Add(-time.Minute)
+	clients := []MasterClient{
+		{
+			Active:     true,
+			Address:    &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910},
+			IP:         net.ParseIP("192.0.2.1"),
+			Port:       27910,
+			Country:    "us",
+			Hostname:   "TDM",
+			CurrentMap: "q2dm1",
+			GameDir:    "opentdm",
+			MaxPlayers: 8,
+			Players: []MasterClientPlayer{
+				{Name: "claire", Score: 10, Ping: 30},
+			},
+			Info: map[string]string{"timelimit": "10"},
+		},
+		{
+			Active:     true,
+			Address:    &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 27911},
+			IP:         net.ParseIP("192.0.2.2"),
+			Port:       27911,
+			Country:    "au",
+			Hostname:   "Private",
+			CurrentMap: "q2dm8",
+			GameDir:    "baseq2",
+			MaxPlayers: 16,
+			Passworded: true,
+		},
+	}
+	for _, cl := range clients {
+		m.Clients.Add(cl)
+	}
+	return m
+}
+
+func TestGetServers(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string // hostnames
+	}{
+		{name: "all", query: "", want: []string{"TDM", "Private"}},
+		{name: "gamedir", query: "?gamedir=OpenTDM", want: []string{"TDM"}},
+		{name: "map", query: "?map=q2dm8", want: []string{"Private"}},
+		{name: "country", query: "?country=AU", want: []string{"Private"}},
+		{name: "nonempty", query: "?nonempty=1", want: []string{"TDM"}},
+		{name: "no password", query: "?passworded=0", want: []string{"TDM"}},
+		{name: "passworded", query: "?passworded=1", want: []string{"Private"}},
+		{name: "no matches", query: "?gamedir=action", want: nil},
+	}
+	m := testMaster()
+	srv := httptest.NewServer(m.HTTPHandler())
+	defer srv.Close()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := &pb.MasterServerList{}
+			get(t, srv.URL+"/GetServers"+tc.query, "", list)
+			var got []string
+			for _, s := range list.GetServers() {
+				got = append(got, s.GetHostname())
+				if len(s.GetPlayers()) > 0 || len(s.GetInfo()) > 0 {
+					t.Errorf("%s has details in the server list", s.GetAddress())
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetServers%s mismatch (-want +got):\n%s", tc.query, diff)
+			}
+		})
+	}
+	if hits := m.GetStats().GetServerHits; hits != len(tests) {
+		t.Errorf("GetServerHits = %d, want %d", hits, len(tests))
+	}
+}
+
+// Servers that are known but shouldn't be shown to anyone
+func addUnlisted(m *MasterServer) {
+	m.Clients.Add(MasterClient{
+		Address:  &net.UDPAddr{IP: net.ParseIP("192.0.2.3"), Port: 27910},
+		IP:       net.ParseIP("192.0.2.3"),
+		Port:     27910,
+		Hostname: "Gone",
+		Players:  []MasterClientPlayer{{Name: "shloo"}},
+	})
+	m.Clients.Add(MasterClient{
+		Active:     true,
+		Unverified: true,
+		Address:    &net.UDPAddr{IP: net.ParseIP("192.0.2.4"), Port: 27910},
+		IP:         net.ParseIP("192.0.2.4"),
+		Port:       27910,
+		Hostname:   "Spoofed",
+		Players:    []MasterClientPlayer{{Name: "bob"}},
+	})
+}
+
+func TestServerInfo(t *testing.T) {
+	m := testMaster()
+	addUnlisted(m)
+	srv := httptest.NewServer(m.HTTPHandler())
+	defer srv.Close()
+
+	want := &pb.MasterServerEntry{
+		Address:     "192.0.2.1:27910",
+		Ip:          "192.0.2.1",
+		Port:        27910,
+		Country:     "us",
+		Hostname:    "TDM",
+		Map:         "q2dm1",
+		GameDir:     "opentdm",
+		MaxPlayers:  8,
+		PlayerCount: 1,
+		Active:      true,
+		Players:     []*pb.MasterServerPlayer{{Name: "claire", Score: 10, Ping: 30}},
+		Info:        map[string]string{"timelimit": "10"},
+	}
+	for _, accept := range []string{"", ContentTypeProtobuf} {
+		got := &pb.MasterServerEntry{}
+		get(t, srv.URL+"/ServerInfo?address=192.0.2.1:27910", accept, got)
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("ServerInfo (accept %q) mismatch (-want +got):\n%s", accept, diff)
+		}
+	}
+
+	for _, addr := range []string{"192.0.2.9:27910", "192.0.2.3:27910", "192.0.2.4:27910"} {
+		resp, err := http.Get(srv.URL + "/ServerInfo?address=" + addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s status = %d, want %d", addr, resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	m := testMaster()
+	addUnlisted(m)
+	srv := httptest.NewServer(m.HTTPHandler())
+	defer srv.Close()
+
+	got := &pb.MasterServerStatus{}
+	get(t, srv.URL+"/HealthCheck?format=proto", "", got)
+	if got.GetStatus() != "ok" || got.GetServerCount() != 2 || got.GetPlayerCount() != 1 {
+		t.Errorf("HealthCheck = %v", got)
+	}
+	if got.GetUptime() < 60 {
+		t.Errorf("HealthCheck uptime = %d, want at least 60", got.GetUptime())
+	}
+}
+
+// Fetch a url and decode the response based on the content type
+func get(t *testing.T, url, accept string, out proto.Message) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: %s", url, resp.Status)
+	}
+	switch resp.Header.Get("Content-Type") {
+	case ContentTypeProtobuf:
+		err = proto.Unmarshal(body, out)
+	case ContentTypeJSON:
+		err = protojson.Unmarshal(body, out)
+	default:
+		t.Fatalf("GET %s: unexpected content type %q", url, resp.Header.Get("Content-Type"))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
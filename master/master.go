@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/packetflinger/libq2/message"
@@ -32,30 +34,58 @@ import (
 const (
 	DefaultListenPort    = 27900
 	DefaultListenAddr    = "[::]" // Any IPv4/IPv6
-	DefaultThinkInterval = 360    // secs
-	DefaultPingInterval  = 360    // secs
+	DefaultThinkInterval = 60     // secs between probing every server
+	DefaultProbeTimeout  = 1000   // msecs
+	DefaultProbeWorkers  = 32     // servers probed at once
+	DefaultInactiveAfter = 2      // missed probes
+	DefaultEvictAfter    = 5      // missed probes
+	DefaultSyncInterval  = 300    // secs
 )
 
 // the all-knowning master server
 type MasterServer struct {
-	Address       string            // IP or DNS name
-	Clients       []MasterClient    // our known q2 server
-	Conn          *net.PacketConn   // the socket
-	GeoIPs        *state.GeoIPList  // the ip-country list
-	PingInterval  int               // seconds
-	Port          int               // default 27900
-	Refresh       bool              // fetch player info
-	Stats         MasterServerStats // stats for this master
-	ThinkInterval int               // seconds
-	Verbose       bool              // be extra mouthy
-
-	AckFunc        func(m *MasterServer, from *net.Addr)
-	ClientListFunc func(m *MasterServer, recip *net.Addr)
-	HeartbeatFunc  func(m *MasterServer, from *net.Addr, info map[string]string)
-	PingFunc       func(m *MasterServer, from *net.Addr) *MasterClient
-	ProcessFunc    func(m *MasterServer)
-	ShutdownFunc   func(m *MasterServer, from *net.Addr)
-	ThinkFunc      func(ctx context.Context, m *MasterServer)
+	Address            string            // IP or DNS name
+	Allow              []*net.IPNet      // only these networks can register servers, empty for anyone
+	Block              []*net.IPNet      // datagrams from these networks are dropped
+	CheckpointInterval int               // seconds between saves to Storage
+	Clients            ClientStore       // our known q2 servers
+	Conn               *net.PacketConn   // the socket
+	EvictAfter         int               // remove servers after this many missed probes
+	GeoIPs             *state.GeoIPList  // the ip-country list
+	HTTPAddress        string            // HTTP API listener (":8080"), empty for none
+	InactiveAfter      int               // stop listing servers after this many missed probes
+	MaxServersPerIP    int               // 0 for no limit
+	Metrics            Metrics           // counters for /metrics, see WriteMetrics()
+	Port               int               // default 27900
+	ProbeTimeout       int               // milliseconds to wait for a server to answer
+	ProbeWorkers       int               // max servers probed at once
+	RateLimit          int               // max datagrams per minute from one IP, 0 for no limit
+	Revalidate         bool              // ping imported servers before listing them, Verify implies this
+	Stats              MasterServerStats // stats for this master, use GetStats()
+	Storage            Storage           // where clients are kept between restarts, nil for nowhere
+	SyncInterval       int               // seconds between pulling lists from Upstreams
+	ThinkInterval      int               // seconds between probing every server
+	Upstreams          []string          // other masters (host:port) to import servers from
+	Verbose            bool              // be extra mouthy
+	Verify             bool              // status probe new servers before listing them
+
+	AckFunc            func(m *MasterServer, from *net.Addr)
+	ClientListFunc     func(m *MasterServer, recip *net.Addr)
+	ClientListExtFunc  func(m *MasterServer, recip *net.Addr, filter ServerFilter)
+	ClientListIPv6Func func(m *MasterServer, recip *net.Addr)
+	HeartbeatFunc      func(m *MasterServer, from *net.Addr, info map[string]string)
+	PingFunc           func(m *MasterServer, from *net.Addr) *MasterClient
+	QueryListFunc      func(m *MasterServer, recip *net.Addr)
+	ProbeFunc          func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error)
+	ProcessFunc        func(m *MasterServer)
+	ShutdownFunc       func(m *MasterServer, from *net.Addr)
+	ThinkFunc          func(ctx context.Context, m *MasterServer)
+	VerifyFunc         func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error)
+
+	limiter    rateLimiter     // for RateLimit
+	statsLock  sync.Mutex      // for Stats, messages are handled concurrently
+	verifyLock sync.Mutex      // for verifying
+	verifying  map[string]bool // addresses being probed
 }
 
 type MasterServerStats struct {
@@ -78,13 +108,16 @@ type MasterClient struct {
 	Info         map[string]string
 	IP           net.IP
 	LastContact  time.Time
+	Latency      time.Duration // round trip of the master's last probe
 	MaxPlayers   int
-	Missedbeats  int
+	Missedbeats  int // probes in a row it didn't answer
 	Passworded   bool
-	PendingAcks  int
 	Players      []MasterClientPlayer
 	Port         int
 	Software     string
+	Source       string    // master it was imported from, empty if it heartbeats to us
+	Synced       time.Time // last seen on Source's list
+	Unverified   bool      // hasn't answered our ping or status probe yet, not listed
 }
 
 type MasterClientPlayer struct {
@@ -103,22 +136,34 @@ func NewMaster() *MasterServer {
 		Stats: MasterServerStats{
 			StartTime: time.Now(),
 		},
-		ThinkInterval:  DefaultThinkInterval,
-		ThinkFunc:      Think,
-		ClientListFunc: ClientList,
-		PingFunc:       Ping,
-		AckFunc:        Ack,
-		HeartbeatFunc:  Heartbeat,
-		ShutdownFunc:   Shutdown,
-		PingInterval:   DefaultPingInterval,
-		Refresh:        false,
-		Verbose:        false,
+		ThinkInterval:      DefaultThinkInterval,
+		ThinkFunc:          Think,
+		ClientListFunc:     ClientList,
+		ClientListExtFunc:  ClientListExt,
+		ClientListIPv6Func: ClientListIPv6,
+		PingFunc:           Ping,
+		QueryListFunc:      QueryList,
+		AckFunc:            Ack,
+		HeartbeatFunc:      Heartbeat,
+		ShutdownFunc:       Shutdown,
+		ProbeFunc:          StatusProbe,
+		VerifyFunc:         StatusProbe,
+		ProbeTimeout:       DefaultProbeTimeout,
+		ProbeWorkers:       DefaultProbeWorkers,
+		InactiveAfter:      DefaultInactiveAfter,
+		EvictAfter:         DefaultEvictAfter,
+		CheckpointInterval: DefaultCheckpointInterval,
+		SyncInterval:       DefaultSyncInterval,
+		Verbose:            false,
 	}
 	return &master
 }
 
 // start the actual server
 func (m *MasterServer) Run(ctx context.Context) {
+	if err := m.LoadClients(); err != nil {
+		log.Println("unable to load clients:", err)
+	}
 	listenAddr := fmt.Sprintf("%s:%d", m.Address, m.Port)
 	listener, err := net.ListenPacket("udp", listenAddr)
 	if err != nil {
@@ -130,8 +175,18 @@ func (m *MasterServer) Run(ctx context.Context) {
 	if m.ThinkFunc != nil {
 		go m.ThinkFunc(ctx, m)
 	}
-	if m.Refresh {
-		go m.DetailRefresher(ctx)
+	if m.Storage != nil {
+		go m.Checkpointer(ctx)
+	}
+	if len(m.Upstreams) > 0 {
+		go m.UpstreamSyncer(ctx)
+	}
+	if m.HTTPAddress != "" {
+		go func() {
+			if err := m.RunHTTP(ctx); err != nil {
+				log.Println("HTTP API error:", err)
+			}
+		}()
 	}
 	buf := make([]byte, 1024)
 	for {
@@ -139,12 +194,30 @@ func (m *MasterServer) Run(ctx context.Context) {
 		if err != nil {
 			continue
 		}
-		go processMessage(m, &addr, buf[:count])
+		// the buffer is reused for the next datagram while this one is
+		// still being processed
+		data := make([]byte, count)
+		copy(data, buf[:count])
+		go processMessage(m, &addr, data)
 	}
 }
 
+// A copy of the master's stats
+func (m *MasterServer) GetStats() MasterServerStats {
+	m.statsLock.Lock()
+	defer m.statsLock.Unlock()
+	return m.Stats
+}
+
+// Change the master's stats
+func (m *MasterServer) updateStats(change func(st *MasterServerStats)) {
+	m.statsLock.Lock()
+	defer m.statsLock.Unlock()
+	change(&m.Stats)
+}
+
 // Grab all servers
-func (m MasterServer) FetchServers() ([]MasterClient, error) {
+func (m *MasterServer) FetchServers() ([]MasterClient, error) {
 	var clients []MasterClient
 	req := message.ConnectionlessPacket{
 		Data: "getservers",
@@ -168,20 +241,77 @@ func (m MasterServer) FetchServers() ([]MasterClient, error) {
 	return clients, nil
 }
 
-// Write all MasterClient's info to a buffer for responding
-func (m *MasterServer) MarshalClients() *message.Buffer {
+// Write all MasterClient's info to buffers for responding, at most
+// ClassicEntriesPerPacket in each so every response packet fits in
+// MaxResponseSize. The last buffer is never full, possibly empty, so clients
+// know when they have everything. The classic format only has room for IPv4
+// addresses, see MarshalClients6() for the rest.
+//
+// This is the "getservers" list other masters pull, so servers imported from
+// other masters are left out. Passing them along would let them bounce
+// between masters and skip the checks of the master they were heartbeating
+// to. See MarshalAllClients() for players.
+func (m *MasterServer) MarshalClients() []*message.Buffer {
+	return m.marshalPages(ClassicEntriesPerPacket, false, marshalClassic)
+}
+
+// Same as MarshalClients() but including imported servers, for players
+// asking with "query".
+func (m *MasterServer) MarshalAllClients() []*message.Buffer {
+	return m.marshalPages(ClassicEntriesPerPacket, true, marshalClassic)
+}
+
+// Same as MarshalClients() but in the IPv6 format, IPv4 servers included.
+func (m *MasterServer) MarshalClients6() []*message.Buffer {
+	return m.marshalPages(IPv6EntriesPerPacket, false, func(cl *MasterClient) *message.Buffer {
+		return cl.Marshal6()
+	})
+}
+
+// Classic entries, IPv6 servers don't fit
+func marshalClassic(cl *MasterClient) *message.Buffer {
+	if cl.IP.To4() == nil {
+		return nil
+	}
+	return cl.Marshal()
+}
+
+// Marshal every listed client into pages of perPage entries. The last page
+// is never full. Clients marshalled to nil are left out, as are imported
+// clients unless asked for.
+func (m *MasterServer) marshalPages(perPage int, imported bool, marshal func(cl *MasterClient) *message.Buffer) []*message.Buffer {
+	var pages []*message.Buffer
 	msg := message.NewEmptyBuffer()
-	for _, cl := range m.Clients {
-		msg.Append(*cl.Marshal())
+	count := 0
+	for _, cl := range m.Clients.Snapshot() {
+		if !cl.listed() || (cl.Source != "" && !imported) {
+			continue
+		}
+		entry := marshal(&cl)
+		if entry == nil {
+			continue
+		}
+		msg.Append(*entry)
+		count++
+		if count == perPage {
+			page := msg
+			pages = append(pages, &page)
+			msg = message.NewEmptyBuffer()
+			count = 0
+		}
 	}
-	return &msg
+	return append(pages, &msg)
 }
 
 // Write this MasterClient's IP and port in a format that can be sent as a
 // response
 func (cl *MasterClient) Marshal() *message.Buffer {
 	msg := message.NewEmptyBuffer()
-	msg.WriteData([]byte(cl.IP))
+	ip := cl.IP
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4 // net.ParseIP() gives 16 bytes even for IPv4
+	}
+	msg.WriteData([]byte(ip))
 
 	// reversed byte-order from msg.WriteShort()
 	port := []byte{
@@ -192,30 +322,38 @@ func (cl *MasterClient) Marshal() *message.Buffer {
 	return &msg
 }
 
-// Get a pointer to the client struct related to this IP address
-func (m *MasterServer) FindClient(cl net.Addr) *MasterClient {
-	for i, c := range m.Clients {
-		if c.Address.String() == cl.String() {
-			return &m.Clients[i]
-		}
-	}
-	return nil
+// Write this MasterClient's IP and port in the IPv6 response format. IPv4
+// addresses are written as IPv4-mapped IPv6 (::ffff:192.0.2.1).
+func (cl *MasterClient) Marshal6() *message.Buffer {
+	msg := message.NewEmptyBuffer()
+	msg.WriteData([]byte(cl.IP.To16()))
+	msg.WriteData([]byte{byte((cl.Port >> 8) & 0xff), byte(cl.Port & 0xff)})
+	return &msg
+}
+
+// Get a copy of the client related to this address
+func (m *MasterServer) FindClient(cl net.Addr) (MasterClient, bool) {
+	return m.Clients.Get(cl.String())
 }
 
 // Calculate the total number of heartbeats this server has seen from all
 // clients.
 func (m *MasterServer) HeartbeatCount() int {
 	total := 0
-	for _, cl := range m.Clients {
+	for _, cl := range m.Clients.Snapshot() {
 		total += cl.Heartbeats
 	}
 	return total
 }
 
-// Periodically checks in on each client, pruning dead ones. This should be run
+// Periodically probes every client, evicting dead ones. This should be run
 // concurrently.
 func Think(ctx context.Context, m *MasterServer) {
-	ticker := time.NewTicker(time.Duration(m.ThinkInterval) * time.Second)
+	interval := m.ThinkInterval
+	if interval <= 0 {
+		interval = DefaultThinkInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
 	defer ticker.Stop()
 
 	for {
@@ -226,33 +364,14 @@ func Think(ctx context.Context, m *MasterServer) {
 			}
 			return
 		case <-ticker.C:
-			for i := range m.Clients {
-				if m.Clients[i].PendingAcks > 3 {
-					RemoveClient(m, &m.Clients[i].Address)
-					continue
-				}
-				needsPing := m.Clients[i].LastContact.Add(time.Duration(m.PingInterval) * time.Second)
-				if time.Now().After(needsPing) {
-					Send("ping", m, &m.Clients[i].Address)
-					m.Clients[i].PendingAcks++
-					m.Clients[i].LastContact = time.Now()
-				}
-			}
+			m.ProbeAll(ctx)
 		}
 	}
 }
 
-// Removes a client from the client slice.
+// Removes a client from the master.
 func RemoveClient(m *MasterServer, from *net.Addr) {
-	oldClients := &m.Clients
-	newClients := []MasterClient{}
-	for i, cl := range *oldClients {
-		if cl.Address.String() == (*from).String() {
-			continue
-		}
-		newClients = append(newClients, (*oldClients)[i])
-	}
-	m.Clients = newClients
+	m.Clients.Remove((*from).String())
 }
 
 // For sending simple "ack"s and "ping"s
@@ -265,6 +384,9 @@ func Send(cmd string, m *MasterServer, recip *net.Addr) {
 
 // Runs concurrently for every datagram recieved by the master
 func processMessage(m *MasterServer, from *net.Addr, buf []byte) {
+	if !m.accept(*from) {
+		return
+	}
 	msg := message.NewBuffer(buf)
 	if msg.ReadLong() == -1 {
 		tok := strings.Split(string(msg.ReadData(msg.UnreadSize())), "\n")
@@ -272,11 +394,23 @@ func processMessage(m *MasterServer, from *net.Addr, buf []byte) {
 			return
 		}
 		cmd := strings.Trim(tok[0], "\x00\x0a\x20\x09") // null, new line, space, tab
+		args := strings.Fields(cmd)
+		if len(args) > 0 {
+			cmd = args[0]
+		}
 		switch cmd {
 		case "getservers":
-			if m.ClientListFunc != nil {
+			if slices.Contains(args[1:], "ipv6") {
+				if m.ClientListIPv6Func != nil {
+					m.ClientListIPv6Func(m, from)
+				}
+			} else if m.ClientListFunc != nil {
 				m.ClientListFunc(m, from)
 			}
+		case ExtRequest:
+			if m.ClientListExtFunc != nil {
+				m.ClientListExtFunc(m, from, ParseServerFilter(args[1:]))
+			}
 		case "ping":
 			if m.PingFunc != nil {
 				m.PingFunc(m, from)
@@ -285,8 +419,10 @@ func processMessage(m *MasterServer, from *net.Addr, buf []byte) {
 			if m.HeartbeatFunc != nil {
 				if len(tok) < 2 || tok[1] == "" {
 					log.Printf("invalid heartbeat format from %q, ignoring: %v", (*from).String(), tok)
+					m.Metrics.reject(RejectMalformed)
 					return
 				}
+				m.Metrics.heartbeat(time.Now())
 				m.HeartbeatFunc(m, from, state.ParseInfoString(tok[1][1:]))
 			}
 		case "ack":
@@ -299,96 +435,161 @@ func processMessage(m *MasterServer, from *net.Addr, buf []byte) {
 			}
 		default:
 			log.Printf("Ignoring unknown command %q from %s\n", cmd, (*from).String())
+			m.Metrics.reject(RejectUnknownCommand)
 		}
 	} else {
 		msg.Rewind()
-		if msg.ReadString() == "query\n" {
-			if m.ClientListFunc != nil {
-				m.ClientListFunc(m, from)
-			}
+		if msg.ReadString() != "query\n" {
+			m.Metrics.reject(RejectMalformed)
+			return
+		}
+		if m.QueryListFunc != nil {
+			m.QueryListFunc(m, from)
 		}
 	}
 }
 
-// Someone requested a list of all Q2 servers we know about.
+// Someone sent "getservers" for a list of the Q2 servers heartbeating to us.
+// Long lists are split over several packets, each starting with the
+// "servers " header.
 func ClientList(m *MasterServer, recip *net.Addr) {
-	m.Stats.GetServerHits++
-	msg := message.NewEmptyBuffer()
-	msg.WriteLong(-1)
-	msg.WriteData([]byte("servers ")) // note the space
-	clients := m.MarshalClients()
-	msg.Append(*clients)
-	(*m.Conn).WriteTo(msg.Data, *recip)
+	m.updateStats(func(st *MasterServerStats) { st.GetServerHits++ })
+	m.Metrics.listRequest("classic")
+	sendPages(m, recip, ClassicResponseHeader, m.MarshalClients())
+}
+
+// A player's client sent "query" for a list of all Q2 servers we know about,
+// imported ones included. Same format as ClientList().
+func QueryList(m *MasterServer, recip *net.Addr) {
+	m.updateStats(func(st *MasterServerStats) { st.GetServerHits++ })
+	m.Metrics.listRequest("classic")
+	sendPages(m, recip, ClassicResponseHeader, m.MarshalAllClients())
+}
+
+// Send each page of a list with the header in front
+func sendPages(m *MasterServer, recip *net.Addr, header string, pages []*message.Buffer) {
+	for _, page := range pages {
+		msg := message.NewEmptyBuffer()
+		msg.WriteLong(-1)
+		msg.WriteData([]byte(header))
+		msg.Append(*page)
+		(*m.Conn).WriteTo(msg.Data, *recip)
+	}
+}
+
+// Someone asked for servers with "getservers ipv6". Same as ClientList() but
+// with the "servers6 " header and 18 byte entries so IPv6 servers fit.
+func ClientListIPv6(m *MasterServer, recip *net.Addr) {
+	m.updateStats(func(st *MasterServerStats) { st.GetServerHits++ })
+	m.Metrics.listRequest("ipv6")
+	sendPages(m, recip, IPv6ResponseHeader, m.MarshalClients6())
 }
 
 // Sent from client to us every 5-10ish or so minutes.
 func Heartbeat(m *MasterServer, from *net.Addr, info map[string]string) {
-	cl := m.FindClient(*from)
-	if cl == nil {
-		cl = Ping(m, from)
-	}
-	cl.Heartbeats++
-	cl.LastContact = time.Now()
-	cl.Hostname = info["hostname"]
-	cl.GameDir = info["gamename"]
-	cl.CurrentMap = info["mapname"]
-	cl.Passworded = info["needpass"] == "1"
-	cl.Software = info["version"]
+	if _, ok := m.FindClient(*from); !ok {
+		if Ping(m, from) == nil {
+			return
+		}
+	}
 	mp, err := strconv.Atoi(info["maxclients"])
 	if err != nil {
 		log.Printf("invalid maxclients value %q defaulting to 8\n", info["maxclients"])
 		mp = 8
 	}
-	cl.MaxPlayers = mp
+	country := ""
 	if m.GeoIPs != nil {
 		ip, _, err := net.SplitHostPort((*from).String())
 		if err != nil {
 			log.Printf("unable to split %q into host/port for location: %v\n", (*from).String(), err)
 		} else {
-			cl.Country = m.GeoIPs.Lookup(ip)
+			country = m.GeoIPs.Lookup(ip)
 		}
 	}
+	m.Clients.Update((*from).String(), func(cl *MasterClient) {
+		cl.Active = true
+		cl.Heartbeats++
+		cl.LastContact = time.Now()
+		cl.Missedbeats = 0
+		cl.Hostname = info["hostname"]
+		cl.GameDir = info["gamename"]
+		cl.CurrentMap = info["mapname"]
+		cl.Passworded = info["needpass"] == "1"
+		cl.Software = info["version"]
+		cl.MaxPlayers = mp
+		if country != "" {
+			cl.Country = country
+		}
+		// it's talking to us directly now, it isn't an import anymore. When
+		// verifying it still needs to answer a probe, heartbeats are easy to
+		// spoof.
+		cl.Source = ""
+		cl.Synced = time.Time{}
+		cl.Unverified = cl.Unverified && m.Verify
+	})
+	if cl, _ := m.FindClient(*from); cl.Unverified {
+		go m.verify(*from)
+	}
 	Send("ack", m, from)
 	if m.Verbose {
 		log.Printf("heartbeat from %s - %s\n", (*from).String(), info["hostname"])
 	}
 }
 
-// An unfamiliar server started talking to us. Start tracking it.
+// An unfamiliar server started talking to us. Start tracking it. Returns a
+// copy of the client, or nil if the address is unusable.
 func Ping(m *MasterServer, from *net.Addr) *MasterClient {
-	c := m.FindClient(*from)
-	if c != nil {
-		return c // we already have this one
+	if c, ok := m.FindClient(*from); ok {
+		return &c // we already have this one
 	}
-	tokens := strings.Split((*from).String(), ":")
-	if len(tokens) != 2 {
+	if !m.admit(*from) {
+		return nil
+	}
+	_, p, err := net.SplitHostPort((*from).String())
+	ip := addrIP(*from)
+	if err != nil || ip == nil {
 		log.Printf("malformed addr %q, ignoring ping\n", (*from).String())
 		return nil
 	}
-	port, err := strconv.Atoi(tokens[1])
+	port, err := strconv.Atoi(p)
 	if err != nil {
-		log.Printf("ping - unable to parse port %q, defaulting to 27900\n", tokens[1])
+		log.Printf("ping - unable to parse port %q, defaulting to 27900\n", p)
 	}
-	cl := MasterClient{
+	cl, added := m.Clients.AddIfUnder(MasterClient{
 		Address:      *from,
-		IP:           net.ParseIP(tokens[0]),
+		IP:           ip,
 		Port:         port,
 		FirstContact: time.Now(),
+		Active:       true,
+		Unverified:   m.Verify,
+	}, m.MaxServersPerIP)
+	if !added && cl.Address == nil {
+		m.Metrics.reject(RejectMaxPerIP)
+		log.Printf("%s already has %d servers, not adding %s\n", ip, m.MaxServersPerIP, (*from).String())
+		return nil
 	}
-	m.Clients = append(m.Clients, cl)
-	log.Println("adding client", (*from).String(), "-", len(m.Clients), "total")
-	return &m.Clients[len(m.Clients)-1]
+	if added {
+		log.Println("adding client", (*from).String(), "-", m.Clients.Len(), "total")
+		if m.Verify {
+			go m.verify(*from)
+		}
+	}
+	return &cl
 }
 
 // A client sends us an Ack when we "ping" them (from management)
 func Ack(m *MasterServer, from *net.Addr) {
-	cl := m.FindClient(*from)
-	if cl == nil {
+	cl, ok := m.FindClient(*from)
+	if !ok {
 		return
 	}
-	cl.Heartbeats++
-	cl.LastContact = time.Now()
-	cl.PendingAcks--
+	m.Clients.Update(cl.addressString(), func(c *MasterClient) {
+		c.Heartbeats++
+		c.LastContact = time.Now()
+		if !m.Verify {
+			c.Unverified = false // acks can be spoofed, only trust a probe
+		}
+	})
 	sv := state.Server{Address: cl.IP.String(), Port: cl.Port}
 	info, err := sv.FetchInfo()
 	if err == nil {
@@ -400,80 +601,23 @@ func Ack(m *MasterServer, from *net.Addr) {
 				Ping:  p.Ping,
 			})
 		}
-		cl.Players = players
-		cl.Info = info.Server
+		m.Clients.Update(cl.addressString(), func(c *MasterClient) {
+			c.Players = players
+			c.Info = info.Server
+		})
 	}
 	if m.Verbose {
 		log.Println("ack from", (*from).String())
 	}
 }
 
-// Clients issue Shutdown msgs when they quit or go non-public
+// Clients issue Shutdown msgs when they quit or go non-public. Anyone can
+// spoof one, so the server is only marked inactive. The next ProbeAll() lists
+// it again if it still answers, otherwise it's evicted like any other server
+// that stopped answering.
 func Shutdown(m *MasterServer, from *net.Addr) {
 	log.Println("shutdown issued from", (*from).String())
-	cl := m.FindClient(*from)
-	if cl == nil {
-		return
-	}
-	RemoveClient(m, from)
-}
-
-// Once a minute, issue an out-of-band "info" request to each server to fetch
-// the map and status for of the players. This should be run as a concurrent
-// goroutine.
-func (m *MasterServer) DetailRefresher(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			if m.Verbose {
-				log.Println("context finished, stopping DetailRefresher thread")
-			}
-			return
-		case <-ticker.C:
-			playercount := 0
-			servercount := 0
-			for i, s := range m.Clients {
-				srv := state.Server{Address: s.IP.String(), Port: s.Port}
-				info, err := srv.FetchInfo()
-				if err != nil {
-					log.Printf("error fetching info for %q: %v", s.Hostname, err)
-					continue
-				}
-				m.Clients[i].CurrentMap = info.Server["mapname"]
-				m.Clients[i].Hostname = info.Server["hostname"]
-				m.Clients[i].GameDir = info.Server["gamename"]
-				mp, err := strconv.Atoi(info.Server["maxclients"])
-				if err != nil {
-					mp = 8
-				}
-				m.Clients[i].MaxPlayers = mp
-
-				var pls []MasterClientPlayer
-				if len(info.Players) > 0 {
-					for _, p := range info.Players {
-						when := time.Now()
-						for _, x := range m.Clients[i].Players {
-							if x.Name == p.Name {
-								when = x.ConnectTime
-							}
-						}
-						pls = append(pls, MasterClientPlayer{
-							Name:        p.Name,
-							Score:       p.Score,
-							Ping:        p.Ping,
-							ConnectTime: when,
-						})
-						playercount++
-					}
-				}
-				m.Clients[i].Players = pls
-				m.Stats.PlayerCount = playercount
-				servercount++
-			}
-			m.Stats.ServerCount = servercount
-		}
-	}
+	m.Clients.Update((*from).String(), func(cl *MasterClient) {
+		cl.Active = false
+	})
 }
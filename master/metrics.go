@@ -0,0 +1,243 @@
+package master
+
+import (
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/packetflinger/libq2/util"
+)
+
+// The Content-Type of the /metrics endpoint, the Prometheus text format
+const ContentTypeMetrics = "text/plain; version=0.0.4; charset=utf-8"
+
+// Why a datagram or server was turned away, the "reason" label of
+// q2master_rejected_total
+const (
+	RejectBadAddress     = "bad_address"     // couldn't get an IP from the source address
+	RejectBlocked        = "blocked"         // from a network in Block
+	RejectMalformed      = "malformed"       // not a message we can parse
+	RejectMaxPerIP       = "max_per_ip"      // IP already has MaxServersPerIP servers
+	RejectNotAllowed     = "not_allowed"     // not from a network in Allow
+	RejectRateLimited    = "rate_limited"    // IP is over RateLimit
+	RejectUnknownCommand = "unknown_command" // a command we don't handle
+	RejectUnverified     = "unverified"      // new server never answered its status probes
+)
+
+// Upper bounds of the probe latency histogram buckets, in seconds
+var ProbeLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+// Counters for the master's traffic, exposed in the Prometheus text format by
+// WriteMetrics(). Gauges like server and player counts are taken from the
+// client list when scraped instead. The zero value is ready to use.
+type Metrics struct {
+	lock           sync.Mutex
+	heartbeats     uint64            // total received
+	heartbeatRate  rateCounter       // for heartbeats per second
+	listRequests   map[string]uint64 // by format: classic, ipv6, ext, http
+	rejected       map[string]uint64 // by reason, see the Reject* consts
+	probes         map[string]uint64 // by result: answered, missed
+	latencyBuckets []uint64          // counts per ProbeLatencyBuckets, not cumulative
+	latencyCount   uint64            // probes answered
+	latencySum     float64           // seconds
+}
+
+func (mt *Metrics) heartbeat(now time.Time) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+	mt.heartbeats++
+	mt.heartbeatRate.add(now)
+}
+
+func (mt *Metrics) listRequest(format string) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+	if mt.listRequests == nil {
+		mt.listRequests = make(map[string]uint64)
+	}
+	mt.listRequests[format]++
+}
+
+func (mt *Metrics) reject(reason string) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+	if mt.rejected == nil {
+		mt.rejected = make(map[string]uint64)
+	}
+	mt.rejected[reason]++
+}
+
+// Record a status probe, latency is only kept for ones that were answered
+func (mt *Metrics) probed(latency time.Duration, answered bool) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+	if mt.probes == nil {
+		mt.probes = make(map[string]uint64)
+	}
+	if !answered {
+		mt.probes["missed"]++
+		return
+	}
+	mt.probes["answered"]++
+	if mt.latencyBuckets == nil {
+		mt.latencyBuckets = make([]uint64, len(ProbeLatencyBuckets))
+	}
+	secs := latency.Seconds()
+	if i := sort.SearchFloat64s(ProbeLatencyBuckets, secs); i < len(ProbeLatencyBuckets) {
+		mt.latencyBuckets[i]++
+	}
+	mt.latencyCount++
+	mt.latencySum += secs
+}
+
+// Counts events per second over the last minute
+type rateCounter struct {
+	counts [60]uint64
+	stamps [60]int64 // which second each count is for
+}
+
+func (r *rateCounter) add(now time.Time) {
+	sec := now.Unix()
+	i := sec % int64(len(r.counts))
+	if r.stamps[i] != sec {
+		r.stamps[i] = sec
+		r.counts[i] = 0
+	}
+	r.counts[i]++
+}
+
+func (r *rateCounter) perSecond(now time.Time) float64 {
+	var total uint64
+	for i, stamp := range r.stamps {
+		if age := now.Unix() - stamp; age >= 0 && age < int64(len(r.counts)) {
+			total += r.counts[i]
+		}
+	}
+	return float64(total) / float64(len(r.counts))
+}
+
+// Write the master's metrics in the Prometheus text format:
+//
+//	q2master_heartbeats_total              heartbeats received
+//	q2master_heartbeats_per_second         average over the last minute
+//	q2master_list_requests_total{format}   server lists handed out
+//	q2master_rejected_total{reason}        datagrams and servers turned away
+//	q2master_probes_total{result}          status probes answered or missed
+//	q2master_probe_latency_seconds         histogram of answered probes
+//	q2master_servers{state}                known servers: listed, inactive, unverified
+//	q2master_server_ips                    unique IPs among known servers
+//	q2master_players{gamedir}              players on listed servers
+//	q2master_players_by_country{country}   players on listed servers by the server's country
+//	q2master_start_time_seconds            unix time the master started
+func (m *MasterServer) WriteMetrics(w io.Writer) error {
+	now := time.Now()
+	e := metricsEncoder{}
+
+	mt := &m.Metrics
+	mt.lock.Lock()
+	e.family("q2master_heartbeats_total", "counter", "Heartbeats received from game servers.")
+	e.sample("q2master_heartbeats_total", "", "", float64(mt.heartbeats))
+	e.family("q2master_heartbeats_per_second", "gauge", "Heartbeats per second averaged over the last minute.")
+	e.sample("q2master_heartbeats_per_second", "", "", mt.heartbeatRate.perSecond(now))
+	e.family("q2master_list_requests_total", "counter", "Server lists handed out by request format.")
+	e.labelled("q2master_list_requests_total", "format", mt.listRequests)
+	e.family("q2master_rejected_total", "counter", "Datagrams and servers turned away by reason.")
+	e.labelled("q2master_rejected_total", "reason", mt.rejected)
+	e.family("q2master_probes_total", "counter", "Status probes sent to game servers by result.")
+	e.labelled("q2master_probes_total", "result", mt.probes)
+	e.family("q2master_probe_latency_seconds", "histogram", "Round trip of answered status probes.")
+	var cumulative uint64
+	for i, le := range ProbeLatencyBuckets {
+		if mt.latencyBuckets != nil {
+			cumulative += mt.latencyBuckets[i]
+		}
+		e.sample("q2master_probe_latency_seconds_bucket", "le", formatFloat(le), float64(cumulative))
+	}
+	e.sample("q2master_probe_latency_seconds_bucket", "le", "+Inf", float64(mt.latencyCount))
+	e.sample("q2master_probe_latency_seconds_sum", "", "", mt.latencySum)
+	e.sample("q2master_probe_latency_seconds_count", "", "", float64(mt.latencyCount))
+	mt.lock.Unlock()
+
+	servers := map[string]uint64{"listed": 0, "inactive": 0, "unverified": 0}
+	ips := make(map[string]bool)
+	gamedirs := make(map[string]uint64)
+	countries := make(map[string]uint64)
+	for _, cl := range m.Clients.Snapshot() {
+		ips[cl.IP.String()] = true
+		switch {
+		case cl.Unverified:
+			servers["unverified"]++
+			continue
+		case !cl.Active:
+			servers["inactive"]++
+			continue
+		}
+		servers["listed"]++
+		gamedirs[orUnknown(cl.GameDir)] += uint64(len(cl.Players))
+		countries[orUnknown(cl.Country)] += uint64(len(cl.Players))
+	}
+	e.family("q2master_servers", "gauge", "Known game servers by state.")
+	e.labelled("q2master_servers", "state", servers)
+	e.family("q2master_server_ips", "gauge", "Unique IPs among known game servers.")
+	e.sample("q2master_server_ips", "", "", float64(len(ips)))
+	e.family("q2master_players", "gauge", "Players on listed servers by gamedir.")
+	e.labelled("q2master_players", "gamedir", gamedirs)
+	e.family("q2master_players_by_country", "gauge", "Players on listed servers by the server's country.")
+	e.labelled("q2master_players_by_country", "country", countries)
+	e.family("q2master_start_time_seconds", "gauge", "Unix time the master started.")
+	e.sample("q2master_start_time_seconds", "", "", float64(m.GetStats().StartTime.Unix()))
+
+	_, err := io.WriteString(w, e.String())
+	return err
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// Builds the text exposition format
+type metricsEncoder struct {
+	strings.Builder
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func (e *metricsEncoder) family(name, kind, help string) {
+	e.WriteString("# HELP " + name + " " + help + "\n")
+	e.WriteString("# TYPE " + name + " " + kind + "\n")
+}
+
+// Write one sample, label is skipped if empty
+func (e *metricsEncoder) sample(name, label, value string, v float64) {
+	e.WriteString(name)
+	if label != "" {
+		e.WriteString("{" + label + "=\"" + labelEscaper.Replace(value) + "\"}")
+	}
+	e.WriteString(" " + formatFloat(v) + "\n")
+}
+
+// Write a sample for every value, sorted by label value
+func (e *metricsEncoder) labelled(name, label string, values map[string]uint64) {
+	for _, k := range util.SortedKeys(values) {
+		e.sample(name, label, k, float64(values[k]))
+	}
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,138 @@
+package master
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/packetflinger/libq2/message"
+)
+
+func TestRateCounter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := rateCounter{}
+	for i := 0; i < 120; i++ {
+		r.add(now.Add(time.Duration(i/2) * time.Second)) // 2 a second for a minute
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{name: "last minute", at: now.Add(59 * time.Second), want: 2},
+		{name: "half gone", at: now.Add(89 * time.Second), want: 1},
+		{name: "all gone", at: now.Add(2 * time.Minute), want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.perSecond(tc.at); got != tc.want {
+				t.Errorf("perSecond() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	m := NewMaster()
+	m.Block, _ = ParseNetworks([]string{"198.51.100.0/24"})
+	for i, gamedir := range []string{"baseq2", "opentdm", "baseq2", ""} {
+		addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910 + i}
+		m.Clients.Add(MasterClient{
+			Address: addr,
+			IP:      addr.IP,
+			Port:    addr.Port,
+			Active:  i < 3,
+			Country: "US",
+			GameDir: gamedir,
+			Players: []MasterClientPlayer{{Name: "claire"}, {Name: "bob"}},
+		})
+	}
+	unverified := &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 27910}
+	m.Clients.Add(MasterClient{Address: unverified, IP: unverified.IP, Port: unverified.Port, Unverified: true})
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	m.Conn = &conn
+	m.HeartbeatFunc = func(m *MasterServer, from *net.Addr, info map[string]string) {}
+	heartbeat := message.NewEmptyBuffer()
+	heartbeat.WriteLong(-1)
+	heartbeat.WriteData([]byte("heartbeat\n\\hostname\\test\n"))
+	unknown := message.NewEmptyBuffer()
+	unknown.WriteLong(-1)
+	unknown.WriteData([]byte("hello"))
+	var good, blocked net.Addr
+	good = &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+	blocked = &net.UDPAddr{IP: net.ParseIP("198.51.100.1"), Port: 27910}
+	processMessage(m, &good, heartbeat.Data)
+	processMessage(m, &good, heartbeat.Data)
+	processMessage(m, &good, unknown.Data)
+	processMessage(m, &good, []byte("garbage"))
+	processMessage(m, &blocked, heartbeat.Data)
+	m.Metrics.probed(20*time.Millisecond, true)
+	m.Metrics.probed(300*time.Millisecond, true)
+	m.Metrics.probed(0, false)
+
+	srv := httptest.NewServer(m.HTTPHandler())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != ContentTypeMetrics {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentTypeMetrics)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(body)
+
+	for _, want := range []string{
+		"# TYPE q2master_heartbeats_total counter\n",
+		"q2master_heartbeats_total 2\n",
+		`q2master_rejected_total{reason="blocked"} 1` + "\n",
+		`q2master_rejected_total{reason="malformed"} 1` + "\n",
+		`q2master_rejected_total{reason="unknown_command"} 1` + "\n",
+		`q2master_probes_total{result="answered"} 2` + "\n",
+		`q2master_probes_total{result="missed"} 1` + "\n",
+		"# TYPE q2master_probe_latency_seconds histogram\n",
+		`q2master_probe_latency_seconds_bucket{le="0.01"} 0` + "\n",
+		`q2master_probe_latency_seconds_bucket{le="0.025"} 1` + "\n",
+		`q2master_probe_latency_seconds_bucket{le="0.5"} 2` + "\n",
+		`q2master_probe_latency_seconds_bucket{le="+Inf"} 2` + "\n",
+		"q2master_probe_latency_seconds_count 2\n",
+		`q2master_servers{state="inactive"} 1` + "\n",
+		`q2master_servers{state="listed"} 3` + "\n",
+		`q2master_servers{state="unverified"} 1` + "\n",
+		"q2master_server_ips 2\n",
+		`q2master_players{gamedir="baseq2"} 4` + "\n",
+		`q2master_players{gamedir="opentdm"} 2` + "\n",
+		`q2master_players_by_country{country="US"} 6` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+	if strings.Contains(got, `gamedir="unknown"`) {
+		t.Error("players on inactive servers were counted")
+	}
+	if strings.Index(got, `{gamedir="baseq2"}`) > strings.Index(got, `{gamedir="opentdm"}`) {
+		t.Error("labels aren't sorted")
+	}
+}
+
+func TestMetricsEncoderEscaping(t *testing.T) {
+	e := metricsEncoder{}
+	e.sample("q2master_players", "gamedir", "a\"b\\c\nd", 1)
+	if want := `q2master_players{gamedir="a\"b\\c\nd"} 1` + "\n"; e.String() != want {
+		t.Errorf("sample() = %q, want %q", e.String(), want)
+	}
+}
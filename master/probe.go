@@ -0,0 +1,155 @@
+package master
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/packetflinger/libq2/state"
+)
+
+// Ask a server for its status from a fresh socket. Spoofed heartbeats can't
+// answer since the reply goes to the real address, and only a reply from that
+// address to our random source port is accepted.
+func StatusProbe(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+	timeout := m.ProbeTimeout
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	sv := state.Server{Address: cl.IP.String(), Port: cl.Port}
+	return sv.FetchInfoTimeout(time.Duration(timeout) * time.Millisecond)
+}
+
+// Status query every listed client at once, at most m.ProbeWorkers at a
+// time. Clients that answer get their details and latency updated, ones that
+// don't are marked inactive after m.InactiveAfter misses in a row and removed
+// after m.EvictAfter. Blocks until every probe is done or the context is.
+func (m *MasterServer) ProbeAll(ctx context.Context) {
+	workers := m.ProbeWorkers
+	if workers <= 0 {
+		workers = DefaultProbeWorkers
+	}
+	jobs := make(chan MasterClient)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cl := range jobs {
+				m.probe(&cl)
+			}
+		}()
+	}
+queue:
+	for _, cl := range m.Clients.Snapshot() {
+		if cl.Unverified {
+			continue // still being verified
+		}
+		select {
+		case jobs <- cl:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	clients := m.Clients.Snapshot()
+	players := 0
+	for _, cl := range clients {
+		if cl.listed() {
+			players += len(cl.Players)
+		}
+	}
+	m.updateStats(func(st *MasterServerStats) {
+		st.PlayerCount = players
+		st.ServerCount = len(clients)
+	})
+}
+
+// Status query one client and record the result
+func (m *MasterServer) probe(cl *MasterClient) {
+	probe := m.ProbeFunc
+	if probe == nil {
+		probe = StatusProbe
+	}
+	key := cl.addressString()
+	start := time.Now()
+	info, err := probe(m, cl)
+	latency := time.Since(start)
+	m.Metrics.probed(latency, err == nil)
+	if err == nil {
+		m.Clients.Update(key, func(c *MasterClient) {
+			c.Active = true
+			c.Missedbeats = 0
+			c.Latency = latency
+			c.applyInfo(info)
+		})
+		return
+	}
+
+	inactiveAfter := m.InactiveAfter
+	if inactiveAfter <= 0 {
+		inactiveAfter = DefaultInactiveAfter
+	}
+	evictAfter := m.EvictAfter
+	if evictAfter <= 0 {
+		evictAfter = DefaultEvictAfter
+	}
+	evict := false
+	m.Clients.Update(key, func(c *MasterClient) {
+		c.Missedbeats++
+		if c.Missedbeats >= inactiveAfter {
+			c.Active = false
+		}
+		evict = c.Missedbeats >= evictAfter
+	})
+	if evict {
+		log.Printf("%s missed %d probes, removing\n", key, evictAfter)
+		m.Clients.Remove(key)
+	} else if m.Verbose {
+		log.Printf("no answer from %s: %v\n", key, err)
+	}
+}
+
+// Copy a status response into the client. Players keep their connect time
+// from earlier responses.
+func (cl *MasterClient) applyInfo(info state.ServerInfo) {
+	if info.Server["hostname"] != "" {
+		cl.Hostname = info.Server["hostname"]
+	}
+	if info.Server["mapname"] != "" {
+		cl.CurrentMap = info.Server["mapname"]
+	}
+	if info.Server["gamename"] != "" {
+		cl.GameDir = info.Server["gamename"]
+	}
+	if mp, err := strconv.Atoi(info.Server["maxclients"]); err == nil {
+		cl.MaxPlayers = mp
+	}
+	cl.Info = info.Server
+
+	var players []MasterClientPlayer
+	for _, p := range info.Players {
+		when := time.Now()
+		for _, x := range cl.Players {
+			if x.Name == p.Name {
+				when = x.ConnectTime
+			}
+		}
+		players = append(players, MasterClientPlayer{
+			Name:        p.Name,
+			Score:       p.Score,
+			Ping:        p.Ping,
+			ConnectTime: when,
+		})
+	}
+	cl.Players = players
+}
+
+// Should the client be handed out in server lists?
+func (cl *MasterClient) listed() bool {
+	return cl.Active && !cl.Unverified
+}
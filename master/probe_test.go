@@ -0,0 +1,174 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/packetflinger/libq2/state"
+)
+
+func TestProbeAll(t *testing.T) {
+	const (
+		servers = 40
+		workers = 8
+	)
+	m := NewMaster()
+	m.ProbeWorkers = workers
+	m.InactiveAfter = 2
+	m.EvictAfter = 3
+
+	var lock sync.Mutex
+	running, most := 0, 0
+	m.ProbeFunc = func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+		lock.Lock()
+		running++
+		most = max(most, running)
+		lock.Unlock()
+		defer func() {
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+		if cl.Port%2 == 1 {
+			return state.ServerInfo{}, errors.New("timeout")
+		}
+		info := state.ServerInfo{Server: map[string]string{"hostname": fmt.Sprintf("server %d", cl.Port), "maxclients": "16"}}
+		info.Players = append(info.Players, struct {
+			Name  string
+			Score int
+			Ping  int
+		}{Name: "claire", Score: 1, Ping: 20})
+		return info, nil
+	}
+	for i := 0; i < servers; i++ {
+		addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910 + i}
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port, Active: true})
+	}
+
+	tests := []struct {
+		round   int
+		clients int
+		listed  int
+	}{
+		{round: 1, clients: servers, listed: servers},
+		{round: 2, clients: servers, listed: servers / 2}, // odd ports inactive
+		{round: 3, clients: servers / 2, listed: servers / 2},
+	}
+	for _, tc := range tests {
+		start := time.Now()
+		m.ProbeAll(context.Background())
+		if elapsed := time.Since(start); elapsed > servers*10*time.Millisecond/2 {
+			t.Errorf("round %d took %v, probes aren't running concurrently", tc.round, elapsed)
+		}
+		listed := 0
+		for _, cl := range m.Clients.Snapshot() {
+			if cl.listed() {
+				listed++
+			}
+		}
+		if m.Clients.Len() != tc.clients || listed != tc.listed {
+			t.Errorf("round %d: %d clients, %d listed, want %d and %d", tc.round, m.Clients.Len(), listed, tc.clients, tc.listed)
+		}
+	}
+	if most > workers {
+		t.Errorf("%d probes ran at once, limit is %d", most, workers)
+	}
+
+	cl, ok := m.Clients.Get("192.0.2.1:27910")
+	if !ok {
+		t.Fatal("answering server was removed")
+	}
+	if cl.Hostname != "server 27910" || cl.MaxPlayers != 16 || len(cl.Players) != 1 || cl.Latency < 10*time.Millisecond {
+		t.Errorf("probed server = %+v", cl)
+	}
+	if st := m.GetStats(); st.ServerCount != servers/2 || st.PlayerCount != servers/2 {
+		t.Errorf("stats = %+v, want %d servers and players", st, servers/2)
+	}
+}
+
+func TestProbeAllCancelled(t *testing.T) {
+	m := NewMaster()
+	m.ProbeWorkers = 1
+	probed := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	m.ProbeFunc = func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+		probed++
+		cancel()
+		return state.ServerInfo{}, nil
+	}
+	for i := 0; i < 5; i++ {
+		addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910 + i}
+		m.Clients.Add(MasterClient{Address: addr, IP: addr.IP, Port: addr.Port})
+	}
+	m.ProbeAll(ctx)
+	if probed > 2 {
+		t.Errorf("%d servers probed after the context was done", probed)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers bool
+		listed  bool
+		kept    bool
+	}{
+		{name: "spoofed", answers: true, listed: true, kept: true},
+		{name: "really shut down", answers: false, listed: false, kept: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMaster()
+			m.EvictAfter = 1
+			m.ProbeFunc = func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+				if !tc.answers {
+					return state.ServerInfo{}, errors.New("timeout")
+				}
+				return state.ServerInfo{}, nil
+			}
+			var addr net.Addr = &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+			m.Clients.Add(MasterClient{Address: addr, IP: net.ParseIP("192.0.2.1"), Port: 27910, Active: true})
+
+			Shutdown(m, &addr)
+			cl, ok := m.FindClient(addr)
+			if !ok || cl.listed() {
+				t.Fatalf("after shutdown found %t, listed %t, want found and unlisted", ok, cl.listed())
+			}
+			m.ProbeAll(context.Background())
+			cl, ok = m.FindClient(addr)
+			if ok != tc.kept || cl.listed() != tc.listed {
+				t.Errorf("after probing found %t, listed %t, want %t, %t", ok, cl.listed(), tc.kept, tc.listed)
+			}
+		})
+	}
+}
+
+func TestApplyInfo(t *testing.T) {
+	joined := time.Now().Add(-time.Hour)
+	cl := MasterClient{
+		Hostname:   "old",
+		CurrentMap: "q2dm1",
+		Players:    []MasterClientPlayer{{Name: "claire", ConnectTime: joined}},
+	}
+	info := state.ServerInfo{Server: map[string]string{"hostname": "new", "maxclients": "x"}}
+	for _, name := range []string{"claire", "bob"} {
+		info.Players = append(info.Players, struct {
+			Name  string
+			Score int
+			Ping  int
+		}{Name: name})
+	}
+	cl.applyInfo(info)
+	if cl.Hostname != "new" || cl.CurrentMap != "q2dm1" || cl.MaxPlayers != 0 {
+		t.Errorf("applyInfo() = %+v", cl)
+	}
+	if len(cl.Players) != 2 || !cl.Players[0].ConnectTime.Equal(joined) || cl.Players[1].ConnectTime.Before(joined) {
+		t.Errorf("players = %+v, claire should keep her connect time", cl.Players)
+	}
+}
@@ -0,0 +1,187 @@
+package master
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How many times a new server is probed before it's removed for never
+// answering.
+const VerifyAttempts = 3
+
+// Parse a list of networks in CIDR notation. Plain IPs are treated as a
+// network of just that address.
+func ParseNetworks(list []string) ([]*net.IPNet, error) {
+	var out []*net.IPNet
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", s)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %v", s, err)
+		}
+		out = append(out, network)
+	}
+	return out, nil
+}
+
+// Is the ip in any of the networks?
+func inNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// The IP part of an address
+func addrIP(addr net.Addr) net.IP {
+	if udp, ok := addr.(*net.UDPAddr); ok {
+		return udp.IP
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+// Should we handle a datagram from this address at all? Blocked networks and
+// IPs over the rate limit are dropped before the message is even parsed.
+func (m *MasterServer) accept(from net.Addr) bool {
+	ip := addrIP(from)
+	if ip == nil {
+		m.Metrics.reject(RejectBadAddress)
+		return false
+	}
+	if inNetworks(ip, m.Block) {
+		m.Metrics.reject(RejectBlocked)
+		if m.Verbose {
+			log.Println("dropping datagram from blocked address", from.String())
+		}
+		return false
+	}
+	if m.RateLimit > 0 && !m.limiter.allow(ip.String(), m.RateLimit, time.Now()) {
+		m.Metrics.reject(RejectRateLimited)
+		if m.Verbose {
+			log.Println("rate limiting", from.String())
+		}
+		return false
+	}
+	return true
+}
+
+// Can this address add a new server to the list? MaxServersPerIP is checked
+// when it's added, see Ping().
+func (m *MasterServer) admit(from net.Addr) bool {
+	ip := addrIP(from)
+	if ip == nil {
+		m.Metrics.reject(RejectBadAddress)
+		return false
+	}
+	if len(m.Allow) > 0 && !inNetworks(ip, m.Allow) {
+		m.Metrics.reject(RejectNotAllowed)
+		log.Printf("%s isn't allowed to register\n", from.String())
+		return false
+	}
+	return true
+}
+
+// Counts datagrams per IP over one minute windows. The counts are thrown out
+// every window so spoofed addresses can't grow it forever.
+type rateLimiter struct {
+	lock   sync.Mutex
+	window time.Time
+	counts map[string]int
+}
+
+func (r *rateLimiter) allow(ip string, limit int, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if now.Sub(r.window) >= time.Minute {
+		r.window = now
+		r.counts = make(map[string]int)
+	}
+	r.counts[ip]++
+	return r.counts[ip] <= limit
+}
+
+// Probe an unverified server until it answers, then list it. Servers that
+// never answer are removed. Only one probe runs per server at a time. This
+// blocks, run it concurrently.
+func (m *MasterServer) verify(addr net.Addr) {
+	key := addr.String()
+	if !m.startVerify(key) {
+		return
+	}
+	defer m.endVerify(key)
+
+	probe := m.VerifyFunc
+	if probe == nil {
+		probe = StatusProbe
+	}
+	for i := 0; i < VerifyAttempts; i++ {
+		cl, ok := m.Clients.Get(key)
+		if !ok || !cl.Unverified {
+			return // gone, or verified some other way
+		}
+		start := time.Now()
+		info, err := probe(m, &cl)
+		latency := time.Since(start)
+		m.Metrics.probed(latency, err == nil)
+		if err != nil {
+			continue
+		}
+		m.Clients.Update(key, func(c *MasterClient) {
+			c.Unverified = false
+			c.Latency = latency
+			c.applyInfo(info)
+		})
+		if m.Verbose {
+			log.Println("verified", key)
+		}
+		return
+	}
+	log.Printf("%s never answered, removing\n", key)
+	m.Metrics.reject(RejectUnverified)
+	m.Clients.Remove(key)
+}
+
+func (m *MasterServer) startVerify(key string) bool {
+	m.verifyLock.Lock()
+	defer m.verifyLock.Unlock()
+	if m.verifying[key] {
+		return false
+	}
+	if m.verifying == nil {
+		m.verifying = make(map[string]bool)
+	}
+	m.verifying[key] = true
+	return true
+}
+
+func (m *MasterServer) endVerify(key string) {
+	m.verifyLock.Lock()
+	defer m.verifyLock.Unlock()
+	delete(m.verifying, key)
+}
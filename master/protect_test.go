@@ -0,0 +1,199 @@
+package master
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/state"
+)
+
+func TestParseNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", list: nil, want: nil},
+		{name: "cidr", list: []string{"192.0.2.0/24", "2001:db8::/32"}, want: []string{"192.0.2.0/24", "2001:db8::/32"}},
+		{name: "plain ips", list: []string{"192.0.2.1", " 2001:db8::1 "}, want: []string{"192.0.2.1/32", "2001:db8::1/128"}},
+		{name: "host bits", list: []string{"192.0.2.7/24"}, want: []string{"192.0.2.0/24"}},
+		{name: "blank", list: []string{"", "192.0.2.1"}, want: []string{"192.0.2.1/32"}},
+		{name: "bad ip", list: []string{"192.0.2"}, wantErr: true},
+		{name: "bad cidr", list: []string{"192.0.2.0/33"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			networks, err := ParseNetworks(tc.list)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseNetworks() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			var got []string
+			for _, n := range networks {
+				got = append(got, n.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseNetworks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	r := rateLimiter{}
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		if !r.allow("192.0.2.1", 3, now) {
+			t.Fatalf("datagram %d denied, limit is 3", i+1)
+		}
+	}
+	if r.allow("192.0.2.1", 3, now) {
+		t.Error("4th datagram allowed, limit is 3")
+	}
+	if !r.allow("192.0.2.2", 3, now) {
+		t.Error("other IPs should have their own limit")
+	}
+	if !r.allow("192.0.2.1", 3, now.Add(time.Minute)) {
+		t.Error("limit not reset for the next window")
+	}
+}
+
+func TestProtection(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	heartbeat := message.NewEmptyBuffer()
+	heartbeat.WriteLong(-1)
+	heartbeat.WriteData([]byte("heartbeat\n\\hostname\\test\n"))
+
+	tests := []struct {
+		name  string
+		setup func(m *MasterServer)
+		from  []string
+		want  int // servers listed
+	}{
+		{
+			name:  "no protection",
+			setup: func(m *MasterServer) {},
+			from:  []string{"192.0.2.1:27910", "192.0.2.1:27911", "198.51.100.1:27910"},
+			want:  3,
+		},
+		{
+			name: "blocked",
+			setup: func(m *MasterServer) {
+				m.Block, _ = ParseNetworks([]string{"192.0.2.0/24"})
+			},
+			from: []string{"192.0.2.1:27910", "198.51.100.1:27910"},
+			want: 1,
+		},
+		{
+			name: "allowed",
+			setup: func(m *MasterServer) {
+				m.Allow, _ = ParseNetworks([]string{"198.51.100.0/24"})
+			},
+			from: []string{"192.0.2.1:27910", "198.51.100.1:27910", "198.51.100.2:27910"},
+			want: 2,
+		},
+		{
+			name:  "max per ip",
+			setup: func(m *MasterServer) { m.MaxServersPerIP = 2 },
+			from:  []string{"192.0.2.1:27910", "192.0.2.1:27911", "192.0.2.1:27912", "198.51.100.1:27910"},
+			want:  3,
+		},
+		{
+			name:  "rate limited",
+			setup: func(m *MasterServer) { m.RateLimit = 2 },
+			from:  []string{"192.0.2.1:27910", "192.0.2.1:27911", "192.0.2.1:27912", "198.51.100.1:27910"},
+			want:  3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMaster()
+			m.Conn = &conn
+			tc.setup(m)
+			for _, a := range tc.from {
+				var addr net.Addr
+				addr, err := net.ResolveUDPAddr("udp", a)
+				if err != nil {
+					t.Fatal(err)
+				}
+				processMessage(m, &addr, heartbeat.Data)
+			}
+			if got := m.Clients.Len(); got != tc.want {
+				t.Errorf("%d servers listed, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var real, spoofed net.Addr
+	real = &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+	spoofed = &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 27910}
+
+	m := NewMaster()
+	m.Conn = &conn
+	m.Verify = true
+	probed := make(chan string, 10)
+	m.VerifyFunc = func(m *MasterServer, cl *MasterClient) (state.ServerInfo, error) {
+		probed <- cl.addressString()
+		if cl.addressString() == real.String() {
+			return state.ServerInfo{Server: map[string]string{"hostname": "real"}}, nil
+		}
+		return state.ServerInfo{}, errors.New("no answer")
+	}
+
+	heartbeat := message.NewEmptyBuffer()
+	heartbeat.WriteLong(-1)
+	heartbeat.WriteData([]byte("heartbeat\n\\hostname\\test\n"))
+	ack := message.NewEmptyBuffer()
+	ack.WriteLong(-1)
+	ack.WriteData([]byte("ack"))
+	processMessage(m, &real, heartbeat.Data)
+	processMessage(m, &spoofed, heartbeat.Data)
+	processMessage(m, &spoofed, ack.Data) // a spoofed ack doesn't verify anything
+
+	if n := len(m.MarshalClients()[0].Data); n != 0 {
+		t.Errorf("%d bytes listed before verification, want 0", n)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		_, spoofedKnown := m.FindClient(spoofed)
+		cl, _ := m.FindClient(real)
+		if !spoofedKnown && !cl.Unverified {
+			if cl.Info["hostname"] != "real" {
+				t.Errorf("verified server info = %v, want the probe's", cl.Info)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("verification didn't finish, real: %+v, spoofed still listed: %t", cl, spoofedKnown)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := len(m.MarshalClients()[0].Data); n != 6 {
+		t.Errorf("%d bytes listed after verification, want 6", n)
+	}
+	close(probed)
+	counts := map[string]int{}
+	for a := range probed {
+		counts[a]++
+	}
+	want := map[string]int{real.String(): 1, spoofed.String(): VerifyAttempts}
+	if diff := cmp.Diff(want, counts); diff != "" {
+		t.Errorf("probes mismatch (-want +got):\n%s", diff)
+	}
+}
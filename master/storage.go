@@ -0,0 +1,219 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+const DefaultCheckpointInterval = 60 // secs
+
+// Somewhere to keep the master's clients between restarts. Servers only
+// heartbeat every few minutes, without this a restarted master has an empty
+// list until they do.
+type Storage interface {
+	Load() ([]MasterClient, error)
+	Save(clients []MasterClient) error
+}
+
+// Stores the clients in a file as a MasterRegistry protobuf
+type FileStorage struct {
+	Path string
+}
+
+func NewFileStorage(path string) *FileStorage {
+	return &FileStorage{Path: path}
+}
+
+// Read the clients from the file. A missing file is just an empty registry,
+// it won't exist the first time the master runs.
+func (f *FileStorage) Load() ([]MasterClient, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading registry: %v", err)
+	}
+	registry := &pb.MasterRegistry{}
+	if err := proto.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("parsing registry %q: %v", f.Path, err)
+	}
+	var out []MasterClient
+	for _, srv := range registry.GetServers() {
+		out = append(out, clientFromRegistry(srv))
+	}
+	return out, nil
+}
+
+// Write the clients to the file. The new file replaces the old one in one
+// step so a crash while saving doesn't lose the last checkpoint.
+func (f *FileStorage) Save(clients []MasterClient) error {
+	registry := &pb.MasterRegistry{Saved: time.Now().Unix()}
+	for _, cl := range clients {
+		registry.Servers = append(registry.Servers, cl.toRegistry())
+	}
+	data, err := proto.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("marshalling registry: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("saving registry: %v", err)
+	}
+	defer os.Remove(tmp.Name()) // fails once renamed, that's fine
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving registry: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving registry: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("saving registry: %v", err)
+	}
+	return nil
+}
+
+// Add the clients from storage to the master. Clients the master already
+// knows about are left alone.
+func (m *MasterServer) LoadClients() error {
+	if m.Storage == nil {
+		return nil
+	}
+	clients, err := m.Storage.Load()
+	if err != nil {
+		return err
+	}
+	for _, cl := range clients {
+		m.Clients.Add(cl)
+	}
+	if m.Verbose {
+		log.Printf("loaded %d clients from storage\n", len(clients))
+	}
+	return nil
+}
+
+// Save all the clients to storage
+func (m *MasterServer) SaveClients() error {
+	if m.Storage == nil {
+		return nil
+	}
+	return m.Storage.Save(m.Clients.Snapshot())
+}
+
+// Periodically save the clients to storage, and once more when the context
+// is done. This should be run concurrently.
+func (m *MasterServer) Checkpointer(ctx context.Context) {
+	interval := m.CheckpointInterval
+	if interval <= 0 {
+		interval = DefaultCheckpointInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := m.SaveClients(); err != nil {
+				log.Println("final checkpoint failed:", err)
+			}
+			return
+		case <-ticker.C:
+			if err := m.SaveClients(); err != nil {
+				log.Println("checkpoint failed:", err)
+			}
+		}
+	}
+}
+
+func (cl *MasterClient) toRegistry() *pb.RegisteredServer {
+	out := &pb.RegisteredServer{
+		Address:      cl.addressString(),
+		Ip:           cl.IP,
+		Port:         int32(cl.Port),
+		Country:      cl.Country,
+		Hostname:     cl.Hostname,
+		Map:          cl.CurrentMap,
+		GameDir:      cl.GameDir,
+		MaxPlayers:   int32(cl.MaxPlayers),
+		Passworded:   cl.Passworded,
+		Software:     cl.Software,
+		Active:       cl.Active,
+		FirstContact: unixTime(cl.FirstContact),
+		LastContact:  unixTime(cl.LastContact),
+		Heartbeats:   int32(cl.Heartbeats),
+		MissedBeats:  int32(cl.Missedbeats),
+		Info:         cl.Info,
+		Source:       cl.Source,
+		Synced:       unixTime(cl.Synced),
+		Unverified:   cl.Unverified,
+	}
+	for _, p := range cl.Players {
+		out.Players = append(out.Players, &pb.MasterServerPlayer{
+			Name:        p.Name,
+			Score:       int32(p.Score),
+			Ping:        int32(p.Ping),
+			Country:     p.Country,
+			ConnectTime: unixTime(p.ConnectTime),
+		})
+	}
+	return out
+}
+
+func clientFromRegistry(srv *pb.RegisteredServer) MasterClient {
+	cl := MasterClient{
+		Address:      &net.UDPAddr{IP: net.IP(srv.GetIp()), Port: int(srv.GetPort())},
+		IP:           net.IP(srv.GetIp()),
+		Port:         int(srv.GetPort()),
+		Country:      srv.GetCountry(),
+		Hostname:     srv.GetHostname(),
+		CurrentMap:   srv.GetMap(),
+		GameDir:      srv.GetGameDir(),
+		MaxPlayers:   int(srv.GetMaxPlayers()),
+		Passworded:   srv.GetPassworded(),
+		Software:     srv.GetSoftware(),
+		Active:       srv.GetActive(),
+		FirstContact: fromUnixTime(srv.GetFirstContact()),
+		LastContact:  fromUnixTime(srv.GetLastContact()),
+		Heartbeats:   int(srv.GetHeartbeats()),
+		Missedbeats:  int(srv.GetMissedBeats()),
+		Info:         srv.GetInfo(),
+		Source:       srv.GetSource(),
+		Synced:       fromUnixTime(srv.GetSynced()),
+		Unverified:   srv.GetUnverified(),
+	}
+	for _, p := range srv.GetPlayers() {
+		cl.Players = append(cl.Players, MasterClientPlayer{
+			Name:        p.GetName(),
+			Score:       int(p.GetScore()),
+			Ping:        int(p.GetPing()),
+			Country:     p.GetCountry(),
+			ConnectTime: fromUnixTime(p.GetConnectTime()),
+		})
+	}
+	return cl
+}
+
+// Zero times are stored as 0 rather than some time in year 1
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixTime(secs int64) time.Time {
+	if secs == 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
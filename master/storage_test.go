@@ -0,0 +1,101 @@
+package master
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileStorage(t *testing.T) {
+	seen := time.Unix(1700000000, 0)
+	tests := []struct {
+		name    string
+		clients []MasterClient
+	}{
+		{name: "empty", clients: nil},
+		{
+			name: "servers",
+			clients: []MasterClient{
+				{
+					Active:       true,
+					Address:      &net.UDPAddr{IP: net.ParseIP("192.0.2.1").To4(), Port: 27910},
+					IP:           net.ParseIP("192.0.2.1").To4(),
+					Port:         27910,
+					Country:      "us",
+					Hostname:     "TDM",
+					CurrentMap:   "q2dm1",
+					GameDir:      "opentdm",
+					MaxPlayers:   8,
+					FirstContact: seen,
+					LastContact:  seen.Add(time.Hour),
+					Heartbeats:   12,
+					Missedbeats:  1,
+					Info:         map[string]string{"timelimit": "10"},
+					Players: []MasterClientPlayer{
+						{Name: "claire", Score: 10, Ping: 30, ConnectTime: seen},
+					},
+				},
+				{
+					Address:    &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 27911},
+					IP:         net.ParseIP("2001:db8::1"),
+					Port:       27911,
+					Passworded: true,
+					Software:   "q2pro",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := NewFileStorage(filepath.Join(t.TempDir(), "registry.pb"))
+			if err := fs.Save(tc.clients); err != nil {
+				t.Fatal(err)
+			}
+			got, err := fs.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.clients, got); diff != "" {
+				t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileStorageMissing(t *testing.T) {
+	fs := NewFileStorage(filepath.Join(t.TempDir(), "nothing.pb"))
+	got, err := fs.Load()
+	if err != nil || len(got) != 0 {
+		t.Errorf("Load() = %v, %v, want an empty registry", got, err)
+	}
+}
+
+func TestCheckpointer(t *testing.T) {
+	fs := NewFileStorage(filepath.Join(t.TempDir(), "registry.pb"))
+	m := testMaster()
+	m.Storage = fs
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Checkpointer(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	restarted := NewMaster()
+	restarted.Storage = fs
+	if err := restarted.LoadClients(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := restarted.Clients.Len(), m.Clients.Len(); got != want {
+		t.Errorf("%d clients after restart, want %d", got, want)
+	}
+	if _, ok := restarted.FindClient(&net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}); !ok {
+		t.Error("192.0.2.1:27910 missing after restart")
+	}
+}
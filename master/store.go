@@ -0,0 +1,136 @@
+package master
+
+import (
+	"maps"
+	"net"
+	"slices"
+	"sync"
+)
+
+// The master's registry of Q2 servers, keyed by address (ip:port). It's safe
+// for concurrent use, every datagram is handled in its own goroutine and the
+// think/refresh loops run alongside them.
+//
+// Nothing outside the store holds a pointer to a stored client. Reads return
+// copies and changes are made with Update, so a client can't be modified
+// while it's being removed or listed. The zero value is an empty store ready
+// to use.
+type ClientStore struct {
+	lock    sync.RWMutex
+	clients map[string]*MasterClient
+}
+
+// Get a copy of the client with this address
+func (s *ClientStore) Get(addr string) (MasterClient, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	cl, ok := s.clients[addr]
+	if !ok {
+		return MasterClient{}, false
+	}
+	return cl.clone(), true
+}
+
+// Add a client unless one with the same address is already known. Returns a
+// copy of the stored client and whether it was added.
+func (s *ClientStore) Add(cl MasterClient) (MasterClient, bool) {
+	return s.AddIfUnder(cl, 0)
+}
+
+// Same as Add() but only if fewer than max clients share its IP, 0 for no
+// limit. The count is taken with the store locked so clients added at the
+// same time can't go over. An empty client is returned when it's over.
+func (s *ClientStore) AddIfUnder(cl MasterClient, max int) (MasterClient, bool) {
+	addr := cl.addressString()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if existing, ok := s.clients[addr]; ok {
+		return existing.clone(), false
+	}
+	if max > 0 && s.countIP(cl.IP) >= max {
+		return MasterClient{}, false
+	}
+	if s.clients == nil {
+		s.clients = make(map[string]*MasterClient)
+	}
+	stored := cl.clone()
+	s.clients[addr] = &stored
+	return stored.clone(), true
+}
+
+// Change a client in place. The function is called with the store locked, it
+// shouldn't block or call back into the store. Returns false if there's no
+// client with that address.
+func (s *ClientStore) Update(addr string, change func(cl *MasterClient)) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cl, ok := s.clients[addr]
+	if !ok {
+		return false
+	}
+	change(cl)
+	return true
+}
+
+// Remove the client with this address, returns whether it was there
+func (s *ClientStore) Remove(addr string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.clients[addr]; !ok {
+		return false
+	}
+	delete(s.clients, addr)
+	return true
+}
+
+// How many clients are known
+func (s *ClientStore) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.clients)
+}
+
+// Copies of all the clients, sorted by address so responses are stable
+func (s *ClientStore) Snapshot() []MasterClient {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var addrs []string
+	for addr := range s.clients {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	out := make([]MasterClient, 0, len(addrs))
+	for _, addr := range addrs {
+		out = append(out, s.clients[addr].clone())
+	}
+	return out
+}
+
+// A deep copy, nothing is shared with the original
+func (cl *MasterClient) clone() MasterClient {
+	out := *cl
+	out.IP = slices.Clone(cl.IP)
+	out.Players = slices.Clone(cl.Players)
+	out.Info = maps.Clone(cl.Info)
+	if addr, ok := cl.Address.(*net.UDPAddr); ok {
+		out.Address = &net.UDPAddr{IP: slices.Clone(addr.IP), Port: addr.Port, Zone: addr.Zone}
+	}
+	return out
+}
+
+// How many clients share this IP
+func (s *ClientStore) CountIP(ip net.IP) int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.countIP(ip)
+}
+
+func (s *ClientStore) countIP(ip net.IP) int {
+	count := 0
+	for _, cl := range s.clients {
+		if cl.IP.Equal(ip) {
+			count++
+		}
+	}
+	return count
+}
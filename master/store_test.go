@@ -0,0 +1,145 @@
+package master
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/packetflinger/libq2/message"
+)
+
+func TestClientStore(t *testing.T) {
+	s := ClientStore{}
+	addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910}
+	cl, added := s.Add(MasterClient{Address: addr, IP: addr.IP, Hostname: "one", Info: map[string]string{"a": "1"}})
+	if !added || cl.Hostname != "one" {
+		t.Fatalf("Add() = %v, %t", cl, added)
+	}
+	if _, added := s.Add(MasterClient{Address: addr, Hostname: "two"}); added {
+		t.Error("Add() added a duplicate address")
+	}
+
+	// copies can't change what's stored
+	cl.Info["a"] = "changed"
+	cl.Hostname = "changed"
+	got, ok := s.Get(addr.String())
+	if !ok || got.Hostname != "one" || got.Info["a"] != "1" {
+		t.Errorf("Get() = %v, the stored client was changed through a copy", got)
+	}
+
+	if !s.Update(addr.String(), func(c *MasterClient) { c.Heartbeats = 5 }) {
+		t.Error("Update() didn't find the client")
+	}
+	if got, _ := s.Get(addr.String()); got.Heartbeats != 5 {
+		t.Errorf("Heartbeats = %d after Update(), want 5", got.Heartbeats)
+	}
+	if s.Update("192.0.2.9:1", func(c *MasterClient) {}) {
+		t.Error("Update() found an unknown client")
+	}
+
+	if _, added := s.AddIfUnder(MasterClient{Address: addr, IP: addr.IP}, 1); added {
+		t.Error("AddIfUnder() added a duplicate address")
+	}
+	other := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27911}
+	if cl, added := s.AddIfUnder(MasterClient{Address: other, IP: other.IP}, 1); added || cl.Address != nil {
+		t.Errorf("AddIfUnder() = %v, %t over the limit", cl, added)
+	}
+	if _, added := s.AddIfUnder(MasterClient{Address: other, IP: other.IP}, 2); !added {
+		t.Error("AddIfUnder() didn't add under the limit")
+	}
+	s.Remove(other.String())
+
+	if !s.Remove(addr.String()) || s.Remove(addr.String()) {
+		t.Error("Remove() should succeed exactly once")
+	}
+	if s.Len() != 0 || len(s.Snapshot()) != 0 {
+		t.Error("store isn't empty after Remove()")
+	}
+}
+
+// Heartbeats, acks, listings and shutdowns all at once. Run with -race.
+func TestAddIfUnderConcurrent(t *testing.T) {
+	const max = 3
+	s := ClientStore{}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 27910 + i}
+			s.AddIfUnder(MasterClient{Address: addr, IP: addr.IP}, max)
+		}()
+	}
+	wg.Wait()
+	if n := s.Len(); n != max {
+		t.Errorf("%d clients added, want %d", n, max)
+	}
+}
+
+func TestConcurrentMessages(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	m := NewMaster()
+	m.Conn = &conn
+
+	const servers = 50
+	heartbeat := message.NewEmptyBuffer()
+	heartbeat.WriteLong(-1)
+	heartbeat.WriteData([]byte("heartbeat\n\\hostname\\test\\maxclients\\8\n"))
+	shutdown := message.NewEmptyBuffer()
+	shutdown.WriteLong(-1)
+	shutdown.WriteData([]byte("shutdown\n"))
+	list := message.NewEmptyBuffer()
+	list.WriteLong(-1)
+	list.WriteData([]byte("getservers\n"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < servers; i++ {
+		var addr net.Addr = &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 20000 + i}
+		for j := 0; j < 5; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				processMessage(m, &addr, heartbeat.Data)
+			}()
+			go func() {
+				defer wg.Done()
+				processMessage(m, &addr, list.Data)
+			}()
+		}
+	}
+	wg.Wait()
+	if n := m.Clients.Len(); n != servers {
+		t.Fatalf("%d clients after heartbeats, want %d", n, servers)
+	}
+	for _, cl := range m.Clients.Snapshot() {
+		if cl.Heartbeats != 5 {
+			t.Errorf("%s has %d heartbeats, want 5", cl.addressString(), cl.Heartbeats)
+		}
+	}
+
+	for i := 0; i < servers; i += 2 {
+		var addr net.Addr = &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 20000 + i}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			processMessage(m, &addr, shutdown.Data)
+		}()
+	}
+	wg.Wait()
+	listed := 0
+	for _, cl := range m.Clients.Snapshot() {
+		if cl.listed() {
+			listed++
+		}
+	}
+	if listed != servers/2 {
+		t.Errorf("%d clients listed after shutdowns, want %d", listed, servers/2)
+	}
+	if hits := m.GetStats().GetServerHits; hits != servers*5 {
+		t.Errorf("GetServerHits = %d, want %d", hits, servers*5)
+	}
+}
@@ -455,6 +455,8 @@ func DeltaEntityBitmask(to *pb.PackedEntity, from *pb.PackedEntity) int {
 		bits |= EntityOldOrigin
 	} else if (to.GetRenderFx() & RFBeam) > 0 {
 		bits |= EntityOldOrigin
+	} else if to.GetOldOriginX() != from.GetOldOriginX() || to.GetOldOriginY() != from.GetOldOriginY() || to.GetOldOriginZ() != from.GetOldOriginZ() {
+		bits |= EntityOldOrigin // new entities usually include where they came from
 	}
 
 	if (to.GetNumber() & 0xff00) > 0 {
@@ -121,9 +121,44 @@ const (
 	TentNumEntities
 )
 
+// muzzle flash types, for player weapons
+const (
+	MzBlaster = iota
+	MzMachinegun
+	MzShotgun
+	MzChaingun1
+	MzChaingun2
+	MzChaingun3
+	MzRailgun
+	MzRocket
+	MzGrenade
+	MzLogin
+	MzLogout
+	MzRespawn
+	MzBFG
+	MzSShotgun
+	MzHyperblaster
+	MzItemRespawn
+	MzIonRipper
+	MzBlueHyperblaster
+	MzPhalanx
+	MzSilenced = 128 // bit flag, or'd with the type
+)
+
 // configstrings
 const (
-	CSMapname = 33
+	CSStatusBar   = 5 // layout program for the hud
+	CSMapname     = 33
+	CSImages      = 544
+	CSItems       = 1056
+	CSPlayerSkins = 1312
+	CSGeneral     = 1568
+)
+
+// r1q2/q2pro server settings (svc_setting indexes)
+const (
+	SettingPlayerUpdates = iota
+	SettingFPS           // server frame rate in hz, default is 10
 )
 
 const (
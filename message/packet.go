@@ -1,8 +1,8 @@
 package message
 
 import (
-	"fmt"
 	"net"
+	"strconv"
 	"time"
 )
 
@@ -60,15 +60,26 @@ func (cp ConnectionlessPacket) Marshal() []byte {
 }
 
 func (cp ConnectionlessPacket) Send(srv string, port int) (Buffer, error) {
-	target := fmt.Sprintf("%s:%d", srv, port)
+	return cp.SendTimeout(srv, port, time.Second)
+}
+
+// Send the packet and wait up to timeout for the reply. No reply is an empty
+// buffer rather than an error.
+func (cp ConnectionlessPacket) SendTimeout(srv string, port int, timeout time.Duration) (Buffer, error) {
+	target := net.JoinHostPort(srv, strconv.Itoa(port))
 
-	// only use IPv4
-	conn, err := net.Dial("udp4", target)
+	// IPv4 unless given an IPv6 address, hostnames with AAAA records aren't
+	// necessarily listening on them
+	network := "udp4"
+	if ip := net.ParseIP(srv); ip != nil && ip.To4() == nil {
+		network = "udp6"
+	}
+	conn, err := net.Dial(network, target)
 	if err != nil {
 		return Buffer{}, err
 	}
 	defer conn.Close()
-	conn.SetReadDeadline(time.Now().Add(1 * time.Second))
+	conn.SetReadDeadline(time.Now().Add(timeout))
 	_, err = conn.Write(cp.Marshal())
 	if err != nil {
 		return Buffer{}, err
@@ -81,5 +92,5 @@ func (cp ConnectionlessPacket) Send(srv string, port int) (Buffer, error) {
 		return Buffer{}, nil
 	}
 
-	return Buffer{Data: d[:read]}, nil
+	return NewBuffer(d[:read]), nil
 }
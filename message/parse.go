@@ -14,9 +14,9 @@ const (
 	MaxEntities      = 1024
 	MaxConfigStrings = 2080
 	MaxMessageLength = 1390
-	PrintLevelLow    = 1
-	PrintLevelObit   = 2
-	PrintLevelHigh   = 3
+	PrintLevelLow    = 0 // item pickups
+	PrintLevelObit   = 1 // obituaries (PRINT_MEDIUM)
+	PrintLevelHigh   = 2 // server messages
 	PrintLevelChat   = 3
 )
 
@@ -361,10 +361,12 @@ func (m *Buffer) ParseLayout() *pb.Layout {
 }
 
 // 2 bytes for every item
-func (m *Buffer) ParseInventory() {
-	// we don't actually care about this, advance the buffer's pointer so we
-	// can accurately find any messages after this one.
-	m.Index += 2 * MaxItems
+func (m *Buffer) ParseInventory() *pb.Inventory {
+	inv := &pb.Inventory{Items: make([]int32, MaxItems)}
+	for i := range inv.Items {
+		inv.Items[i] = int32(m.ReadShort())
+	}
+	return inv
 }
 
 // A string that should appear temporarily in the center of the screen
@@ -377,6 +379,19 @@ func (m *Buffer) ParseCenterPrint() *pb.CenterPrint {
 	}
 }
 
+// Settings are extensions sent by r1q2 and q2pro servers just after the
+// serverdata. The important one is SettingFPS, servers running faster than
+// the original 10hz send their frame rate this way.
+func (m *Buffer) ParseSetting() *pb.Setting {
+	if m.Index == m.Length {
+		return nil
+	}
+	return &pb.Setting{
+		Index: int32(m.ReadLong()),
+		Value: int32(m.ReadLong()),
+	}
+}
+
 // This the first server-to-client message after client issues "getchallenge"
 // when initiating a connection.
 //
@@ -479,37 +494,62 @@ func (p *Buffer) ParsePacket(oldFrames map[int32]*pb.Frame) (*pb.Packet, error)
 	}
 	out := &pb.Packet{}
 	for p.Index < len(p.Data) {
-		cmd := p.ReadByte()
-		switch cmd {
-		case SVCServerData:
-			out.ServerData = p.ParseServerData()
-		case SVCConfigString:
-			out.ConfigStrings = append(out.ConfigStrings, p.ParseConfigString())
-		case SVCSpawnBaseline:
-			bitmask := p.ParseEntityBitmask()
-			number := p.ParseEntityNumber(bitmask)
-			out.Baselines = append(out.Baselines, p.ParseEntity(nil, number, bitmask))
-		case SVCStuffText:
-			out.Stuffs = append(out.Stuffs, p.ParseStuffText())
-		case SVCFrame: // includes playerstate and packetentities
-			out.Frames = append(out.Frames, p.ParseFrame(oldFrames))
-		case SVCPrint:
-			out.Prints = append(out.Prints, p.ParsePrint())
-		case SVCMuzzleFlash:
-			out.MuzzleFlashes = append(out.MuzzleFlashes, p.ParseMuzzleFlash())
-		case SVCTempEntity:
-			out.TempEnts = append(out.TempEnts, p.ParseTempEntity())
-		case SVCLayout:
-			out.Layouts = append(out.Layouts, p.ParseLayout())
-		case SVCSound:
-			out.Sounds = append(out.Sounds, p.ParseSound())
-		case SVCCenterPrint:
-			out.Centerprints = append(out.Centerprints, p.ParseCenterPrint())
-		}
+		p.ParseMessage(p.ReadByte(), out, oldFrames)
 	}
 	return out, nil
 }
 
+// ParseMessage parses a single server message, the command byte has already
+// been read. Whatever was parsed is added to out. This is shared by regular
+// packets and the payloads of multi-view demo unicasts and multicasts, which
+// are the same messages.
+//
+// Returns false for commands it doesn't know how to parse. Nothing after
+// those in the buffer can be trusted.
+func (p *Buffer) ParseMessage(cmd int, out *pb.Packet, oldFrames map[int32]*pb.Frame) bool {
+	switch cmd {
+	case SVCServerData:
+		out.ServerData = p.ParseServerData()
+	case SVCConfigString:
+		out.ConfigStrings = append(out.ConfigStrings, p.ParseConfigString())
+	case SVCSpawnBaseline:
+		bitmask := p.ParseEntityBitmask()
+		number := p.ParseEntityNumber(bitmask)
+		out.Baselines = append(out.Baselines, p.ParseEntity(nil, number, bitmask))
+	case SVCStuffText:
+		out.Stuffs = append(out.Stuffs, p.ParseStuffText())
+	case SVCFrame: // includes playerstate and packetentities
+		out.Frames = append(out.Frames, p.ParseFrame(oldFrames))
+	case SVCPrint:
+		out.Prints = append(out.Prints, p.ParsePrint())
+	case SVCMuzzleFlash:
+		out.MuzzleFlashes = append(out.MuzzleFlashes, p.ParseMuzzleFlash())
+	case SVCMuzzleFlash2:
+		out.MuzzleFlashes2 = append(out.MuzzleFlashes2, p.ParseMuzzleFlash())
+	case SVCTempEntity:
+		out.TempEnts = append(out.TempEnts, p.ParseTempEntity())
+	case SVCLayout:
+		out.Layouts = append(out.Layouts, p.ParseLayout())
+	case SVCInventory:
+		out.Inventories = append(out.Inventories, p.ParseInventory())
+	case SVCSound:
+		out.Sounds = append(out.Sounds, p.ParseSound())
+	case SVCCenterPrint:
+		out.Centerprints = append(out.Centerprints, p.ParseCenterPrint())
+	case SVCSetting:
+		out.Settings = append(out.Settings, p.ParseSetting())
+	case SVCDisconnect:
+		out.Disconnect = true
+	case SVCReconnect:
+		out.Reconnect = true
+	case SVCNOP:
+		// no payload
+	default:
+		return false
+	}
+	return true
+}
+
 // Write a ServerData proto back to binary
 func MarshalServerData(s *pb.ServerInfo) Buffer {
 	b := Buffer{}
@@ -536,6 +576,15 @@ func MarshalConfigstring(cs *pb.ConfigString) Buffer {
 	return b
 }
 
+// Write a Setting proto back to binary
+func MarshalSetting(s *pb.Setting) Buffer {
+	b := Buffer{}
+	b.WriteByte(SVCSetting)
+	b.WriteLong(int(s.GetIndex()))
+	b.WriteLong(int(s.GetValue()))
+	return b
+}
+
 // Write a StuffText proto back to binary
 func MarshalStuffText(st *pb.StuffText) Buffer {
 	b := Buffer{}
@@ -738,6 +787,19 @@ func MarshalTempEntity(te *pb.TemporaryEntity) Buffer {
 	return b
 }
 
+// Write an Inventory proto back to binary, all MaxItems counts are sent
+func MarshalInventory(inv *pb.Inventory) Buffer {
+	b := Buffer{}
+	for i := 0; i < MaxItems; i++ {
+		count := 0
+		if i < len(inv.GetItems()) {
+			count = int(inv.GetItems()[i])
+		}
+		b.WriteShort(count)
+	}
+	return b
+}
+
 // Write a Layout proto back to binary
 func MarshalLayout(lo *pb.Layout) Buffer {
 	b := Buffer{}
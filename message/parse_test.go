@@ -482,6 +482,40 @@ func TestParseCenterPrint(t *testing.T) {
 	}
 }
 
+func TestParseSetting(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *pb.Setting
+	}{
+		{
+			name: "empty data",
+			data: "",
+			want: nil,
+		},
+		{
+			name: "40hz",
+			data: "0100000028000000",
+			want: &pb.Setting{
+				Index: SettingFPS,
+				Value: 40,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := hexToBuffer(tc.data)
+			if err != nil {
+				t.Error(err)
+			}
+			got := in.ParseSetting()
+			if diff := cmp.Diff(got, tc.want, protocmp.Transform()); diff != "" {
+				t.Errorf("(%v).ParseSetting() resulted in diff:\n%v", tc.data, diff)
+			}
+		})
+	}
+}
+
 func TestParsePacket(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -580,6 +614,31 @@ func TestMarshalServerData(t *testing.T) {
 	}
 }
 
+func TestMarshalSetting(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.Setting
+		want string
+	}{
+		{
+			name: "40hz",
+			in: &pb.Setting{
+				Index: SettingFPS,
+				Value: 40,
+			},
+			want: "180100000028000000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := bufferToHex(MarshalSetting(tc.in))
+			if !strings.EqualFold(got, tc.want) {
+				t.Errorf("MarshalSetting(%v) = %v, want %v\n", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMarshalCenterprint(t *testing.T) {
 	tests := []struct {
 		name string
@@ -765,3 +824,69 @@ func TestMarshalTempEntity(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMessage(t *testing.T) {
+	inventory := make([]int32, MaxItems)
+	inventory[3] = 2
+	tests := []struct {
+		name   string
+		data   string
+		want   *pb.Packet
+		wantOK bool
+	}{
+		{
+			name:   "muzzleflash2",
+			data:   "02050007",
+			want:   &pb.Packet{MuzzleFlashes2: []*pb.MuzzleFlash{{Entity: 5, Weapon: 7}}},
+			wantOK: true,
+		},
+		{
+			name:   "centerprint",
+			data:   "0f686900",
+			want:   &pb.Packet{Centerprints: []*pb.CenterPrint{{Data: "hi"}}},
+			wantOK: true,
+		},
+		{
+			name:   "nop",
+			data:   "06",
+			want:   &pb.Packet{},
+			wantOK: true,
+		},
+		{
+			name:   "disconnect",
+			data:   "07",
+			want:   &pb.Packet{Disconnect: true},
+			wantOK: true,
+		},
+		{
+			name:   "inventory",
+			data:   "05" + strings.Repeat("0000", 3) + "0200" + strings.Repeat("0000", MaxItems-4),
+			want:   &pb.Packet{Inventories: []*pb.Inventory{{Items: inventory}}},
+			wantOK: true,
+		},
+		{
+			name: "unknown",
+			data: "c8",
+			want: &pb.Packet{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := NewBuffer(data)
+			got := &pb.Packet{}
+			if ok := msg.ParseMessage(msg.ReadByte(), got, nil); ok != tc.wantOK {
+				t.Errorf("ParseMessage() = %t, want %t", ok, tc.wantOK)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParseMessage() mismatch (-want +got):\n%s", diff)
+			}
+			if msg.Index != len(data) {
+				t.Errorf("ParseMessage() read %d bytes, want %d", msg.Index, len(data))
+			}
+		})
+	}
+}
@@ -27,6 +27,37 @@ const (
 	PlayerMask = (1 << PlayerBits) - 1
 )
 
+// player movement types
+const (
+	PMNormal = iota
+	PMSpectator
+	PMDead
+	PMGib // different bounding box
+	PMFreeze
+)
+
+// indexes into the playerstate stats array
+const (
+	StatHealthIcon = iota
+	StatHealth
+	StatAmmoIcon
+	StatAmmo
+	StatArmorIcon
+	StatArmor
+	StatSelectedIcon
+	StatPickupIcon
+	StatPickupString // configstring index of the item name
+	StatTimerIcon
+	StatTimer
+	StatHelpIcon
+	StatSelectedItem
+	StatLayouts
+	StatFrags
+	StatFlashes
+	StatChase
+	StatSpectator
+)
+
 // DeltaPlayerBitmask will return a bitmask representing the difference between
 // two playerstates. This way only differences are transmitted from server to
 // client to save bandwidth/processing since playerstates are emitted on every
@@ -109,8 +140,9 @@ func (m *Buffer) ParseDeltaPlayerstate(from *pb.PackedPlayer) *pb.PackedPlayer {
 			// from might not have playermove defined
 			pm = &pb.PlayerMove{}
 		}
+		// stats not in the mask are unchanged
 		for k, v := range from.GetStats() {
-			to.Stats[k] = v
+			stats[k] = v
 		}
 	}
 	mask := m.ReadWord()
@@ -141,6 +141,44 @@ func TestParseDeltaPlayerstate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unchanged stats kept from previous state",
+			data: "96265F225013C10E5D07F10200000400003654000016FB0AF501FDFFFF000033028000005E000100",
+			from: &pb.PackedPlayer{
+				Stats: map[uint32]int32{
+					StatHealth: 100,
+					StatAmmo:   25,
+					StatArmor:  50,
+				},
+			},
+			want: &pb.PackedPlayer{
+				Movestate: &pb.PlayerMove{
+					OriginX:   8799,
+					OriginY:   4944,
+					OriginZ:   3777,
+					VelocityX: 1885,
+					VelocityY: 753,
+					Flags:     4,
+				},
+				ViewOffsetZ: 54,
+				KickAnglesX: 84,
+				GunAnglesX:  1,
+				GunAnglesY:  -3,
+				GunAnglesZ:  -1,
+				GunOffsetX:  -5,
+				GunOffsetY:  10,
+				GunOffsetZ:  -11,
+				GunFrame:    22,
+				BlendW:      -1,
+				BlendZ:      51,
+				Stats: map[uint32]int32{
+					StatHealth:  94,
+					StatAmmo:    25,
+					StatArmor:   50,
+					StatFlashes: 1,
+				},
+			},
+		},
 		{
 			name: "another",
 			data: "11F2393B239F13C10E0420030000006000000000587D038C0F0000041EFB0AF50000006900000000",
@@ -0,0 +1,270 @@
+package playback
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/player"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+const (
+	UpdateBackup = 16 // frames the client keeps for delta compression
+	MinSpeed     = 0.1
+	MaxSpeed     = 10.0
+)
+
+// A Quake 2 client watching the demo. Each client has its own position in
+// the demo and its own controls.
+type Client struct {
+	Address  net.Addr
+	Userinfo player.Userinfo
+	Spawned  bool    // sent "begin", frames are flowing
+	Paused   bool    // keep sending the same frame
+	Speed    float64 // 1 is normal
+	Position int     // index of the current demo frame
+
+	netchan     netchan
+	lastMessage time.Time           // for timeouts
+	lastFrame   int32               // the last frame the client acked
+	frameNum    int32               // our frame numbers, not the demo's
+	sent        map[int32]*pb.Frame // what was sent in each frame, for deltas
+	clock       float64             // demo frames owed to the client
+	configs     map[int32]string    // configstrings the client has
+}
+
+func newClient(addr net.Addr, ui player.Userinfo) *Client {
+	return &Client{
+		Address:     addr,
+		Userinfo:    ui,
+		Speed:       1,
+		lastMessage: time.Now(),
+		lastFrame:   -1,
+		sent:        make(map[int32]*pb.Frame),
+		configs:     make(map[int32]string),
+	}
+}
+
+// The client's name from their userinfo
+func (c *Client) Name() string {
+	return c.Userinfo["name"]
+}
+
+// Queue a message to be printed in the client's console
+func (c *Client) Printf(format string, a ...any) {
+	msg := message.Buffer{}
+	msg.WriteByte(message.SVCPrint)
+	msg.Append(message.MarshalPrint(&pb.Print{
+		Level: message.PrintLevelHigh,
+		Data:  fmt.Sprintf(format, a...),
+	}))
+	c.netchan.queue(msg.Data)
+}
+
+// Send all the configstrings that differ from what the client has
+func (c *Client) syncConfigstrings(configs map[int32]string) {
+	var keys []int32
+	for k := range configs {
+		keys = append(keys, k)
+	}
+	for k := range c.configs {
+		if _, ok := configs[k]; !ok {
+			keys = append(keys, k) // cleared
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if c.configs[k] == configs[k] {
+			continue
+		}
+		c.configs[k] = configs[k]
+		c.netchan.queue(message.MarshalConfigstring(&pb.ConfigString{
+			Index: uint32(k),
+			Data:  configs[k],
+		}).Data)
+	}
+}
+
+// Forget all the frames sent so the next one is uncompressed. Needed after
+// jumping around in the demo since the entities could be completely
+// different.
+func (c *Client) resetDelta() {
+	c.sent = make(map[int32]*pb.Frame)
+	c.lastFrame = -1
+}
+
+// The frame the next one should be compressed against, nil for none
+func (c *Client) deltaFrame() *pb.Frame {
+	if c.lastFrame <= 0 || c.frameNum-c.lastFrame >= UpdateBackup {
+		return nil
+	}
+	return c.sent[c.lastFrame]
+}
+
+// Build the next frame message for the client: the frame itself compressed
+// against the last one they acked followed by the unreliable messages.
+func (c *Client) frameMessage(fr *pb.Frame, extra []*pb.Frame) []byte {
+	c.frameNum++
+	from := c.deltaFrame()
+	delta := int32(-1)
+	if from != nil {
+		delta = from.GetNumber()
+	}
+	state := &pb.Frame{
+		Number:      c.frameNum,
+		PlayerState: fr.GetPlayerState(),
+		Entities:    fr.GetEntities(),
+	}
+	c.sent[c.frameNum] = state
+	delete(c.sent, c.frameNum-UpdateBackup)
+
+	msg := message.Buffer{}
+	msg.WriteByte(message.SVCFrame)
+	msg.WriteLong(int(c.frameNum))
+	msg.WriteLong(int(delta))
+	msg.WriteByte(0) // suppressed
+	msg.WriteByte(len(fr.GetAreaBits()))
+	for _, ab := range fr.GetAreaBits() {
+		msg.WriteByte(int(ab))
+	}
+	var fromPS *pb.PackedPlayer
+	var fromEnts map[int32]*pb.PackedEntity
+	if from != nil {
+		fromPS = from.GetPlayerState()
+		fromEnts = from.GetEntities()
+	}
+	toPS := fr.GetPlayerState()
+	if toPS == nil {
+		toPS = &pb.PackedPlayer{}
+	}
+	msg.Append(message.WriteDeltaPlayerstate(fromPS, toPS))
+	msg.Append(writePacketEntities(fromEnts, fr.GetEntities()))
+
+	for _, f := range extra {
+		for _, m := range f.GetFlashes1() {
+			msg.WriteByte(message.SVCMuzzleFlash)
+			msg.Append(message.MarshalFlash(m))
+		}
+		for _, m := range f.GetFlashes2() {
+			msg.WriteByte(message.SVCMuzzleFlash2)
+			msg.Append(message.MarshalFlash(m))
+		}
+		for _, m := range f.GetTemporaryEntities() {
+			msg.WriteByte(message.SVCTempEntity)
+			msg.Append(message.MarshalTempEntity(m))
+		}
+		for _, m := range f.GetSounds() {
+			msg.WriteByte(message.SVCSound)
+			msg.Append(message.MarshalSound(m))
+		}
+	}
+	return msg.Data
+}
+
+// Queue the messages that have to arrive: prints, layouts, etc. Stuffed
+// commands from the demo are not passed on, they were meant for the player
+// that recorded it.
+func (c *Client) queueReliable(fr *pb.Frame) {
+	for _, k := range util.SortedKeys(fr.GetConfigstrings()) {
+		cs := fr.GetConfigstrings()[k]
+		c.configs[k] = cs.GetData()
+		c.netchan.queue(message.MarshalConfigstring(cs).Data)
+	}
+	for _, m := range fr.GetPrints() {
+		msg := message.Buffer{}
+		msg.WriteByte(message.SVCPrint)
+		msg.Append(message.MarshalPrint(m))
+		c.netchan.queue(msg.Data)
+	}
+	for _, m := range fr.GetCenterprints() {
+		msg := message.Buffer{}
+		msg.WriteByte(message.SVCCenterPrint)
+		msg.Append(message.MarshalCenterPrint(m))
+		c.netchan.queue(msg.Data)
+	}
+	for _, m := range fr.GetLayouts() {
+		msg := message.Buffer{}
+		msg.WriteByte(message.SVCLayout)
+		msg.Append(message.MarshalLayout(m))
+		c.netchan.queue(msg.Data)
+	}
+}
+
+// Write the changes between two sets of entities. New entities are written
+// in full, entities that are gone are removed.
+func writePacketEntities(from, to map[int32]*pb.PackedEntity) message.Buffer {
+	msg := message.Buffer{}
+	msg.WriteByte(message.SVCPacketEntities)
+	nums := make(map[int32]bool)
+	for k, ent := range from {
+		if !ent.GetRemove() {
+			nums[k] = true
+		}
+	}
+	for k, ent := range to {
+		if !ent.GetRemove() {
+			nums[k] = true
+		}
+	}
+	for _, num := range util.SortedKeys(nums) {
+		old, inOld := from[num]
+		ent, inNew := to[num]
+		inOld = inOld && !old.GetRemove()
+		inNew = inNew && !ent.GetRemove()
+		switch {
+		case !inNew:
+			msg.Append(message.WriteDeltaEntity(nil, &pb.PackedEntity{Number: uint32(num), Remove: true}))
+		case !inOld:
+			msg.Append(message.WriteDeltaEntity(&pb.PackedEntity{}, withNumber(ent, num)))
+		case !proto.Equal(old, ent):
+			msg.Append(message.WriteDeltaEntity(old, withNumber(ent, num)))
+		}
+	}
+	msg.WriteShort(0) // end of entities
+	return msg
+}
+
+// Entities in the demo don't always have their number set
+func withNumber(ent *pb.PackedEntity, num int32) *pb.PackedEntity {
+	if ent.GetNumber() == uint32(num) {
+		return ent
+	}
+	e := proto.Clone(ent).(*pb.PackedEntity)
+	e.Number = uint32(num)
+	return e
+}
+
+// Parse a time for seeking. "90" and "1:30" are both 90 seconds from the
+// start of the demo, a leading + or - is relative to the current time.
+// Returns the time in milliseconds and whether it's relative.
+func ParseSeekTime(s string) (int32, bool, error) {
+	relative := strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")
+	sign := int32(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	s = strings.TrimLeft(s, "+-")
+	var secs float64
+	for _, part := range strings.Split(s, ":") {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 {
+			return 0, false, fmt.Errorf("invalid time %q", s)
+		}
+		secs = secs*60 + v
+	}
+	return sign * int32(secs*1000), relative, nil
+}
+
+// Format milliseconds as m:ss
+func formatTime(msecs int32) string {
+	secs := msecs / 1000
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
@@ -0,0 +1,114 @@
+package playback
+
+import (
+	"github.com/packetflinger/libq2/message"
+)
+
+const (
+	MaxReliableSize = 1024 // leave some of each packet for the frame
+	MaxPendingSize  = 256 * 1024
+)
+
+// The server side of a Q2 netchan. Every packet has a header with its
+// sequence number and the last sequence received from the other side, the
+// high bits are for reliable messages.
+//
+// Only one reliable message can be in flight at a time. It's resent in every
+// packet until the client acks it (by echoing the reliable bit back), more
+// reliable messages queue up in the mean time. Unreliable data is just
+// whatever fits after that.
+type netchan struct {
+	outgoing            int32    // sequence of the last packet sent
+	incoming            int32    // sequence of the last packet received
+	incomingAck         int32    // the last of our packets the client got
+	incomingReliableAck bool     // the reliable bit the client last acked
+	incomingReliable    bool     // toggled for each reliable received
+	reliableSequence    bool     // toggled for each reliable sent
+	lastReliable        int32    // outgoing sequence the reliable was sent in
+	reliable            []byte   // sent, waiting for an ack
+	pending             [][]byte // whole messages waiting to be sent reliably
+	pendingSize         int
+	overflowed          bool // the client couldn't keep up
+}
+
+// Queue a message to be sent reliably. Messages are never split across
+// packets. If too much is queued up the channel is marked as overflowed and
+// the client should be dropped.
+func (n *netchan) queue(msg []byte) {
+	if n.pendingSize+len(msg) > MaxPendingSize {
+		n.overflowed = true
+		return
+	}
+	n.pending = append(n.pending, msg)
+	n.pendingSize += len(msg)
+}
+
+// Is anything still waiting to be delivered reliably?
+func (n *netchan) reliablePending() bool {
+	return len(n.reliable) > 0 || len(n.pending) > 0
+}
+
+// Build the next packet to send. Unreliable data that doesn't fit is dropped.
+func (n *netchan) transmit(unreliable []byte) []byte {
+	send := false
+	// the last reliable was lost, send it again
+	if n.incomingAck > n.lastReliable && n.incomingReliableAck != n.reliableSequence {
+		send = true
+	}
+	if len(n.reliable) == 0 && len(n.pending) > 0 {
+		for len(n.pending) > 0 && (len(n.reliable) == 0 || len(n.reliable)+len(n.pending[0]) <= MaxReliableSize) {
+			n.reliable = append(n.reliable, n.pending[0]...)
+			n.pendingSize -= len(n.pending[0])
+			n.pending = n.pending[1:]
+		}
+		n.reliableSequence = !n.reliableSequence
+		send = true
+	}
+
+	n.outgoing++
+	out := message.Buffer{}
+	out.WriteLong(int(n.outgoing))
+	if send {
+		out.Data[out.Index-1] |= 0x80
+	}
+	out.WriteLong(int(n.incoming))
+	if n.incomingReliable {
+		out.Data[out.Index-1] |= 0x80
+	}
+	if send {
+		out.WriteData(n.reliable)
+		n.lastReliable = n.outgoing
+	}
+	if len(out.Data)+len(unreliable) <= message.MaxMessageLength {
+		out.WriteData(unreliable)
+	}
+	return out.Data
+}
+
+// Read the header of a packet from the client, returning the rest of the
+// packet. Old and duplicate packets are dropped.
+func (n *netchan) process(data []byte) ([]byte, bool) {
+	if len(data) < 10 {
+		return nil, false
+	}
+	msg := message.NewBuffer(data)
+	seq := uint32(msg.ReadLong())
+	ack := uint32(msg.ReadLong())
+	msg.ReadShort() // qport, the address is enough for us
+
+	sequence := int32(seq &^ (1 << 31))
+	if sequence <= n.incoming {
+		return nil, false
+	}
+	reliableAck := ack>>31 == 1
+	if reliableAck == n.reliableSequence {
+		n.reliable = nil // delivered
+	}
+	n.incoming = sequence
+	n.incomingAck = int32(ack &^ (1 << 31))
+	n.incomingReliableAck = reliableAck
+	if seq>>31 == 1 {
+		n.incomingReliable = !n.incomingReliable
+	}
+	return data[msg.Index:], true
+}
@@ -0,0 +1,94 @@
+package playback
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/packetflinger/libq2/message"
+)
+
+// a client packet header
+func clientHeader(seq int32, reliable bool, ack int32, reliableAck bool) []byte {
+	msg := message.Buffer{}
+	msg.WriteLong(int(seq))
+	if reliable {
+		msg.Data[msg.Index-1] |= 0x80
+	}
+	msg.WriteLong(int(ack))
+	if reliableAck {
+		msg.Data[msg.Index-1] |= 0x80
+	}
+	msg.WriteShort(1234) // qport
+	return msg.Data
+}
+
+func TestNetchanReliable(t *testing.T) {
+	n := netchan{}
+	n.queue([]byte("first"))
+	n.queue([]byte("second"))
+
+	// both fit in one reliable packet
+	p1 := n.transmit([]byte("frame1"))
+	if p1[3]&0x80 == 0 {
+		t.Fatal("first packet should be reliable")
+	}
+	if !bytes.Equal(p1[8:], []byte("firstsecondframe1")) {
+		t.Errorf("first packet body = %q", p1[8:])
+	}
+	n.queue([]byte("third"))
+
+	// nothing heard back yet, the reliable is still in flight so the third
+	// message has to wait
+	p2 := n.transmit(nil)
+	if len(p2) != 8 {
+		t.Errorf("second packet body = %q, want nothing", p2[8:])
+	}
+
+	// packet 1 was lost, the client acks 2 without flipping the reliable bit
+	if _, ok := n.process(clientHeader(1, false, 2, false)); !ok {
+		t.Fatal("process() dropped a new packet")
+	}
+	p3 := n.transmit(nil)
+	if p3[3]&0x80 == 0 || !bytes.Equal(p3[8:], []byte("firstsecond")) {
+		t.Errorf("resent packet body = %q, want the reliable again", p3[8:])
+	}
+
+	// acked, the next reliable goes out
+	if _, ok := n.process(clientHeader(2, false, 3, true)); !ok {
+		t.Fatal("process() dropped a new packet")
+	}
+	p4 := n.transmit(nil)
+	if p4[3]&0x80 == 0 || !bytes.Equal(p4[8:], []byte("third")) {
+		t.Errorf("fourth packet = %q, want reliable \"third\"", p4[8:])
+	}
+
+	// old packets are ignored
+	if _, ok := n.process(clientHeader(2, false, 3, true)); ok {
+		t.Error("process() accepted a duplicate packet")
+	}
+}
+
+func TestNetchanIncomingReliable(t *testing.T) {
+	n := netchan{}
+	body, ok := n.process(append(clientHeader(1, true, 0, false), message.CLCNop))
+	if !ok || !bytes.Equal(body, []byte{message.CLCNop}) {
+		t.Fatalf("process() = %v, %v", body, ok)
+	}
+	p := n.transmit(nil)
+	if p[7]&0x80 == 0 {
+		t.Error("reliable from the client wasn't acked")
+	}
+}
+
+func TestNetchanOverflow(t *testing.T) {
+	n := netchan{}
+	big := make([]byte, MaxPendingSize/2+1)
+	n.queue(big)
+	if n.overflowed {
+		t.Fatal("overflowed too early")
+	}
+	n.queue(big)
+	if !n.overflowed {
+		t.Error("queue() didn't overflow")
+	}
+}
@@ -0,0 +1,551 @@
+// Package playback serves a stored demo to regular Quake 2 clients over UDP
+// as if it were a live server. Connect with any protocol 34 client and watch.
+//
+// Each client controls its own playback with commands sent to the server
+// (prefix them with "cmd" in the console if the client has its own command
+// with the same name):
+//
+//	pause         - pause or resume
+//	play          - resume
+//	speed <x>     - playback speed, 1 is normal, 0.5 is half speed
+//	seek <time>   - jump to a time, "90" or "1:30", "+10"/"-10" are relative
+//	playback      - show the current position
+//
+// Clients see the demo from the point of view of whoever recorded it (or the
+// followed player for multi-view demos).
+package playback
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/player"
+	"github.com/packetflinger/libq2/util"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+const (
+	DefaultMaxClients = 8
+	DefaultTimeout    = 30 * time.Second
+	DefaultHostname   = "demo playback"
+	ProtocolVersion   = 34
+	TickRate          = 10               // frames sent per second, protocol 34 is 10hz
+	MaxChallenges     = 1024             // outstanding challenges, the oldest is replaced
+	ChallengeTimeout  = 10 * time.Second // unused challenges expire
+)
+
+// A Server plays a demo to any clients that connect
+type Server struct {
+	Address    string        // ip:port to listen on
+	Hostname   string        // shown in server browsers
+	MaxClients int           // connection limit
+	Timeout    time.Duration // drop clients that go quiet this long
+	Verbose    bool          // be extra mouthy
+
+	demo       *pb.DM2Demo
+	frames     []int32 // demo frame numbers in order
+	fps        int     // of the demo
+	conn       net.PacketConn
+	lock       sync.Mutex // guards everything below
+	clients    map[string]*Client
+	challenges map[string]challenge
+	spawnCount int32
+	closed     bool
+}
+
+// A challenge sent to an address that hasn't connected yet
+type challenge struct {
+	value int32
+	time  time.Time
+}
+
+// Setup a server to play a regular demo
+func NewServer(addr string, dm2 *pb.DM2Demo) *Server {
+	fps := int(dm2.GetFrameRate())
+	if fps <= 0 {
+		fps = demo.DefaultFrameRate
+	}
+	return &Server{
+		Address:    addr,
+		Hostname:   DefaultHostname,
+		MaxClients: DefaultMaxClients,
+		Timeout:    DefaultTimeout,
+		demo:       dm2,
+		frames:     util.SortedKeys(dm2.GetFrames()),
+		fps:        fps,
+		clients:    make(map[string]*Client),
+		challenges: make(map[string]challenge),
+		spawnCount: rand.Int31(),
+	}
+}
+
+// Setup a server to play a multi-view demo from the point of view of one
+// player. A negative client follows the first player.
+func NewMVD2Server(addr string, mvd *pb.MvdDemo, client int32) (*Server, error) {
+	dm2, err := demo.MVD2ToDM2(mvd, client)
+	if err != nil {
+		return nil, err
+	}
+	return NewServer(addr, dm2), nil
+}
+
+// Open the socket. This is separate from Serve() so the actual address is
+// known before any clients connect (when using port 0).
+func (s *Server) Listen() error {
+	conn, err := net.ListenPacket("udp", s.Address)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// The address actually being listened on
+func (s *Server) Addr() net.Addr {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.LocalAddr()
+}
+
+// The clients currently connected
+func (s *Server) Clients() []*Client {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var out []*Client
+	for _, c := range s.clients {
+		out = append(out, c)
+	}
+	return out
+}
+
+// Play the demo to clients until the context is cancelled.
+func (s *Server) Serve(ctx context.Context) error {
+	if len(s.frames) == 0 {
+		return fmt.Errorf("demo has no frames")
+	}
+	if s.conn == nil {
+		if err := s.Listen(); err != nil {
+			return err
+		}
+	}
+	go func() {
+		<-ctx.Done()
+		s.Close()
+	}()
+	go s.run(ctx)
+	if s.Verbose {
+		log.Println("playing demo on", s.conn.LocalAddr())
+	}
+	buf := make([]byte, message.MaxMessageLength*2)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		s.lock.Lock()
+		s.handlePacket(addr, data)
+		s.lock.Unlock()
+	}
+}
+
+// Disconnect everyone and stop listening.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for _, c := range s.clients {
+		s.drop(c, "server shutting down")
+	}
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// Send frames to everyone at the tick rate
+func (s *Server) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second / TickRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.lock.Lock()
+			if s.closed {
+				s.lock.Unlock()
+				return
+			}
+			for _, c := range s.clients {
+				if time.Since(c.lastMessage) > s.Timeout {
+					s.drop(c, "timed out")
+					continue
+				}
+				s.tick(c)
+			}
+			s.lock.Unlock()
+		}
+	}
+}
+
+func (s *Server) handlePacket(addr net.Addr, data []byte) {
+	if len(data) >= 4 && data[0] == 0xff && data[1] == 0xff && data[2] == 0xff && data[3] == 0xff {
+		msg := message.NewBuffer(data)
+		msg.ReadLong()
+		s.connectionless(addr, msg.ReadString())
+		return
+	}
+	c, ok := s.clients[addr.String()]
+	if !ok {
+		return
+	}
+	body, ok := c.netchan.process(data)
+	if !ok {
+		return
+	}
+	c.lastMessage = time.Now()
+	msg := message.NewBuffer(body)
+	for msg.Index < msg.Length {
+		switch msg.ReadByte() {
+		case message.CLCNop:
+		case message.CLCMove:
+			msg.ReadByte() // checksum
+			c.lastFrame = int32(msg.ReadLong())
+			msg.Index = msg.Length // the moves themselves don't matter and are last
+		case message.CLCUserinfo:
+			if ui, err := player.Unmarshal(msg.ReadString()); err == nil {
+				c.Userinfo = ui
+			}
+		case message.CLCStringCommand:
+			s.command(c, msg.ReadString())
+			if _, ok := s.clients[addr.String()]; !ok {
+				return // disconnected
+			}
+		default:
+			msg.Index = msg.Length // can't skip unknown messages
+		}
+	}
+	// keep the gamestate moving as fast as the client acks it
+	if !c.Spawned && len(c.netchan.reliable) == 0 && len(c.netchan.pending) > 0 {
+		s.send(c, c.netchan.transmit(nil))
+	}
+}
+
+// Out of band messages: getting a challenge, connecting and status queries
+func (s *Server) connectionless(addr net.Addr, line string) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return
+	}
+	switch args[0] {
+	case "ping":
+		s.sendOOB(addr, "ack")
+	case "info":
+		s.sendOOB(addr, fmt.Sprintf("info\n%16s %8s %2d/%2d\n", s.Hostname, s.mapName(), len(s.clients), s.MaxClients))
+	case "status":
+		info := fmt.Sprintf("\\hostname\\%s\\mapname\\%s\\maxclients\\%d", s.Hostname, s.mapName(), s.MaxClients)
+		var players strings.Builder
+		for _, c := range s.clients {
+			fmt.Fprintf(&players, "0 0 %q\n", c.Name())
+		}
+		s.sendOOB(addr, fmt.Sprintf("print\n%s\n%s", info, players.String()))
+	case "getchallenge":
+		ch := s.newChallenge(addr.String())
+		s.sendOOB(addr, fmt.Sprintf("challenge %d p=%d", ch, ProtocolVersion))
+	case "connect":
+		s.connect(addr, line)
+	}
+}
+
+// Make a challenge for an address. Anyone can ask for one with a spoofed
+// address, so when there are too many the expired ones are removed and then
+// the oldest one is replaced, like the game server does.
+func (s *Server) newChallenge(addr string) int32 {
+	if _, ok := s.challenges[addr]; !ok && len(s.challenges) >= MaxChallenges {
+		oldest := ""
+		for a, ch := range s.challenges {
+			if time.Since(ch.time) > ChallengeTimeout {
+				delete(s.challenges, a)
+				continue
+			}
+			if oldest == "" || ch.time.Before(s.challenges[oldest].time) {
+				oldest = a
+			}
+		}
+		if len(s.challenges) >= MaxChallenges {
+			delete(s.challenges, oldest)
+		}
+	}
+	ch := challenge{value: rand.Int31(), time: time.Now()}
+	s.challenges[addr] = ch
+	return ch.value
+}
+
+// Whether a challenge is the one sent to an address and hasn't expired. A
+// challenge can only be used once.
+func (s *Server) checkChallenge(addr string, value int32) bool {
+	ch, ok := s.challenges[addr]
+	if !ok || ch.value != value {
+		return false
+	}
+	delete(s.challenges, addr)
+	return time.Since(ch.time) <= ChallengeTimeout
+}
+
+// connect <protocol> <qport> <challenge> "<userinfo>"
+func (s *Server) connect(addr net.Addr, line string) {
+	args := strings.SplitN(line, " ", 5)
+	if len(args) < 5 {
+		s.sendOOB(addr, "print\nInvalid connect.\n")
+		return
+	}
+	if protocol, _ := strconv.Atoi(args[1]); protocol != ProtocolVersion {
+		s.sendOOB(addr, fmt.Sprintf("print\nServer is protocol %d.\n", ProtocolVersion))
+		return
+	}
+	ch, _ := strconv.Atoi(args[3])
+	if !s.checkChallenge(addr.String(), int32(ch)) {
+		s.sendOOB(addr, "print\nBad challenge.\n")
+		return
+	}
+	if _, ok := s.clients[addr.String()]; !ok && len(s.clients) >= s.MaxClients {
+		s.sendOOB(addr, "print\nServer is full.\n")
+		return
+	}
+	ui, err := player.Unmarshal(strings.Trim(args[4], "\""))
+	if err != nil {
+		ui = player.NewUserinfo()
+	}
+	c := newClient(addr, ui)
+	s.clients[addr.String()] = c
+	s.sendOOB(addr, "client_connect")
+	if s.Verbose {
+		log.Printf("%s connected from %s\n", c.Name(), addr)
+	}
+}
+
+// Commands from a connected client
+func (s *Server) command(c *Client, line string) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return
+	}
+	switch args[0] {
+	case "new":
+		s.sendGamestate(c)
+	case "begin":
+		if len(args) > 1 && args[1] != strconv.Itoa(int(s.spawnCount)) {
+			s.sendGamestate(c) // from a previous level, start over
+			return
+		}
+		c.Spawned = true
+		c.Printf("Playing demo, commands: pause, play, speed <x>, seek <time>, playback\n")
+	case "disconnect":
+		s.drop(c, "")
+	case "pause":
+		c.Paused = !c.Paused
+		s.status(c)
+	case "play":
+		c.Paused = false
+		s.status(c)
+	case "speed":
+		if len(args) < 2 {
+			c.Printf("usage: speed <multiplier>\n")
+			return
+		}
+		v, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			c.Printf("invalid speed %q\n", args[1])
+			return
+		}
+		c.Speed = min(max(v, MinSpeed), MaxSpeed)
+		s.status(c)
+	case "seek":
+		if len(args) < 2 {
+			c.Printf("usage: seek <[+-]time>\n")
+			return
+		}
+		msecs, relative, err := ParseSeekTime(args[1])
+		if err != nil {
+			c.Printf("%v\n", err)
+			return
+		}
+		if relative {
+			msecs += s.frameTime(c.Position) - s.frameTime(0)
+		}
+		s.Seek(c, msecs)
+		s.status(c)
+	case "playback":
+		s.status(c)
+	}
+}
+
+// Tell the client where they are in the demo
+func (s *Server) status(c *Client) {
+	state := "playing"
+	if c.Paused {
+		state = "paused"
+	}
+	c.Printf("%s %s/%s (frame %d/%d) at %.2fx\n",
+		state,
+		formatTime(s.frameTime(c.Position)-s.frameTime(0)),
+		formatTime(s.frameTime(len(s.frames)-1)-s.frameTime(0)),
+		c.Position+1, len(s.frames), c.Speed)
+}
+
+// Jump to a time (msecs from the start of the demo). Configstrings that
+// changed between here and there are sent and the next frame is
+// uncompressed.
+func (s *Server) Seek(c *Client, msecs int32) {
+	target := s.frameTime(0) + max(msecs, 0)
+	pos := len(s.frames) - 1
+	for i := range s.frames {
+		if s.frameTime(i) >= target {
+			pos = i
+			break
+		}
+	}
+	c.Position = pos
+	c.clock = 0
+	c.syncConfigstrings(s.configstringsAt(pos))
+	c.resetDelta()
+}
+
+// All the configstrings as they were at a frame
+func (s *Server) configstringsAt(pos int) map[int32]string {
+	out := make(map[int32]string)
+	for k, cs := range s.demo.GetConfigstrings() {
+		out[k] = cs.GetData()
+	}
+	for _, num := range s.frames[:pos+1] {
+		for k, cs := range s.demo.GetFrames()[num].GetConfigstrings() {
+			out[k] = cs.GetData()
+		}
+	}
+	return out
+}
+
+// When a demo frame happened, in msecs
+func (s *Server) frameTime(pos int) int32 {
+	fr := s.demo.GetFrames()[s.frames[pos]]
+	if fr.GetServerTime() > 0 {
+		return fr.GetServerTime()
+	}
+	return fr.GetNumber() * 1000 / int32(s.fps)
+}
+
+// Everything a client needs before it can start: serverdata, configstrings,
+// baselines and then precache to load it all.
+func (s *Server) sendGamestate(c *Client) {
+	c.Spawned = false
+	c.Position = 0
+	c.clock = 0
+	c.configs = make(map[int32]string)
+	c.resetDelta()
+
+	sd := proto.Clone(s.demo.GetServerinfo()).(*pb.ServerInfo)
+	sd.Protocol = ProtocolVersion
+	sd.ServerCount = uint32(s.spawnCount)
+	sd.Demo = false // an attract loop won't accept input
+	c.netchan.queue(message.MarshalServerData(sd).Data)
+	for _, k := range util.SortedKeys(s.demo.GetConfigstrings()) {
+		cs := s.demo.GetConfigstrings()[k]
+		c.configs[k] = cs.GetData()
+		c.netchan.queue(message.MarshalConfigstring(cs).Data)
+	}
+	for _, k := range util.SortedKeys(s.demo.GetBaselines()) {
+		msg := message.Buffer{}
+		msg.WriteByte(message.SVCSpawnBaseline)
+		msg.Append(message.WriteDeltaEntity(nil, withNumber(s.demo.GetBaselines()[k], k)))
+		c.netchan.queue(msg.Data)
+	}
+	msg := message.Buffer{}
+	msg.WriteByte(message.SVCStuffText)
+	msg.Append(message.MarshalStuffText(&pb.StuffText{Data: fmt.Sprintf("precache %d\n", s.spawnCount)}))
+	c.netchan.queue(msg.Data)
+}
+
+// Move a client along in the demo and send them a frame
+func (s *Server) tick(c *Client) {
+	var unreliable []byte
+	if c.Spawned {
+		var passed []*pb.Frame
+		if !c.Paused {
+			c.clock += c.Speed * float64(s.fps) / TickRate
+			for c.clock >= 1 {
+				c.clock--
+				if c.Position+1 >= len(s.frames) {
+					c.Paused = true
+					c.Printf("End of demo\n")
+					break
+				}
+				c.Position++
+				passed = append(passed, s.demo.GetFrames()[s.frames[c.Position]])
+			}
+		}
+		for _, fr := range passed {
+			c.queueReliable(fr)
+		}
+		unreliable = c.frameMessage(s.demo.GetFrames()[s.frames[c.Position]], passed)
+	}
+	if c.netchan.overflowed {
+		s.drop(c, "overflowed")
+		return
+	}
+	if !c.Spawned && !c.netchan.reliablePending() && c.netchan.outgoing > 0 {
+		return // nothing to say while they load
+	}
+	s.send(c, c.netchan.transmit(unreliable))
+}
+
+// Disconnect a client, telling them why
+func (s *Server) drop(c *Client, reason string) {
+	if reason != "" {
+		c.Printf("%s\n", reason)
+	}
+	c.netchan.queue([]byte{message.SVCDisconnect})
+	s.send(c, c.netchan.transmit(nil))
+	delete(s.clients, c.Address.String())
+	if s.Verbose {
+		log.Printf("%s disconnected %s\n", c.Name(), reason)
+	}
+}
+
+func (s *Server) send(c *Client, data []byte) {
+	if _, err := s.conn.WriteTo(data, c.Address); err != nil && s.Verbose {
+		log.Println(err)
+	}
+}
+
+func (s *Server) sendOOB(addr net.Addr, text string) {
+	msg := message.NewConnectionlessPacket(text)
+	if _, err := s.conn.WriteTo(msg.Data, addr); err != nil && s.Verbose {
+		log.Println(err)
+	}
+}
+
+// The map's filename from its configstring
+func (s *Server) mapName() string {
+	cs := s.demo.GetConfigstrings()[message.CSMapname].GetData()
+	return strings.TrimSuffix(strings.TrimPrefix(cs, "maps/"), ".bsp")
+}
@@ -0,0 +1,319 @@
+package playback
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/packetflinger/libq2/proto"
+)
+
+// Just enough of a client to watch a demo
+type testClient struct {
+	t                *testing.T
+	conn             net.Conn
+	outgoing         int32
+	incoming         int32
+	incomingReliable bool
+	frames           map[int32]*pb.Frame
+	lastFrame        int32
+}
+
+func (tc *testClient) oob(text string) string {
+	tc.t.Helper()
+	if _, err := tc.conn.Write(message.NewConnectionlessPacket(text).Data); err != nil {
+		tc.t.Fatal(err)
+	}
+	buf := make([]byte, 2048)
+	tc.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := tc.conn.Read(buf)
+	if err != nil {
+		tc.t.Fatal(err)
+	}
+	msg := message.NewBuffer(buf[:n])
+	msg.ReadLong()
+	return msg.ReadString()
+}
+
+// send a command along with a move acking the last frame
+func (tc *testClient) send(cmd string) {
+	tc.t.Helper()
+	tc.outgoing++
+	msg := message.Buffer{}
+	msg.WriteLong(int(tc.outgoing))
+	msg.WriteLong(int(tc.incoming))
+	if tc.incomingReliable {
+		msg.Data[msg.Index-1] |= 0x80
+	}
+	msg.WriteShort(1234)
+	if cmd != "" {
+		msg.WriteByte(message.CLCStringCommand)
+		msg.WriteString(cmd)
+	}
+	msg.WriteByte(message.CLCMove)
+	msg.WriteByte(0)
+	msg.WriteLong(int(tc.lastFrame))
+	if _, err := tc.conn.Write(msg.Data); err != nil {
+		tc.t.Fatal(err)
+	}
+}
+
+// read and parse a packet from the server
+func (tc *testClient) read() *pb.Packet {
+	tc.t.Helper()
+	buf := make([]byte, 2048)
+	tc.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := tc.conn.Read(buf)
+	if err != nil {
+		tc.t.Fatal(err)
+	}
+	msg := message.NewBuffer(buf[:n])
+	seq := uint32(msg.ReadLong())
+	msg.ReadLong()
+	if int32(seq&^(1<<31)) <= tc.incoming {
+		return &pb.Packet{}
+	}
+	tc.incoming = int32(seq &^ (1 << 31))
+	if seq>>31 == 1 {
+		tc.incomingReliable = !tc.incomingReliable
+	}
+	packet, err := msg.ParsePacket(tc.frames)
+	if err != nil {
+		tc.t.Fatal(err)
+	}
+	for _, fr := range packet.GetFrames() {
+		tc.frames[fr.GetNumber()] = fr
+		tc.lastFrame = fr.GetNumber()
+	}
+	return packet
+}
+
+func TestServer(t *testing.T) {
+	content, err := os.ReadFile("../testdata/test.dm2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := demo.NewDM2Parser()
+	if err := parser.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+	dm2 := parser.GetTextProto()
+
+	srv := NewServer("127.0.0.1:0", dm2)
+	if err := srv.Listen(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Serve(ctx)
+
+	conn, err := net.Dial("udp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tc := &testClient{t: t, conn: conn, frames: make(map[int32]*pb.Frame), lastFrame: -1}
+
+	var challenge int
+	if _, err := fmt.Sscanf(tc.oob("getchallenge"), "challenge %d", &challenge); err != nil {
+		t.Fatal(err)
+	}
+	if got := tc.oob(fmt.Sprintf("connect 34 1234 %d \"\\name\\tester\"", challenge)); got != "client_connect" {
+		t.Fatalf("connect response = %q", got)
+	}
+
+	// load the gamestate
+	tc.send("new")
+	configs := 0
+	var precache string
+	for precache == "" {
+		packet := tc.read()
+		configs += len(packet.GetConfigStrings())
+		for _, st := range packet.GetStuffs() {
+			if strings.HasPrefix(st.GetData(), "precache") {
+				precache = st.GetData()
+			}
+		}
+		tc.send("")
+	}
+	if configs != len(dm2.GetConfigstrings()) {
+		t.Errorf("got %d configstrings, want %d", configs, len(dm2.GetConfigstrings()))
+	}
+
+	// watch a few frames, they should match the demo
+	tc.send("begin " + strings.Fields(precache)[1])
+	deltas := 0
+	for i := 0; i < 10; i++ {
+		packet := tc.read()
+		for _, fr := range packet.GetFrames() {
+			if fr.GetDelta() > 0 {
+				deltas++
+			}
+			if !matchesDemo(dm2, fr) {
+				t.Errorf("frame %d (delta %d) doesn't match any demo frame", fr.GetNumber(), fr.GetDelta())
+			}
+		}
+		tc.send("")
+	}
+	if deltas == 0 {
+		t.Error("no delta compressed frames")
+	}
+
+	tc.send("pause")
+	tc.read()
+	clients := srv.Clients()
+	if len(clients) != 1 {
+		t.Fatalf("server has %d clients, want 1", len(clients))
+	}
+	srv.lock.Lock()
+	paused := clients[0].Paused
+	srv.lock.Unlock()
+	if !paused {
+		t.Error("pause didn't pause")
+	}
+
+	tc.send("seek 0")
+	tc.read()
+	srv.lock.Lock()
+	pos := clients[0].Position
+	srv.lock.Unlock()
+	if pos != 0 {
+		t.Errorf("seek 0 went to position %d", pos)
+	}
+
+	tc.send("disconnect")
+	time.Sleep(100 * time.Millisecond)
+	if n := len(srv.Clients()); n != 0 {
+		t.Errorf("%d clients after disconnect", n)
+	}
+}
+
+// Is the frame the same as one from the demo (ignoring removed entities)?
+func matchesDemo(dm2 *pb.DM2Demo, fr *pb.Frame) bool {
+	for _, want := range dm2.GetFrames() {
+		if !proto.Equal(want.GetPlayerState().GetMovestate(), fr.GetPlayerState().GetMovestate()) {
+			continue
+		}
+		if sameEntities(want.GetEntities(), fr.GetEntities()) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameEntities(a, b map[int32]*pb.PackedEntity) bool {
+	live := func(m map[int32]*pb.PackedEntity) map[int32]*pb.PackedEntity {
+		out := make(map[int32]*pb.PackedEntity)
+		for k, e := range m {
+			if !e.GetRemove() {
+				e = proto.Clone(e).(*pb.PackedEntity)
+				e.Number = uint32(k)
+				out[k] = e
+			}
+		}
+		return out
+	}
+	la, lb := live(a), live(b)
+	if len(la) != len(lb) {
+		return false
+	}
+	for k, e := range la {
+		if !proto.Equal(e, lb[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseSeekTime(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     int32
+		relative bool
+		wantErr  bool
+	}{
+		{in: "90", want: 90000},
+		{in: "1:30", want: 90000},
+		{in: "+10", want: 10000, relative: true},
+		{in: "-2.5", want: -2500, relative: true},
+		{in: "1:02:03", want: 3723000},
+		{in: "abc", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, relative, err := ParseSeekTime(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseSeekTime(%q) error = %v, want error %v", tc.in, err, tc.wantErr)
+			}
+			if got != tc.want || relative != tc.relative {
+				t.Errorf("ParseSeekTime(%q) = %d, %v, want %d, %v", tc.in, got, relative, tc.want, tc.relative)
+			}
+		})
+	}
+}
+
+func TestChallenges(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(s *Server, ch int32) int32 // returns the value to connect with
+		want  bool
+	}{
+		{
+			name:  "valid",
+			setup: func(s *Server, ch int32) int32 { return ch },
+			want:  true,
+		},
+		{
+			name:  "wrong value",
+			setup: func(s *Server, ch int32) int32 { return ch + 1 },
+		},
+		{
+			name: "expired",
+			setup: func(s *Server, ch int32) int32 {
+				c := s.challenges["10.0.0.1:27910"]
+				c.time = time.Now().Add(-ChallengeTimeout - time.Second)
+				s.challenges["10.0.0.1:27910"] = c
+				return ch
+			},
+		},
+		{
+			name: "already used",
+			setup: func(s *Server, ch int32) int32 {
+				s.checkChallenge("10.0.0.1:27910", ch)
+				return ch
+			},
+		},
+		{
+			name: "replaced by a flood",
+			setup: func(s *Server, ch int32) int32 {
+				c := s.challenges["10.0.0.1:27910"]
+				c.time = time.Now().Add(-time.Second) // oldest, but not expired
+				s.challenges["10.0.0.1:27910"] = c
+				for i := range MaxChallenges {
+					s.newChallenge(fmt.Sprintf("10.1.%d.%d:27910", i/256, i%256))
+				}
+				return ch
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewServer("", &pb.DM2Demo{})
+			ch := tc.setup(s, s.newChallenge("10.0.0.1:27910"))
+			if got := s.checkChallenge("10.0.0.1:27910", ch); got != tc.want {
+				t.Errorf("checkChallenge() = %t, want %t", got, tc.want)
+			}
+			if len(s.challenges) > MaxChallenges {
+				t.Errorf("%d challenges, want at most %d", len(s.challenges), MaxChallenges)
+			}
+		})
+	}
+}
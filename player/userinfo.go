@@ -100,14 +100,9 @@ func Unmarshal(s string) (Userinfo, error) {
 		return nil, ErrUiMismatch
 	}
 	ui := NewUserinfo()
-	for i := 0; i < len(tokens); i++ {
+	for i := 0; i < len(tokens); i += 2 {
 		k, v := tokens[i], tokens[i+1]
-		if len(k) > UserinfoMaxKeySize {
-			i++
-			continue
-		}
-		if len(v) > UserinfoMaxValueSize {
-			i++
+		if len(k) > UserinfoMaxKeySize || len(v) > UserinfoMaxValueSize {
 			continue
 		}
 		ui[k] = v
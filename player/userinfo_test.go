@@ -101,3 +101,49 @@ func TestUserinfoMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUserinfoUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Userinfo
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			in:   "\\name\\claire\\skin\\female/athena",
+			want: map[string]string{"name": "claire", "skin": "female/athena"},
+		},
+		{
+			name: "oversized value skipped",
+			in:   "\\name\\claire\\junk\\lkajsflkjsflkjasflkasflkjsfkljwfieifjijeflijelfjeflkjelkjlekfjkjlklejlkj",
+			want: map[string]string{"name": "claire"},
+		},
+		{
+			name:    "blank",
+			in:      "",
+			wantErr: true,
+		},
+		{
+			name:    "key without value",
+			in:      "\\name\\claire\\skin",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Unmarshal(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unmarshal(%q) error = %v, want error %v", tc.in, err, tc.wantErr)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Unmarshal(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+			for k, v := range tc.want {
+				if got[k] != v {
+					t.Errorf("Unmarshal(%q)[%q] = %q, want %q", tc.in, k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,549 @@
+// compile with:
+// protoc --go_out=. --go_opt=paths=source_relative demo_event.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v4.22.2
+// source: demo_event.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A single server message from a demo. Demos are exported to text formats
+// (JSON, prototext) as a stream of these, one per line.
+//
+// Field numbers here are part of the export format, don't reuse them.
+type DemoEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Frame      int32 `protobuf:"varint,1,opt,name=frame,proto3" json:"frame,omitempty"`                             // server frame the msg belongs to
+	ServerTime int32 `protobuf:"varint,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // msecs since the start of the demo
+	Packet     int32 `protobuf:"varint,3,opt,name=packet,proto3" json:"packet,omitempty"`                           // which mvd packet it came from
+	// Types that are assignable to Event:
+	//	*DemoEvent_Serverdata
+	//	*DemoEvent_MvdServerdata
+	//	*DemoEvent_Configstring
+	//	*DemoEvent_Baseline
+	//	*DemoEvent_Dm2Frame
+	//	*DemoEvent_MvdFrame
+	//	*DemoEvent_Print
+	//	*DemoEvent_Centerprint
+	//	*DemoEvent_Stufftext
+	//	*DemoEvent_Sound
+	//	*DemoEvent_TempEntity
+	//	*DemoEvent_MuzzleFlash
+	//	*DemoEvent_MuzzleFlash2
+	//	*DemoEvent_Layout
+	//	*DemoEvent_Unicast
+	//	*DemoEvent_Multicast
+	//	*DemoEvent_Disconnect
+	//	*DemoEvent_Setting
+	Event isDemoEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *DemoEvent) Reset() {
+	*x = DemoEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_demo_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DemoEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoEvent) ProtoMessage() {}
+
+func (x *DemoEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoEvent.ProtoReflect.Descriptor instead.
+func (*DemoEvent) Descriptor() ([]byte, []int) {
+	return file_demo_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DemoEvent) GetFrame() int32 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+func (x *DemoEvent) GetServerTime() int32 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+func (x *DemoEvent) GetPacket() int32 {
+	if x != nil {
+		return x.Packet
+	}
+	return 0
+}
+
+func (m *DemoEvent) GetEvent() isDemoEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetServerdata() *ServerInfo {
+	if x, ok := x.GetEvent().(*DemoEvent_Serverdata); ok {
+		return x.Serverdata
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetMvdServerdata() *MvdServerData {
+	if x, ok := x.GetEvent().(*DemoEvent_MvdServerdata); ok {
+		return x.MvdServerdata
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetConfigstring() *ConfigString {
+	if x, ok := x.GetEvent().(*DemoEvent_Configstring); ok {
+		return x.Configstring
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetBaseline() *PackedEntity {
+	if x, ok := x.GetEvent().(*DemoEvent_Baseline); ok {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetDm2Frame() *Frame {
+	if x, ok := x.GetEvent().(*DemoEvent_Dm2Frame); ok {
+		return x.Dm2Frame
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetMvdFrame() *MvdFrame {
+	if x, ok := x.GetEvent().(*DemoEvent_MvdFrame); ok {
+		return x.MvdFrame
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetPrint() *Print {
+	if x, ok := x.GetEvent().(*DemoEvent_Print); ok {
+		return x.Print
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetCenterprint() *CenterPrint {
+	if x, ok := x.GetEvent().(*DemoEvent_Centerprint); ok {
+		return x.Centerprint
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetStufftext() *StuffText {
+	if x, ok := x.GetEvent().(*DemoEvent_Stufftext); ok {
+		return x.Stufftext
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetSound() *PackedSound {
+	if x, ok := x.GetEvent().(*DemoEvent_Sound); ok {
+		return x.Sound
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetTempEntity() *TemporaryEntity {
+	if x, ok := x.GetEvent().(*DemoEvent_TempEntity); ok {
+		return x.TempEntity
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetMuzzleFlash() *MuzzleFlash {
+	if x, ok := x.GetEvent().(*DemoEvent_MuzzleFlash); ok {
+		return x.MuzzleFlash
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetMuzzleFlash2() *MuzzleFlash {
+	if x, ok := x.GetEvent().(*DemoEvent_MuzzleFlash2); ok {
+		return x.MuzzleFlash2
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetLayout() *Layout {
+	if x, ok := x.GetEvent().(*DemoEvent_Layout); ok {
+		return x.Layout
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetUnicast() *MvdUnicast {
+	if x, ok := x.GetEvent().(*DemoEvent_Unicast); ok {
+		return x.Unicast
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetMulticast() *MvdMulticast {
+	if x, ok := x.GetEvent().(*DemoEvent_Multicast); ok {
+		return x.Multicast
+	}
+	return nil
+}
+
+func (x *DemoEvent) GetDisconnect() bool {
+	if x, ok := x.GetEvent().(*DemoEvent_Disconnect); ok {
+		return x.Disconnect
+	}
+	return false
+}
+
+func (x *DemoEvent) GetSetting() *Setting {
+	if x, ok := x.GetEvent().(*DemoEvent_Setting); ok {
+		return x.Setting
+	}
+	return nil
+}
+
+type isDemoEvent_Event interface {
+	isDemoEvent_Event()
+}
+
+type DemoEvent_Serverdata struct {
+	Serverdata *ServerInfo `protobuf:"bytes,4,opt,name=serverdata,proto3,oneof"` // dm2
+}
+
+type DemoEvent_MvdServerdata struct {
+	MvdServerdata *MvdServerData `protobuf:"bytes,5,opt,name=mvd_serverdata,json=mvdServerdata,proto3,oneof"` // mvd2
+}
+
+type DemoEvent_Configstring struct {
+	Configstring *ConfigString `protobuf:"bytes,6,opt,name=configstring,proto3,oneof"`
+}
+
+type DemoEvent_Baseline struct {
+	Baseline *PackedEntity `protobuf:"bytes,7,opt,name=baseline,proto3,oneof"` // dm2
+}
+
+type DemoEvent_Dm2Frame struct {
+	Dm2Frame *Frame `protobuf:"bytes,8,opt,name=dm2_frame,json=dm2Frame,proto3,oneof"` // playerstate and entities only
+}
+
+type DemoEvent_MvdFrame struct {
+	MvdFrame *MvdFrame `protobuf:"bytes,9,opt,name=mvd_frame,json=mvdFrame,proto3,oneof"`
+}
+
+type DemoEvent_Print struct {
+	Print *Print `protobuf:"bytes,10,opt,name=print,proto3,oneof"`
+}
+
+type DemoEvent_Centerprint struct {
+	Centerprint *CenterPrint `protobuf:"bytes,11,opt,name=centerprint,proto3,oneof"`
+}
+
+type DemoEvent_Stufftext struct {
+	Stufftext *StuffText `protobuf:"bytes,12,opt,name=stufftext,proto3,oneof"`
+}
+
+type DemoEvent_Sound struct {
+	Sound *PackedSound `protobuf:"bytes,13,opt,name=sound,proto3,oneof"`
+}
+
+type DemoEvent_TempEntity struct {
+	TempEntity *TemporaryEntity `protobuf:"bytes,14,opt,name=temp_entity,json=tempEntity,proto3,oneof"`
+}
+
+type DemoEvent_MuzzleFlash struct {
+	MuzzleFlash *MuzzleFlash `protobuf:"bytes,15,opt,name=muzzle_flash,json=muzzleFlash,proto3,oneof"`
+}
+
+type DemoEvent_MuzzleFlash2 struct {
+	MuzzleFlash2 *MuzzleFlash `protobuf:"bytes,16,opt,name=muzzle_flash2,json=muzzleFlash2,proto3,oneof"`
+}
+
+type DemoEvent_Layout struct {
+	Layout *Layout `protobuf:"bytes,17,opt,name=layout,proto3,oneof"`
+}
+
+type DemoEvent_Unicast struct {
+	Unicast *MvdUnicast `protobuf:"bytes,18,opt,name=unicast,proto3,oneof"`
+}
+
+type DemoEvent_Multicast struct {
+	Multicast *MvdMulticast `protobuf:"bytes,19,opt,name=multicast,proto3,oneof"`
+}
+
+type DemoEvent_Disconnect struct {
+	Disconnect bool `protobuf:"varint,20,opt,name=disconnect,proto3,oneof"` // mvd2, server dropped the stream
+}
+
+type DemoEvent_Setting struct {
+	Setting *Setting `protobuf:"bytes,21,opt,name=setting,proto3,oneof"` // dm2
+}
+
+func (*DemoEvent_Serverdata) isDemoEvent_Event() {}
+
+func (*DemoEvent_MvdServerdata) isDemoEvent_Event() {}
+
+func (*DemoEvent_Configstring) isDemoEvent_Event() {}
+
+func (*DemoEvent_Baseline) isDemoEvent_Event() {}
+
+func (*DemoEvent_Dm2Frame) isDemoEvent_Event() {}
+
+func (*DemoEvent_MvdFrame) isDemoEvent_Event() {}
+
+func (*DemoEvent_Print) isDemoEvent_Event() {}
+
+func (*DemoEvent_Centerprint) isDemoEvent_Event() {}
+
+func (*DemoEvent_Stufftext) isDemoEvent_Event() {}
+
+func (*DemoEvent_Sound) isDemoEvent_Event() {}
+
+func (*DemoEvent_TempEntity) isDemoEvent_Event() {}
+
+func (*DemoEvent_MuzzleFlash) isDemoEvent_Event() {}
+
+func (*DemoEvent_MuzzleFlash2) isDemoEvent_Event() {}
+
+func (*DemoEvent_Layout) isDemoEvent_Event() {}
+
+func (*DemoEvent_Unicast) isDemoEvent_Event() {}
+
+func (*DemoEvent_Multicast) isDemoEvent_Event() {}
+
+func (*DemoEvent_Disconnect) isDemoEvent_Event() {}
+
+func (*DemoEvent_Setting) isDemoEvent_Event() {}
+
+var File_demo_event_proto protoreflect.FileDescriptor
+
+var file_demo_event_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x64, 0x65, 0x6d, 0x6f, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x14, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x64, 0x65, 0x6d, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x07, 0x0a, 0x09, 0x44, 0x65, 0x6d, 0x6f, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3d, 0x0a, 0x0e, 0x6d, 0x76, 0x64, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x76, 0x64, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x12, 0x31, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a, 0x09, 0x64, 0x6d, 0x32, 0x5f, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x08, 0x64, 0x6d, 0x32, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x12, 0x2e, 0x0a, 0x09, 0x6d, 0x76, 0x64, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x76, 0x64, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x0b, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12,
+	0x30, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x66, 0x66, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66,
+	0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x75, 0x66, 0x66, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53,
+	0x6f, 0x75, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x05, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x39, 0x0a,
+	0x0b, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6f,
+	0x72, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x65,
+	0x6d, 0x70, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x6d, 0x75, 0x7a, 0x7a,
+	0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73,
+	0x68, 0x12, 0x39, 0x0a, 0x0d, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73,
+	0x68, 0x32, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x48, 0x00, 0x52, 0x0c,
+	0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x32, 0x12, 0x27, 0x0a, 0x06,
+	0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x48, 0x00, 0x52, 0x06, 0x6c,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
+	0x76, 0x64, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x75, 0x6e, 0x69,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73,
+	0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4d, 0x76, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0a, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62,
+	0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_demo_event_proto_rawDescOnce sync.Once
+	file_demo_event_proto_rawDescData = file_demo_event_proto_rawDesc
+)
+
+func file_demo_event_proto_rawDescGZIP() []byte {
+	file_demo_event_proto_rawDescOnce.Do(func() {
+		file_demo_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_demo_event_proto_rawDescData)
+	})
+	return file_demo_event_proto_rawDescData
+}
+
+var file_demo_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_demo_event_proto_goTypes = []interface{}{
+	(*DemoEvent)(nil),       // 0: proto.DemoEvent
+	(*ServerInfo)(nil),      // 1: proto.ServerInfo
+	(*MvdServerData)(nil),   // 2: proto.MvdServerData
+	(*ConfigString)(nil),    // 3: proto.ConfigString
+	(*PackedEntity)(nil),    // 4: proto.PackedEntity
+	(*Frame)(nil),           // 5: proto.Frame
+	(*MvdFrame)(nil),        // 6: proto.MvdFrame
+	(*Print)(nil),           // 7: proto.Print
+	(*CenterPrint)(nil),     // 8: proto.CenterPrint
+	(*StuffText)(nil),       // 9: proto.StuffText
+	(*PackedSound)(nil),     // 10: proto.PackedSound
+	(*TemporaryEntity)(nil), // 11: proto.TemporaryEntity
+	(*MuzzleFlash)(nil),     // 12: proto.MuzzleFlash
+	(*Layout)(nil),          // 13: proto.Layout
+	(*MvdUnicast)(nil),      // 14: proto.MvdUnicast
+	(*MvdMulticast)(nil),    // 15: proto.MvdMulticast
+	(*Setting)(nil),         // 16: proto.Setting
+}
+var file_demo_event_proto_depIdxs = []int32{
+	1,  // 0: proto.DemoEvent.serverdata:type_name -> proto.ServerInfo
+	2,  // 1: proto.DemoEvent.mvd_serverdata:type_name -> proto.MvdServerData
+	3,  // 2: proto.DemoEvent.configstring:type_name -> proto.ConfigString
+	4,  // 3: proto.DemoEvent.baseline:type_name -> proto.PackedEntity
+	5,  // 4: proto.DemoEvent.dm2_frame:type_name -> proto.Frame
+	6,  // 5: proto.DemoEvent.mvd_frame:type_name -> proto.MvdFrame
+	7,  // 6: proto.DemoEvent.print:type_name -> proto.Print
+	8,  // 7: proto.DemoEvent.centerprint:type_name -> proto.CenterPrint
+	9,  // 8: proto.DemoEvent.stufftext:type_name -> proto.StuffText
+	10, // 9: proto.DemoEvent.sound:type_name -> proto.PackedSound
+	11, // 10: proto.DemoEvent.temp_entity:type_name -> proto.TemporaryEntity
+	12, // 11: proto.DemoEvent.muzzle_flash:type_name -> proto.MuzzleFlash
+	12, // 12: proto.DemoEvent.muzzle_flash2:type_name -> proto.MuzzleFlash
+	13, // 13: proto.DemoEvent.layout:type_name -> proto.Layout
+	14, // 14: proto.DemoEvent.unicast:type_name -> proto.MvdUnicast
+	15, // 15: proto.DemoEvent.multicast:type_name -> proto.MvdMulticast
+	16, // 16: proto.DemoEvent.setting:type_name -> proto.Setting
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_demo_event_proto_init() }
+func file_demo_event_proto_init() {
+	if File_demo_event_proto != nil {
+		return
+	}
+	file_server_message_proto_init()
+	file_multiview_demo_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_demo_event_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DemoEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_demo_event_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*DemoEvent_Serverdata)(nil),
+		(*DemoEvent_MvdServerdata)(nil),
+		(*DemoEvent_Configstring)(nil),
+		(*DemoEvent_Baseline)(nil),
+		(*DemoEvent_Dm2Frame)(nil),
+		(*DemoEvent_MvdFrame)(nil),
+		(*DemoEvent_Print)(nil),
+		(*DemoEvent_Centerprint)(nil),
+		(*DemoEvent_Stufftext)(nil),
+		(*DemoEvent_Sound)(nil),
+		(*DemoEvent_TempEntity)(nil),
+		(*DemoEvent_MuzzleFlash)(nil),
+		(*DemoEvent_MuzzleFlash2)(nil),
+		(*DemoEvent_Layout)(nil),
+		(*DemoEvent_Unicast)(nil),
+		(*DemoEvent_Multicast)(nil),
+		(*DemoEvent_Disconnect)(nil),
+		(*DemoEvent_Setting)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_demo_event_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_demo_event_proto_goTypes,
+		DependencyIndexes: file_demo_event_proto_depIdxs,
+		MessageInfos:      file_demo_event_proto_msgTypes,
+	}.Build()
+	File_demo_event_proto = out.File
+	file_demo_event_proto_rawDesc = nil
+	file_demo_event_proto_goTypes = nil
+	file_demo_event_proto_depIdxs = nil
+}
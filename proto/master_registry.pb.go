@@ -0,0 +1,422 @@
+// compile with:
+// protoc --go_out=. --go_opt=paths=source_relative master_registry.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v4.22.2
+// source: master_registry.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A snapshot of every server a master knows about, saved periodically so a
+// restarted master doesn't start with an empty list.
+type MasterRegistry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Saved   int64               `protobuf:"varint,1,opt,name=saved,proto3" json:"saved,omitempty"` // unix time
+	Servers []*RegisteredServer `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *MasterRegistry) Reset() {
+	*x = MasterRegistry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_master_registry_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MasterRegistry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MasterRegistry) ProtoMessage() {}
+
+func (x *MasterRegistry) ProtoReflect() protoreflect.Message {
+	mi := &file_master_registry_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MasterRegistry.ProtoReflect.Descriptor instead.
+func (*MasterRegistry) Descriptor() ([]byte, []int) {
+	return file_master_registry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MasterRegistry) GetSaved() int64 {
+	if x != nil {
+		return x.Saved
+	}
+	return 0
+}
+
+func (x *MasterRegistry) GetServers() []*RegisteredServer {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type RegisteredServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string                `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // ip:port
+	Ip           []byte                `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port         int32                 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Country      string                `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Hostname     string                `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Map          string                `protobuf:"bytes,6,opt,name=map,proto3" json:"map,omitempty"`
+	GameDir      string                `protobuf:"bytes,7,opt,name=game_dir,json=gameDir,proto3" json:"game_dir,omitempty"`
+	MaxPlayers   int32                 `protobuf:"varint,8,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	Passworded   bool                  `protobuf:"varint,9,opt,name=passworded,proto3" json:"passworded,omitempty"`
+	Software     string                `protobuf:"bytes,10,opt,name=software,proto3" json:"software,omitempty"`
+	Active       bool                  `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	FirstContact int64                 `protobuf:"varint,12,opt,name=first_contact,json=firstContact,proto3" json:"first_contact,omitempty"` // unix time
+	LastContact  int64                 `protobuf:"varint,13,opt,name=last_contact,json=lastContact,proto3" json:"last_contact,omitempty"`    // unix time
+	Heartbeats   int32                 `protobuf:"varint,14,opt,name=heartbeats,proto3" json:"heartbeats,omitempty"`
+	MissedBeats  int32                 `protobuf:"varint,15,opt,name=missed_beats,json=missedBeats,proto3" json:"missed_beats,omitempty"`
+	Info         map[string]string     `protobuf:"bytes,16,rep,name=info,proto3" json:"info,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Players      []*MasterServerPlayer `protobuf:"bytes,17,rep,name=players,proto3" json:"players,omitempty"`
+	Source       string                `protobuf:"bytes,18,opt,name=source,proto3" json:"source,omitempty"`          // master it was imported from
+	Synced       int64                 `protobuf:"varint,19,opt,name=synced,proto3" json:"synced,omitempty"`         // unix time, last seen on the source's list
+	Unverified   bool                  `protobuf:"varint,20,opt,name=unverified,proto3" json:"unverified,omitempty"` // imported but hasn't answered a ping yet
+}
+
+func (x *RegisteredServer) Reset() {
+	*x = RegisteredServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_master_registry_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisteredServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisteredServer) ProtoMessage() {}
+
+func (x *RegisteredServer) ProtoReflect() protoreflect.Message {
+	mi := &file_master_registry_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisteredServer.ProtoReflect.Descriptor instead.
+func (*RegisteredServer) Descriptor() ([]byte, []int) {
+	return file_master_registry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisteredServer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetIp() []byte {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *RegisteredServer) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetGameDir() string {
+	if x != nil {
+		return x.GameDir
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetPassworded() bool {
+	if x != nil {
+		return x.Passworded
+	}
+	return false
+}
+
+func (x *RegisteredServer) GetSoftware() string {
+	if x != nil {
+		return x.Software
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *RegisteredServer) GetFirstContact() int64 {
+	if x != nil {
+		return x.FirstContact
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetLastContact() int64 {
+	if x != nil {
+		return x.LastContact
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetHeartbeats() int32 {
+	if x != nil {
+		return x.Heartbeats
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetMissedBeats() int32 {
+	if x != nil {
+		return x.MissedBeats
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetInfo() map[string]string {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *RegisteredServer) GetPlayers() []*MasterServerPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *RegisteredServer) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RegisteredServer) GetSynced() int64 {
+	if x != nil {
+		return x.Synced
+	}
+	return 0
+}
+
+func (x *RegisteredServer) GetUnverified() bool {
+	if x != nil {
+		return x.Unverified
+	}
+	return false
+}
+
+var File_master_registry_proto protoreflect.FileDescriptor
+
+var file_master_registry_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x59, 0x0a, 0x0e,
+	0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x61, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73,
+	0x61, 0x76, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0xa8, 0x05, 0x0a, 0x10, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d,
+	0x61, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69,
+	0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42, 0x65, 0x61, 0x74, 0x73, 0x12, 0x35, 0x0a,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x12, 0x33, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x75,
+	0x6e, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x1a, 0x37, 0x0a, 0x09, 0x49, 0x6e, 0x66,
+	0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c,
+	0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_master_registry_proto_rawDescOnce sync.Once
+	file_master_registry_proto_rawDescData = file_master_registry_proto_rawDesc
+)
+
+func file_master_registry_proto_rawDescGZIP() []byte {
+	file_master_registry_proto_rawDescOnce.Do(func() {
+		file_master_registry_proto_rawDescData = protoimpl.X.CompressGZIP(file_master_registry_proto_rawDescData)
+	})
+	return file_master_registry_proto_rawDescData
+}
+
+var file_master_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_master_registry_proto_goTypes = []interface{}{
+	(*MasterRegistry)(nil),     // 0: proto.MasterRegistry
+	(*RegisteredServer)(nil),   // 1: proto.RegisteredServer
+	nil,                        // 2: proto.RegisteredServer.InfoEntry
+	(*MasterServerPlayer)(nil), // 3: proto.MasterServerPlayer
+}
+var file_master_registry_proto_depIdxs = []int32{
+	1, // 0: proto.MasterRegistry.servers:type_name -> proto.RegisteredServer
+	2, // 1: proto.RegisteredServer.info:type_name -> proto.RegisteredServer.InfoEntry
+	3, // 2: proto.RegisteredServer.players:type_name -> proto.MasterServerPlayer
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_master_registry_proto_init() }
+func file_master_registry_proto_init() {
+	if File_master_registry_proto != nil {
+		return
+	}
+	file_master_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_master_registry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MasterRegistry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_master_registry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisteredServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_master_registry_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_master_registry_proto_goTypes,
+		DependencyIndexes: file_master_registry_proto_depIdxs,
+		MessageInfos:      file_master_registry_proto_msgTypes,
+	}.Build()
+	File_master_registry_proto = out.File
+	file_master_registry_proto_rawDesc = nil
+	file_master_registry_proto_goTypes = nil
+	file_master_registry_proto_depIdxs = nil
+}
@@ -572,10 +572,11 @@ type MvdFrame struct {
 	unknownFields protoimpl.UnknownFields
 
 	//int32 portal_bits = 1;  // remove later this is len(portal_data)
-	PortalData []byte                  `protobuf:"bytes,2,opt,name=portal_data,json=portalData,proto3" json:"portal_data,omitempty"`
-	Players    map[int32]*PackedPlayer `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`   // players introduced this frame
-	Entities   map[int32]*PackedEntity `protobuf:"bytes,4,rep,name=entities,proto3" json:"entities,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // ents seen this frame
-	Sounds     []*PackedSound          `protobuf:"bytes,5,rep,name=sounds,proto3" json:"sounds,omitempty"`
+	PortalData     []byte                  `protobuf:"bytes,2,opt,name=portal_data,json=portalData,proto3" json:"portal_data,omitempty"`
+	Players        map[int32]*PackedPlayer `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`   // players introduced this frame
+	Entities       map[int32]*PackedEntity `protobuf:"bytes,4,rep,name=entities,proto3" json:"entities,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // ents seen this frame
+	Sounds         []*PackedSound          `protobuf:"bytes,5,rep,name=sounds,proto3" json:"sounds,omitempty"`
+	RemovedPlayers []int32                 `protobuf:"varint,6,rep,packed,name=removed_players,json=removedPlayers,proto3" json:"removed_players,omitempty"` // players removed this frame
 }
 
 func (x *MvdFrame) Reset() {
@@ -638,14 +639,35 @@ func (x *MvdFrame) GetSounds() []*PackedSound {
 	return nil
 }
 
+func (x *MvdFrame) GetRemovedPlayers() []int32 {
+	if x != nil {
+		return x.RemovedPlayers
+	}
+	return nil
+}
+
 type MvdMulticast struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type int32  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"` // byte
-	Leaf int32  `protobuf:"varint,2,opt,name=leaf,proto3" json:"leaf,omitempty"` // word
-	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Type int32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"` // byte
+	Leaf int32 `protobuf:"varint,2,opt,name=leaf,proto3" json:"leaf,omitempty"` // word
+	// 3 was the raw payload, it's decoded into the fields below now
+	TempEnts       []*TemporaryEntity `protobuf:"bytes,4,rep,name=temp_ents,json=tempEnts,proto3" json:"temp_ents,omitempty"`
+	MuzzleFlashes  []*MuzzleFlash     `protobuf:"bytes,5,rep,name=muzzle_flashes,json=muzzleFlashes,proto3" json:"muzzle_flashes,omitempty"`
+	MuzzleFlashes2 []*MuzzleFlash     `protobuf:"bytes,6,rep,name=muzzle_flashes2,json=muzzleFlashes2,proto3" json:"muzzle_flashes2,omitempty"`
+	Sounds         []*PackedSound     `protobuf:"bytes,7,rep,name=sounds,proto3" json:"sounds,omitempty"`
+	Prints         []*Print           `protobuf:"bytes,8,rep,name=prints,proto3" json:"prints,omitempty"`
+	Centerprints   []*CenterPrint     `protobuf:"bytes,9,rep,name=centerprints,proto3" json:"centerprints,omitempty"`
+	Layouts        []*Layout          `protobuf:"bytes,10,rep,name=layouts,proto3" json:"layouts,omitempty"`
+	Stuffs         []*StuffText       `protobuf:"bytes,11,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
+	Configstrings  []*ConfigString    `protobuf:"bytes,12,rep,name=configstrings,proto3" json:"configstrings,omitempty"`
+	Inventories    []*Inventory       `protobuf:"bytes,13,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	Disconnect     bool               `protobuf:"varint,14,opt,name=disconnect,proto3" json:"disconnect,omitempty"`
+	Reconnect      bool               `protobuf:"varint,15,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
+	Order          []int32            `protobuf:"varint,16,rep,packed,name=order,proto3" json:"order,omitempty"` // svc command of each message, as they were sent
+	Unknown        []byte             `protobuf:"bytes,17,opt,name=unknown,proto3" json:"unknown,omitempty"`     // the payload from the first message that couldn't be parsed
 }
 
 func (x *MvdMulticast) Reset() {
@@ -694,9 +716,100 @@ func (x *MvdMulticast) GetLeaf() int32 {
 	return 0
 }
 
-func (x *MvdMulticast) GetData() []byte {
+func (x *MvdMulticast) GetTempEnts() []*TemporaryEntity {
 	if x != nil {
-		return x.Data
+		return x.TempEnts
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetMuzzleFlashes() []*MuzzleFlash {
+	if x != nil {
+		return x.MuzzleFlashes
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetMuzzleFlashes2() []*MuzzleFlash {
+	if x != nil {
+		return x.MuzzleFlashes2
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetSounds() []*PackedSound {
+	if x != nil {
+		return x.Sounds
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetPrints() []*Print {
+	if x != nil {
+		return x.Prints
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetCenterprints() []*CenterPrint {
+	if x != nil {
+		return x.Centerprints
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetLayouts() []*Layout {
+	if x != nil {
+		return x.Layouts
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetStuffs() []*StuffText {
+	if x != nil {
+		return x.Stuffs
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetConfigstrings() []*ConfigString {
+	if x != nil {
+		return x.Configstrings
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetInventories() []*Inventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetDisconnect() bool {
+	if x != nil {
+		return x.Disconnect
+	}
+	return false
+}
+
+func (x *MvdMulticast) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *MvdMulticast) GetOrder() []int32 {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *MvdMulticast) GetUnknown() []byte {
+	if x != nil {
+		return x.Unknown
 	}
 	return nil
 }
@@ -706,12 +819,23 @@ type MvdUnicast struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientNumber  int32           `protobuf:"varint,1,opt,name=client_number,json=clientNumber,proto3" json:"client_number,omitempty"` // player index
-	Player        *MvdPlayer      `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
-	Layouts       []*Layout       `protobuf:"bytes,3,rep,name=layouts,proto3" json:"layouts,omitempty"`
-	Configstrings []*ConfigString `protobuf:"bytes,4,rep,name=configstrings,proto3" json:"configstrings,omitempty"`
-	Prints        []*Print        `protobuf:"bytes,5,rep,name=prints,proto3" json:"prints,omitempty"`
-	Stuffs        []*StuffText    `protobuf:"bytes,6,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
+	ClientNumber   int32              `protobuf:"varint,1,opt,name=client_number,json=clientNumber,proto3" json:"client_number,omitempty"` // player index
+	Player         *MvdPlayer         `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Layouts        []*Layout          `protobuf:"bytes,3,rep,name=layouts,proto3" json:"layouts,omitempty"`
+	Configstrings  []*ConfigString    `protobuf:"bytes,4,rep,name=configstrings,proto3" json:"configstrings,omitempty"`
+	Prints         []*Print           `protobuf:"bytes,5,rep,name=prints,proto3" json:"prints,omitempty"`
+	Stuffs         []*StuffText       `protobuf:"bytes,6,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
+	Reliable       bool               `protobuf:"varint,7,opt,name=reliable,proto3" json:"reliable,omitempty"`
+	Centerprints   []*CenterPrint     `protobuf:"bytes,8,rep,name=centerprints,proto3" json:"centerprints,omitempty"`
+	Sounds         []*PackedSound     `protobuf:"bytes,9,rep,name=sounds,proto3" json:"sounds,omitempty"`
+	TempEnts       []*TemporaryEntity `protobuf:"bytes,10,rep,name=temp_ents,json=tempEnts,proto3" json:"temp_ents,omitempty"`
+	MuzzleFlashes  []*MuzzleFlash     `protobuf:"bytes,11,rep,name=muzzle_flashes,json=muzzleFlashes,proto3" json:"muzzle_flashes,omitempty"`
+	MuzzleFlashes2 []*MuzzleFlash     `protobuf:"bytes,12,rep,name=muzzle_flashes2,json=muzzleFlashes2,proto3" json:"muzzle_flashes2,omitempty"`
+	Inventories    []*Inventory       `protobuf:"bytes,13,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	Disconnect     bool               `protobuf:"varint,14,opt,name=disconnect,proto3" json:"disconnect,omitempty"`
+	Reconnect      bool               `protobuf:"varint,15,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
+	Order          []int32            `protobuf:"varint,16,rep,packed,name=order,proto3" json:"order,omitempty"` // svc command of each message, as they were sent
+	Unknown        []byte             `protobuf:"bytes,17,opt,name=unknown,proto3" json:"unknown,omitempty"`     // the payload from the first message that couldn't be parsed
 }
 
 func (x *MvdUnicast) Reset() {
@@ -788,6 +912,83 @@ func (x *MvdUnicast) GetStuffs() []*StuffText {
 	return nil
 }
 
+func (x *MvdUnicast) GetReliable() bool {
+	if x != nil {
+		return x.Reliable
+	}
+	return false
+}
+
+func (x *MvdUnicast) GetCenterprints() []*CenterPrint {
+	if x != nil {
+		return x.Centerprints
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetSounds() []*PackedSound {
+	if x != nil {
+		return x.Sounds
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetTempEnts() []*TemporaryEntity {
+	if x != nil {
+		return x.TempEnts
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetMuzzleFlashes() []*MuzzleFlash {
+	if x != nil {
+		return x.MuzzleFlashes
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetMuzzleFlashes2() []*MuzzleFlash {
+	if x != nil {
+		return x.MuzzleFlashes2
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetInventories() []*Inventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetDisconnect() bool {
+	if x != nil {
+		return x.Disconnect
+	}
+	return false
+}
+
+func (x *MvdUnicast) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *MvdUnicast) GetOrder() []int32 {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *MvdUnicast) GetUnknown() []byte {
+	if x != nil {
+		return x.Unknown
+	}
+	return nil
+}
+
 type MvdPacket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -800,6 +1001,8 @@ type MvdPacket struct {
 	Multicasts    []*MvdMulticast         `protobuf:"bytes,4,rep,name=multicasts,proto3" json:"multicasts,omitempty"`
 	Frames        []*MvdFrame             `protobuf:"bytes,5,rep,name=frames,proto3" json:"frames,omitempty"`
 	Configstrings map[int32]*ConfigString `protobuf:"bytes,6,rep,name=configstrings,proto3" json:"configstrings,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Stuffs        []*StuffText            `protobuf:"bytes,7,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
+	Disconnect    bool                    `protobuf:"varint,9,opt,name=disconnect,proto3" json:"disconnect,omitempty"` // server dropped the stream
 }
 
 func (x *MvdPacket) Reset() {
@@ -883,6 +1086,20 @@ func (x *MvdPacket) GetConfigstrings() map[int32]*ConfigString {
 	return nil
 }
 
+func (x *MvdPacket) GetStuffs() []*StuffText {
+	if x != nil {
+		return x.Stuffs
+	}
+	return nil
+}
+
+func (x *MvdPacket) GetDisconnect() bool {
+	if x != nil {
+		return x.Disconnect
+	}
+	return false
+}
+
 type MvdServerData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1100,7 +1317,7 @@ var file_multiview_demo_proto_rawDesc = []byte{
 	0x05, 0x52, 0x0a, 0x6c, 0x6f, 0x6f, 0x70, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x29, 0x0a,
 	0x10, 0x6c, 0x6f, 0x6f, 0x70, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6c, 0x6f, 0x6f, 0x70, 0x41, 0x74, 0x74,
-	0x65, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xed, 0x02, 0x0a, 0x08, 0x4d, 0x76, 0x64,
+	0x65, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x96, 0x03, 0x0a, 0x08, 0x4d, 0x76, 0x64,
 	0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x5f,
 	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x6f, 0x72, 0x74,
 	0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
@@ -1113,90 +1330,163 @@ var file_multiview_demo_proto_rawDesc = []byte{
 	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f, 0x75,
 	0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x73,
-	0x6f, 0x75, 0x6e, 0x64, 0x73, 0x1a, 0x4f, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4a, 0x0a, 0x0c, 0x4d, 0x76, 0x64, 0x4d,
-	0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6c, 0x65, 0x61, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x22, 0x8f, 0x02, 0x0a, 0x0a, 0x4d, 0x76, 0x64, 0x55, 0x6e, 0x69, 0x63,
-	0x61, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x4d, 0x76, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x12, 0x27, 0x0a, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f,
-	0x75, 0x74, 0x52, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x72, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x06,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65, 0x78, 0x74, 0x52, 0x06,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x73, 0x22, 0xc2, 0x03, 0x0a, 0x09, 0x4d, 0x76, 0x64, 0x50, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x4d, 0x76, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f,
-	0x75, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06,
-	0x73, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x72, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x08,
-	0x75, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73,
-	0x74, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x0a, 0x6d,
-	0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69,
-	0x63, 0x61, 0x73, 0x74, 0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73,
-	0x12, 0x27, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x46, 0x72, 0x61, 0x6d,
-	0x65, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x49, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x73, 0x1a, 0x55, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0e,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x1a, 0x4f,
+	0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x50, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x9d, 0x05, 0x0a, 0x0c, 0x4d, 0x76, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x65,
+	0x6d, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x45, 0x6e, 0x74, 0x73, 0x12,
+	0x39, 0x0a, 0x0e, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x0d, 0x6d, 0x75, 0x7a,
+	0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x6d, 0x75,
+	0x7a, 0x7a, 0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a,
+	0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x0e, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46,
+	0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0c, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x50, 0x72,
+	0x69, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x27, 0x0a, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x52, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65, 0x78, 0x74, 0x52, 0x06, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb4, 0x02, 0x0a, 0x0d,
-	0x4d, 0x76, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61,
-	0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12,
-	0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x67,
-	0x61, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x67, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x5f, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x10, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x73, 0x74, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61,
-	0x67, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x70,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12,
-	0x31, 0x0a, 0x05, 0x72, 0x65, 0x6d, 0x61, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6d, 0x61, 0x70, 0x52, 0x05, 0x72, 0x65, 0x6d,
-	0x61, 0x70, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c,
-	0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x32, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x10, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x22, 0xde, 0x05, 0x0a, 0x0a, 0x4d, 0x76, 0x64, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76,
+	0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x27, 0x0a, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52,
+	0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65, 0x78, 0x74, 0x52, 0x06, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x36, 0x0a, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54,
+	0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08,
+	0x74, 0x65, 0x6d, 0x70, 0x45, 0x6e, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0e, 0x6d, 0x75, 0x7a, 0x7a,
+	0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46,
+	0x6c, 0x61, 0x73, 0x68, 0x52, 0x0d, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x5f, 0x66, 0x6c,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68,
+	0x52, 0x0e, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32,
+	0x12, 0x32, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x22, 0x8c, 0x04, 0x0a, 0x09, 0x4d, 0x76, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x34, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x08, 0x75, 0x6e, 0x69, 0x63,
+	0x61, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52, 0x08, 0x75,
+	0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x06,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x49, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x28, 0x0a, 0x06, 0x73, 0x74, 0x75, 0x66, 0x66, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65,
+	0x78, 0x74, 0x52, 0x06, 0x73, 0x74, 0x75, 0x66, 0x66, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x1a, 0x55, 0x0a, 0x12, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xb4, 0x02, 0x0a, 0x0d, 0x4d, 0x76, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x25, 0x0a, 0x0e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x67, 0x61, 0x6d, 0x65, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x75, 0x6d, 0x6d,
+	0x79, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x64, 0x75, 0x6d, 0x6d, 0x79, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x05, 0x72, 0x65, 0x6d, 0x61, 0x70, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x76, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6d, 0x61,
+	0x70, 0x52, 0x05, 0x72, 0x65, 0x6d, 0x61, 0x70, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1231,11 +1521,15 @@ var file_multiview_demo_proto_goTypes = []interface{}{
 	nil,                             // 15: proto.MvdPacket.ConfigstringsEntry
 	(*PackedPlayer)(nil),            // 16: proto.PackedPlayer
 	(*PackedSound)(nil),             // 17: proto.PackedSound
-	(*Layout)(nil),                  // 18: proto.Layout
-	(*ConfigString)(nil),            // 19: proto.ConfigString
+	(*TemporaryEntity)(nil),         // 18: proto.TemporaryEntity
+	(*MuzzleFlash)(nil),             // 19: proto.MuzzleFlash
 	(*Print)(nil),                   // 20: proto.Print
-	(*StuffText)(nil),               // 21: proto.StuffText
-	(*PackedEntity)(nil),            // 22: proto.PackedEntity
+	(*CenterPrint)(nil),             // 21: proto.CenterPrint
+	(*Layout)(nil),                  // 22: proto.Layout
+	(*StuffText)(nil),               // 23: proto.StuffText
+	(*ConfigString)(nil),            // 24: proto.ConfigString
+	(*Inventory)(nil),               // 25: proto.Inventory
+	(*PackedEntity)(nil),            // 26: proto.PackedEntity
 }
 var file_multiview_demo_proto_depIdxs = []int32{
 	2,  // 0: proto.MvdDemo.remap:type_name -> proto.MvdConfigStringRemap
@@ -1248,30 +1542,47 @@ var file_multiview_demo_proto_depIdxs = []int32{
 	13, // 7: proto.MvdFrame.players:type_name -> proto.MvdFrame.PlayersEntry
 	14, // 8: proto.MvdFrame.entities:type_name -> proto.MvdFrame.EntitiesEntry
 	17, // 9: proto.MvdFrame.sounds:type_name -> proto.PackedSound
-	3,  // 10: proto.MvdUnicast.player:type_name -> proto.MvdPlayer
-	18, // 11: proto.MvdUnicast.layouts:type_name -> proto.Layout
-	19, // 12: proto.MvdUnicast.configstrings:type_name -> proto.ConfigString
-	20, // 13: proto.MvdUnicast.prints:type_name -> proto.Print
-	21, // 14: proto.MvdUnicast.stuffs:type_name -> proto.StuffText
-	9,  // 15: proto.MvdPacket.serverdata:type_name -> proto.MvdServerData
-	17, // 16: proto.MvdPacket.sounds:type_name -> proto.PackedSound
-	20, // 17: proto.MvdPacket.prints:type_name -> proto.Print
-	7,  // 18: proto.MvdPacket.unicasts:type_name -> proto.MvdUnicast
-	6,  // 19: proto.MvdPacket.multicasts:type_name -> proto.MvdMulticast
-	5,  // 20: proto.MvdPacket.frames:type_name -> proto.MvdFrame
-	15, // 21: proto.MvdPacket.configstrings:type_name -> proto.MvdPacket.ConfigstringsEntry
-	2,  // 22: proto.MvdServerData.remap:type_name -> proto.MvdConfigStringRemap
-	19, // 23: proto.MvdDemo.ConfigstringsEntry.value:type_name -> proto.ConfigString
-	3,  // 24: proto.MvdDemo.PlayersEntry.value:type_name -> proto.MvdPlayer
-	22, // 25: proto.MvdDemo.EntitiesEntry.value:type_name -> proto.PackedEntity
-	16, // 26: proto.MvdFrame.PlayersEntry.value:type_name -> proto.PackedPlayer
-	22, // 27: proto.MvdFrame.EntitiesEntry.value:type_name -> proto.PackedEntity
-	19, // 28: proto.MvdPacket.ConfigstringsEntry.value:type_name -> proto.ConfigString
-	29, // [29:29] is the sub-list for method output_type
-	29, // [29:29] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	18, // 10: proto.MvdMulticast.temp_ents:type_name -> proto.TemporaryEntity
+	19, // 11: proto.MvdMulticast.muzzle_flashes:type_name -> proto.MuzzleFlash
+	19, // 12: proto.MvdMulticast.muzzle_flashes2:type_name -> proto.MuzzleFlash
+	17, // 13: proto.MvdMulticast.sounds:type_name -> proto.PackedSound
+	20, // 14: proto.MvdMulticast.prints:type_name -> proto.Print
+	21, // 15: proto.MvdMulticast.centerprints:type_name -> proto.CenterPrint
+	22, // 16: proto.MvdMulticast.layouts:type_name -> proto.Layout
+	23, // 17: proto.MvdMulticast.stuffs:type_name -> proto.StuffText
+	24, // 18: proto.MvdMulticast.configstrings:type_name -> proto.ConfigString
+	25, // 19: proto.MvdMulticast.inventories:type_name -> proto.Inventory
+	3,  // 20: proto.MvdUnicast.player:type_name -> proto.MvdPlayer
+	22, // 21: proto.MvdUnicast.layouts:type_name -> proto.Layout
+	24, // 22: proto.MvdUnicast.configstrings:type_name -> proto.ConfigString
+	20, // 23: proto.MvdUnicast.prints:type_name -> proto.Print
+	23, // 24: proto.MvdUnicast.stuffs:type_name -> proto.StuffText
+	21, // 25: proto.MvdUnicast.centerprints:type_name -> proto.CenterPrint
+	17, // 26: proto.MvdUnicast.sounds:type_name -> proto.PackedSound
+	18, // 27: proto.MvdUnicast.temp_ents:type_name -> proto.TemporaryEntity
+	19, // 28: proto.MvdUnicast.muzzle_flashes:type_name -> proto.MuzzleFlash
+	19, // 29: proto.MvdUnicast.muzzle_flashes2:type_name -> proto.MuzzleFlash
+	25, // 30: proto.MvdUnicast.inventories:type_name -> proto.Inventory
+	9,  // 31: proto.MvdPacket.serverdata:type_name -> proto.MvdServerData
+	17, // 32: proto.MvdPacket.sounds:type_name -> proto.PackedSound
+	20, // 33: proto.MvdPacket.prints:type_name -> proto.Print
+	7,  // 34: proto.MvdPacket.unicasts:type_name -> proto.MvdUnicast
+	6,  // 35: proto.MvdPacket.multicasts:type_name -> proto.MvdMulticast
+	5,  // 36: proto.MvdPacket.frames:type_name -> proto.MvdFrame
+	15, // 37: proto.MvdPacket.configstrings:type_name -> proto.MvdPacket.ConfigstringsEntry
+	23, // 38: proto.MvdPacket.stuffs:type_name -> proto.StuffText
+	2,  // 39: proto.MvdServerData.remap:type_name -> proto.MvdConfigStringRemap
+	24, // 40: proto.MvdDemo.ConfigstringsEntry.value:type_name -> proto.ConfigString
+	3,  // 41: proto.MvdDemo.PlayersEntry.value:type_name -> proto.MvdPlayer
+	26, // 42: proto.MvdDemo.EntitiesEntry.value:type_name -> proto.PackedEntity
+	16, // 43: proto.MvdFrame.PlayersEntry.value:type_name -> proto.PackedPlayer
+	26, // 44: proto.MvdFrame.EntitiesEntry.value:type_name -> proto.PackedEntity
+	24, // 45: proto.MvdPacket.ConfigstringsEntry.value:type_name -> proto.ConfigString
+	46, // [46:46] is the sub-list for method output_type
+	46, // [46:46] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_multiview_demo_proto_init() }
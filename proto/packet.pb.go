@@ -29,17 +29,22 @@ type Packet struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Frames        []*Frame           `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"` // includes playerstate and packetentities
-	ConfigStrings []*ConfigString    `protobuf:"bytes,2,rep,name=config_strings,json=configStrings,proto3" json:"config_strings,omitempty"`
-	Prints        []*Print           `protobuf:"bytes,3,rep,name=prints,proto3" json:"prints,omitempty"`
-	Sounds        []*PackedSound     `protobuf:"bytes,4,rep,name=sounds,proto3" json:"sounds,omitempty"`
-	TempEnts      []*TemporaryEntity `protobuf:"bytes,5,rep,name=temp_ents,json=tempEnts,proto3" json:"temp_ents,omitempty"`
-	MuzzleFlashes []*MuzzleFlash     `protobuf:"bytes,6,rep,name=muzzle_flashes,json=muzzleFlashes,proto3" json:"muzzle_flashes,omitempty"`
-	Layouts       []*Layout          `protobuf:"bytes,7,rep,name=layouts,proto3" json:"layouts,omitempty"`
-	Centerprints  []*CenterPrint     `protobuf:"bytes,8,rep,name=centerprints,proto3" json:"centerprints,omitempty"`
-	Stuffs        []*StuffText       `protobuf:"bytes,9,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
-	Baselines     []*PackedEntity    `protobuf:"bytes,11,rep,name=baselines,proto3" json:"baselines,omitempty"`
-	ServerData    *ServerInfo        `protobuf:"bytes,10,opt,name=server_data,json=serverData,proto3" json:"server_data,omitempty"`
+	Frames         []*Frame           `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"` // includes playerstate and packetentities
+	ConfigStrings  []*ConfigString    `protobuf:"bytes,2,rep,name=config_strings,json=configStrings,proto3" json:"config_strings,omitempty"`
+	Prints         []*Print           `protobuf:"bytes,3,rep,name=prints,proto3" json:"prints,omitempty"`
+	Sounds         []*PackedSound     `protobuf:"bytes,4,rep,name=sounds,proto3" json:"sounds,omitempty"`
+	TempEnts       []*TemporaryEntity `protobuf:"bytes,5,rep,name=temp_ents,json=tempEnts,proto3" json:"temp_ents,omitempty"`
+	MuzzleFlashes  []*MuzzleFlash     `protobuf:"bytes,6,rep,name=muzzle_flashes,json=muzzleFlashes,proto3" json:"muzzle_flashes,omitempty"`
+	Layouts        []*Layout          `protobuf:"bytes,7,rep,name=layouts,proto3" json:"layouts,omitempty"`
+	Centerprints   []*CenterPrint     `protobuf:"bytes,8,rep,name=centerprints,proto3" json:"centerprints,omitempty"`
+	Stuffs         []*StuffText       `protobuf:"bytes,9,rep,name=stuffs,proto3" json:"stuffs,omitempty"`
+	Baselines      []*PackedEntity    `protobuf:"bytes,11,rep,name=baselines,proto3" json:"baselines,omitempty"`
+	ServerData     *ServerInfo        `protobuf:"bytes,10,opt,name=server_data,json=serverData,proto3" json:"server_data,omitempty"`
+	Settings       []*Setting         `protobuf:"bytes,12,rep,name=settings,proto3" json:"settings,omitempty"`
+	MuzzleFlashes2 []*MuzzleFlash     `protobuf:"bytes,13,rep,name=muzzle_flashes2,json=muzzleFlashes2,proto3" json:"muzzle_flashes2,omitempty"`
+	Inventories    []*Inventory       `protobuf:"bytes,14,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	Disconnect     bool               `protobuf:"varint,15,opt,name=disconnect,proto3" json:"disconnect,omitempty"`
+	Reconnect      bool               `protobuf:"varint,16,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
 }
 
 func (x *Packet) Reset() {
@@ -151,12 +156,47 @@ func (x *Packet) GetServerData() *ServerInfo {
 	return nil
 }
 
+func (x *Packet) GetSettings() []*Setting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *Packet) GetMuzzleFlashes2() []*MuzzleFlash {
+	if x != nil {
+		return x.MuzzleFlashes2
+	}
+	return nil
+}
+
+func (x *Packet) GetInventories() []*Inventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+func (x *Packet) GetDisconnect() bool {
+	if x != nil {
+		return x.Disconnect
+	}
+	return false
+}
+
+func (x *Packet) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
 var File_packet_proto protoreflect.FileDescriptor
 
 var file_packet_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9e, 0x04, 0x0a, 0x06,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf9, 0x05, 0x0a, 0x06,
 	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46,
 	0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0e,
@@ -190,10 +230,24 @@ var file_packet_proto_rawDesc = []byte{
 	0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x42, 0x26, 0x5a, 0x24,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65,
-	0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62, 0x71, 0x32, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x08,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x6d, 0x75, 0x7a, 0x7a,
+	0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65,
+	0x46, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x0e, 0x6d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x32, 0x12, 0x32, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64,
+	0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -222,6 +276,8 @@ var file_packet_proto_goTypes = []interface{}{
 	(*StuffText)(nil),       // 9: proto.StuffText
 	(*PackedEntity)(nil),    // 10: proto.PackedEntity
 	(*ServerInfo)(nil),      // 11: proto.ServerInfo
+	(*Setting)(nil),         // 12: proto.Setting
+	(*Inventory)(nil),       // 13: proto.Inventory
 }
 var file_packet_proto_depIdxs = []int32{
 	1,  // 0: proto.Packet.frames:type_name -> proto.Frame
@@ -235,11 +291,14 @@ var file_packet_proto_depIdxs = []int32{
 	9,  // 8: proto.Packet.stuffs:type_name -> proto.StuffText
 	10, // 9: proto.Packet.baselines:type_name -> proto.PackedEntity
 	11, // 10: proto.Packet.server_data:type_name -> proto.ServerInfo
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	12, // 11: proto.Packet.settings:type_name -> proto.Setting
+	6,  // 12: proto.Packet.muzzle_flashes2:type_name -> proto.MuzzleFlash
+	13, // 13: proto.Packet.inventories:type_name -> proto.Inventory
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_packet_proto_init() }
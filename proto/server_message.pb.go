@@ -33,6 +33,7 @@ type DM2Demo struct {
 	Configstrings map[int32]*ConfigString `protobuf:"bytes,3,rep,name=configstrings,proto3" json:"configstrings,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Frames        map[int32]*Frame        `protobuf:"bytes,5,rep,name=frames,proto3" json:"frames,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	CurrentFrame  int32                   `protobuf:"varint,6,opt,name=current_frame,json=currentFrame,proto3" json:"current_frame,omitempty"` // index to the frames map
+	FrameRate     int32                   `protobuf:"varint,7,opt,name=frame_rate,json=frameRate,proto3" json:"frame_rate,omitempty"`          // hz, 0 means the original 10
 }
 
 func (x *DM2Demo) Reset() {
@@ -102,6 +103,13 @@ func (x *DM2Demo) GetCurrentFrame() int32 {
 	return 0
 }
 
+func (x *DM2Demo) GetFrameRate() int32 {
+	if x != nil {
+		return x.FrameRate
+	}
+	return 0
+}
+
 type ServerInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -244,6 +252,62 @@ func (x *ConfigString) GetData() string {
 	return ""
 }
 
+// r1q2/q2pro server settings
+type Setting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 32 bits
+	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"` // 32 bits
+}
+
+func (x *Setting) Reset() {
+	*x = Setting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Setting) ProtoMessage() {}
+
+func (x *Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_server_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Setting.ProtoReflect.Descriptor instead.
+func (*Setting) Descriptor() ([]byte, []int) {
+	return file_server_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Setting) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Setting) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
 type StuffText struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -255,7 +319,7 @@ type StuffText struct {
 func (x *StuffText) Reset() {
 	*x = StuffText{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[3]
+		mi := &file_server_message_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -268,7 +332,7 @@ func (x *StuffText) String() string {
 func (*StuffText) ProtoMessage() {}
 
 func (x *StuffText) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[3]
+	mi := &file_server_message_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,7 +345,7 @@ func (x *StuffText) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StuffText.ProtoReflect.Descriptor instead.
 func (*StuffText) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{3}
+	return file_server_message_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StuffText) GetData() string {
@@ -324,7 +388,7 @@ type PackedEntity struct {
 func (x *PackedEntity) Reset() {
 	*x = PackedEntity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[4]
+		mi := &file_server_message_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -337,7 +401,7 @@ func (x *PackedEntity) String() string {
 func (*PackedEntity) ProtoMessage() {}
 
 func (x *PackedEntity) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[4]
+	mi := &file_server_message_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +414,7 @@ func (x *PackedEntity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackedEntity.ProtoReflect.Descriptor instead.
 func (*PackedEntity) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{4}
+	return file_server_message_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PackedEntity) GetNumber() uint32 {
@@ -530,7 +594,7 @@ type PlayerMove struct {
 func (x *PlayerMove) Reset() {
 	*x = PlayerMove{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[5]
+		mi := &file_server_message_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -543,7 +607,7 @@ func (x *PlayerMove) String() string {
 func (*PlayerMove) ProtoMessage() {}
 
 func (x *PlayerMove) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[5]
+	mi := &file_server_message_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +620,7 @@ func (x *PlayerMove) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerMove.ProtoReflect.Descriptor instead.
 func (*PlayerMove) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{5}
+	return file_server_message_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PlayerMove) GetType() uint32 {
@@ -689,7 +753,7 @@ type PackedPlayer struct {
 func (x *PackedPlayer) Reset() {
 	*x = PackedPlayer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[6]
+		mi := &file_server_message_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -702,7 +766,7 @@ func (x *PackedPlayer) String() string {
 func (*PackedPlayer) ProtoMessage() {}
 
 func (x *PackedPlayer) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[6]
+	mi := &file_server_message_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,7 +779,7 @@ func (x *PackedPlayer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackedPlayer.ProtoReflect.Descriptor instead.
 func (*PackedPlayer) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{6}
+	return file_server_message_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PackedPlayer) GetMovestate() *PlayerMove {
@@ -942,12 +1006,13 @@ type Frame struct {
 	Flashes1          []*MuzzleFlash          `protobuf:"bytes,14,rep,name=flashes1,proto3" json:"flashes1,omitempty"`
 	Flashes2          []*MuzzleFlash          `protobuf:"bytes,15,rep,name=flashes2,proto3" json:"flashes2,omitempty"`
 	Layouts           []*Layout               `protobuf:"bytes,16,rep,name=layouts,proto3" json:"layouts,omitempty"`
+	ServerTime        int32                   `protobuf:"varint,17,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // msecs, from the frame rate
 }
 
 func (x *Frame) Reset() {
 	*x = Frame{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[7]
+		mi := &file_server_message_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -960,7 +1025,7 @@ func (x *Frame) String() string {
 func (*Frame) ProtoMessage() {}
 
 func (x *Frame) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[7]
+	mi := &file_server_message_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1038,7 @@ func (x *Frame) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Frame.ProtoReflect.Descriptor instead.
 func (*Frame) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{7}
+	return file_server_message_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Frame) GetNumber() int32 {
@@ -1088,6 +1153,13 @@ func (x *Frame) GetLayouts() []*Layout {
 	return nil
 }
 
+func (x *Frame) GetServerTime() int32 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
 type Print struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1100,7 +1172,7 @@ type Print struct {
 func (x *Print) Reset() {
 	*x = Print{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[8]
+		mi := &file_server_message_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1113,7 +1185,7 @@ func (x *Print) String() string {
 func (*Print) ProtoMessage() {}
 
 func (x *Print) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[8]
+	mi := &file_server_message_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1126,7 +1198,7 @@ func (x *Print) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Print.ProtoReflect.Descriptor instead.
 func (*Print) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{8}
+	return file_server_message_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Print) GetLevel() uint32 {
@@ -1164,7 +1236,7 @@ type PackedSound struct {
 func (x *PackedSound) Reset() {
 	*x = PackedSound{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[9]
+		mi := &file_server_message_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1177,7 +1249,7 @@ func (x *PackedSound) String() string {
 func (*PackedSound) ProtoMessage() {}
 
 func (x *PackedSound) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[9]
+	mi := &file_server_message_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,7 +1262,7 @@ func (x *PackedSound) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackedSound.ProtoReflect.Descriptor instead.
 func (*PackedSound) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{9}
+	return file_server_message_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PackedSound) GetFlags() uint32 {
@@ -1289,7 +1361,7 @@ type TemporaryEntity struct {
 func (x *TemporaryEntity) Reset() {
 	*x = TemporaryEntity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[10]
+		mi := &file_server_message_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1302,7 +1374,7 @@ func (x *TemporaryEntity) String() string {
 func (*TemporaryEntity) ProtoMessage() {}
 
 func (x *TemporaryEntity) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[10]
+	mi := &file_server_message_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1315,7 +1387,7 @@ func (x *TemporaryEntity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TemporaryEntity.ProtoReflect.Descriptor instead.
 func (*TemporaryEntity) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{10}
+	return file_server_message_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *TemporaryEntity) GetType() uint32 {
@@ -1442,7 +1514,7 @@ type MuzzleFlash struct {
 func (x *MuzzleFlash) Reset() {
 	*x = MuzzleFlash{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[11]
+		mi := &file_server_message_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1455,7 +1527,7 @@ func (x *MuzzleFlash) String() string {
 func (*MuzzleFlash) ProtoMessage() {}
 
 func (x *MuzzleFlash) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[11]
+	mi := &file_server_message_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1468,7 +1540,7 @@ func (x *MuzzleFlash) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuzzleFlash.ProtoReflect.Descriptor instead.
 func (*MuzzleFlash) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{11}
+	return file_server_message_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MuzzleFlash) GetEntity() uint32 {
@@ -1496,7 +1568,7 @@ type Layout struct {
 func (x *Layout) Reset() {
 	*x = Layout{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[12]
+		mi := &file_server_message_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1509,7 +1581,7 @@ func (x *Layout) String() string {
 func (*Layout) ProtoMessage() {}
 
 func (x *Layout) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[12]
+	mi := &file_server_message_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1522,7 +1594,7 @@ func (x *Layout) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Layout.ProtoReflect.Descriptor instead.
 func (*Layout) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{12}
+	return file_server_message_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Layout) GetData() string {
@@ -1543,7 +1615,7 @@ type CenterPrint struct {
 func (x *CenterPrint) Reset() {
 	*x = CenterPrint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_message_proto_msgTypes[13]
+		mi := &file_server_message_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1556,7 +1628,7 @@ func (x *CenterPrint) String() string {
 func (*CenterPrint) ProtoMessage() {}
 
 func (x *CenterPrint) ProtoReflect() protoreflect.Message {
-	mi := &file_server_message_proto_msgTypes[13]
+	mi := &file_server_message_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1569,7 +1641,7 @@ func (x *CenterPrint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CenterPrint.ProtoReflect.Descriptor instead.
 func (*CenterPrint) Descriptor() ([]byte, []int) {
-	return file_server_message_proto_rawDescGZIP(), []int{13}
+	return file_server_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CenterPrint) GetData() string {
@@ -1579,11 +1651,58 @@ func (x *CenterPrint) GetData() string {
 	return ""
 }
 
+type Inventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []int32 `protobuf:"varint,1,rep,packed,name=items,proto3" json:"items,omitempty"` // how many of each item, by item index
+}
+
+func (x *Inventory) Reset() {
+	*x = Inventory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_message_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Inventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inventory) ProtoMessage() {}
+
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_server_message_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_server_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Inventory) GetItems() []int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 var File_server_message_proto protoreflect.FileDescriptor
 
 var file_server_message_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8e, 0x04,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xad, 0x04,
 	0x0a, 0x07, 0x44, 0x4d, 0x32, 0x44, 0x65, 0x6d, 0x6f, 0x12, 0x31, 0x0a, 0x0a, 0x73, 0x65, 0x72,
 	0x76, 0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
@@ -1601,287 +1720,296 @@ var file_server_message_proto_rawDesc = []byte{
 	0x6d, 0x6f, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
 	0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
 	0x74, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x1a, 0x51, 0x0a, 0x0e, 0x42,
-	0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x55,
-	0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x47, 0x0a, 0x0b, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x72,
-	0x61, 0x6d, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xba,
-	0x01, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x65, 0x6d, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x6d, 0x6f,
-	0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x0c, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x1f, 0x0a, 0x09, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65,
-	0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xed, 0x04, 0x0a, 0x0c, 0x50, 0x61, 0x63, 0x6b, 0x65,
-	0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x61, 0x74, 0x65, 0x1a, 0x51, 0x0a, 0x0e, 0x42, 0x61,
+	0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x55, 0x0a,
+	0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x47, 0x0a, 0x0b, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xba, 0x01,
+	0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x65, 0x6d, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x6d, 0x6f, 0x12,
+	0x19, 0x0a, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x61, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x0c, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x35, 0x0a, 0x07, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1f, 0x0a, 0x09, 0x53,
+	0x74, 0x75, 0x66, 0x66, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xed, 0x04, 0x0a,
+	0x0c, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x58,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x59, 0x12, 0x19, 0x0a, 0x08, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x5a, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x58, 0x12,
+	0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67, 0x6c,
+	0x65, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c, 0x65,
+	0x5a, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
+	0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x59, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64,
+	0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5a, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x32, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x32, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x33, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x33, 0x12, 0x21,
+	0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x34, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x34, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x73, 0x6b, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x66, 0x78, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x46, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x6f, 0x6c, 0x69, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6f, 0x6c,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0xfe, 0x02, 0x0a,
+	0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
 	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x58, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
 	0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72,
 	0x69, 0x67, 0x69, 0x6e, 0x59, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
 	0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5a,
-	0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x58, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67,
-	0x6c, 0x65, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c,
-	0x65, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x7a, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5a, 0x12, 0x20, 0x0a, 0x0c, 0x6f,
-	0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x58, 0x12, 0x20, 0x0a,
-	0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x59, 0x12,
-	0x20, 0x0a, 0x0c, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x7a, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e,
-	0x5a, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x32, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x32, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x33, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x33, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x34, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x73,
-	0x6b, 0x69, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x6e, 0x12,
-	0x18, 0x0a, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e,
-	0x64, 0x65, 0x72, 0x5f, 0x66, 0x78, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65,
-	0x6e, 0x64, 0x65, 0x72, 0x46, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x18,
-	0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x72, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x05, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0xfe, 0x02, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x58, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x59, 0x12,
-	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5a, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65,
-	0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x58, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x6c,
-	0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76,
-	0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x59, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x6c, 0x6f,
-	0x63, 0x69, 0x74, 0x79, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76, 0x65,
-	0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5a, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x61, 0x76, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x61, 0x76, 0x69, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x64,
-	0x65, 0x6c, 0x74, 0x61, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x78, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x58, 0x12,
-	0x22, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x79,
-	0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6e, 0x67,
-	0x6c, 0x65, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x5f, 0x61, 0x6e, 0x67,
-	0x6c, 0x65, 0x5f, 0x7a, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74,
-	0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x5a, 0x22, 0xa2, 0x08, 0x0a, 0x0c, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x09,
-	0x6d, 0x6f, 0x76, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65,
-	0x77, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x58, 0x12, 0x22, 0x0a,
-	0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73,
-	0x59, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73,
-	0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x41, 0x6e,
-	0x67, 0x6c, 0x65, 0x73, 0x5a, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69,
-	0x65, 0x77, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65,
-	0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x59, 0x12, 0x22, 0x0a,
-	0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x7a, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
-	0x5a, 0x12, 0x22, 0x0a, 0x0d, 0x6b, 0x69, 0x63, 0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73,
-	0x5f, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6b, 0x69, 0x63, 0x6b, 0x41, 0x6e,
-	0x67, 0x6c, 0x65, 0x73, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x6b, 0x69, 0x63, 0x6b, 0x5f, 0x61, 0x6e,
-	0x67, 0x6c, 0x65, 0x73, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6b, 0x69,
-	0x63, 0x6b, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x6b, 0x69, 0x63,
-	0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x6b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5a, 0x12, 0x20, 0x0a,
-	0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x78, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x58, 0x12,
-	0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x79, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73,
-	0x59, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f,
-	0x7a, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x41, 0x6e, 0x67, 0x6c,
-	0x65, 0x73, 0x5a, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x5f, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x4f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x5f, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e,
-	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x59, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x7a, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67,
-	0x75, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5a, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x75, 0x6e,
-	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x67, 0x75,
-	0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x75, 0x6e, 0x5f, 0x66, 0x72,
-	0x61, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x67, 0x75, 0x6e, 0x46, 0x72,
-	0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x18, 0x13,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x57, 0x12, 0x17, 0x0a, 0x07,
-	0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x78, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62,
-	0x6c, 0x65, 0x6e, 0x64, 0x58, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x79,
-	0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x59, 0x12, 0x17,
-	0x0a, 0x07, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x7a, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5a, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67,
-	0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x57, 0x12, 0x24, 0x0a,
-	0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x78, 0x18,
-	0x1c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x6c, 0x65,
-	0x6e, 0x64, 0x58, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c,
-	0x65, 0x6e, 0x64, 0x5f, 0x79, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d,
-	0x61, 0x67, 0x65, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x59, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d,
-	0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x7a, 0x18, 0x1e, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x5a, 0x12,
-	0x10, 0x0a, 0x03, 0x66, 0x6f, 0x76, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x66, 0x6f,
-	0x76, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x64, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x18, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x34, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfd, 0x06, 0x0a,
-	0x05, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
-	0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64,
-	0x65, 0x6c, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x72, 0x65, 0x61, 0x42, 0x79,
-	0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x62, 0x69, 0x74, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x72, 0x65, 0x61, 0x42, 0x69, 0x74, 0x73,
-	0x12, 0x36, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x0b, 0x70, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x12, 0x45, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x36, 0x0a, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65,
-	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x6e,
-	0x74, 0x52, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12,
-	0x30, 0x0a, 0x0a, 0x73, 0x74, 0x75, 0x66, 0x66, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x0a, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66,
-	0x66, 0x54, 0x65, 0x78, 0x74, 0x52, 0x0a, 0x73, 0x74, 0x75, 0x66, 0x66, 0x74, 0x65, 0x78, 0x74,
-	0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x52,
-	0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64,
-	0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x75,
-	0x6e, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79,
-	0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72,
-	0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61,
-	0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6c,
-	0x61, 0x73, 0x68, 0x65, 0x73, 0x31, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68,
-	0x52, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x31, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6c,
-	0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68,
-	0x52, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x12, 0x27, 0x0a, 0x07, 0x6c, 0x61,
-	0x79, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x07, 0x6c, 0x61, 0x79, 0x6f,
-	0x75, 0x74, 0x73, 0x1a, 0x50, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61,
-	0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x55, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x58, 0x12,
+	0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x59, 0x12, 0x1d,
+	0x0a, 0x0a, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5a, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x61, 0x76, 0x69,
+	0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x61, 0x76, 0x69, 0x74,
+	0x79, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65,
+	0x5f, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41,
+	0x6e, 0x67, 0x6c, 0x65, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x5f, 0x61,
+	0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x7a, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x5a, 0x22, 0xa2, 0x08,
+	0x0a, 0x0c, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2f,
+	0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x4d, 0x6f, 0x76, 0x65, 0x52, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x41, 0x6e, 0x67, 0x6c,
+	0x65, 0x73, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x61, 0x6e, 0x67, 0x6c,
+	0x65, 0x73, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77,
+	0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f,
+	0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x76, 0x69, 0x65, 0x77, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5a, 0x12, 0x22, 0x0a, 0x0d, 0x76,
+	0x69, 0x65, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x58, 0x12,
+	0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5a, 0x12, 0x22, 0x0a, 0x0d, 0x6b, 0x69, 0x63, 0x6b, 0x5f,
+	0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x6b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x6b,
+	0x69, 0x63, 0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x6b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x59, 0x12,
+	0x22, 0x0a, 0x0d, 0x6b, 0x69, 0x63, 0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x7a,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x67, 0x6c,
+	0x65, 0x73, 0x5a, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65,
+	0x73, 0x5f, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x41, 0x6e,
+	0x67, 0x6c, 0x65, 0x73, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61, 0x6e, 0x67,
+	0x6c, 0x65, 0x73, 0x5f, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e,
+	0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x59, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x61,
+	0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5f, 0x7a, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67,
+	0x75, 0x6e, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x73, 0x5a, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x75, 0x6e,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x67, 0x75, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x67,
+	0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x59, 0x12, 0x20, 0x0a,
+	0x0c, 0x67, 0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x7a, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x75, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5a, 0x12,
+	0x1b, 0x0a, 0x09, 0x67, 0x75, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x67, 0x75, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x75, 0x6e, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x67, 0x75, 0x6e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x65,
+	0x6e, 0x64, 0x5f, 0x77, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x65, 0x6e,
+	0x64, 0x57, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x78, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x58, 0x12, 0x17, 0x0a, 0x07, 0x62,
+	0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c,
+	0x65, 0x6e, 0x64, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x7a, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5a, 0x12, 0x24, 0x0a,
+	0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x6c, 0x65,
+	0x6e, 0x64, 0x57, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c,
+	0x65, 0x6e, 0x64, 0x5f, 0x78, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d,
+	0x61, 0x67, 0x65, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x58, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f, 0x79, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x6c, 0x65, 0x6e, 0x64, 0x59, 0x12,
+	0x24, 0x0a, 0x0e, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x5f,
+	0x7a, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x42,
+	0x6c, 0x65, 0x6e, 0x64, 0x5a, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x6f, 0x76, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x66, 0x6f, 0x76, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x64, 0x5f, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x64, 0x46, 0x6c, 0x61,
+	0x67, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x9e, 0x07, 0x0a, 0x05, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75,
+	0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x72,
+	0x65, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x61, 0x72, 0x65, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x72, 0x65,
+	0x61, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x72,
+	0x65, 0x61, 0x42, 0x69, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x36,
+	0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x36, 0x0a,
+	0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x70,
+	0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x73, 0x74, 0x75, 0x66, 0x66, 0x74, 0x65,
+	0x78, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x74, 0x75, 0x66, 0x66, 0x54, 0x65, 0x78, 0x74, 0x52, 0x0a, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e,
+	0x64, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x12, 0x74, 0x65, 0x6d,
+	0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x65,
+	0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x11, 0x74,
+	0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x31, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c,
+	0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x31,
+	0x12, 0x2e, 0x0a, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x7a, 0x7a, 0x6c,
+	0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x08, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x73, 0x32,
+	0x12, 0x27, 0x0a, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x52, 0x07, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x1a, 0x50, 0x0a, 0x0d, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a,
 	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x31, 0x0a, 0x05,
-	0x50, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0xa3, 0x02, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
-	0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x76,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x76, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x75,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65,
-	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x58, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6f, 0x73,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x59, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x5a, 0x22, 0xce, 0x03, 0x0a, 0x0f, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72,
-	0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x58, 0x12, 0x1f,
-	0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x5f, 0x79, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x59, 0x12,
-	0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x5f, 0x7a, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x5a,
-	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x78, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32,
-	0x58, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x79,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x32, 0x59, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5f,
-	0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x32, 0x5a, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x78, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x58, 0x12, 0x19,
-	0x0a, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x59, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x5a, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
-	0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x31, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x31, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x32, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3d, 0x0a, 0x0b, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65,
-	0x46, 0x6c, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77,
-	0x65, 0x61, 0x70, 0x6f, 0x6e, 0x22, 0x1c, 0x0a, 0x06, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x21, 0x0a, 0x0b, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69,
-	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67,
-	0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x55, 0x0a, 0x12,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x31, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa3, 0x02, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x64, 0x53, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74,
+	0x74, 0x65, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x78, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x58, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x59, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5a, 0x22, 0xce, 0x03, 0x0a,
+	0x0f, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x31, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x31, 0x58, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x31, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x31, 0x59, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x31, 0x5f, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x5a, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x58, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x59, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x5a, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x5f, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x58, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x59,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x7a, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5a, 0x12, 0x1c, 0x0a, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x31,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x31, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x32, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3d, 0x0a,
+	0x0b, 0x4d, 0x75, 0x7a, 0x7a, 0x6c, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x22, 0x1c, 0x0a, 0x06,
+	0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x21, 0x0a, 0x0b, 0x43, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x21, 0x0a,
+	0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62,
+	0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1896,51 +2024,53 @@ func file_server_message_proto_rawDescGZIP() []byte {
 	return file_server_message_proto_rawDescData
 }
 
-var file_server_message_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_server_message_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_server_message_proto_goTypes = []interface{}{
 	(*DM2Demo)(nil),         // 0: proto.DM2Demo
 	(*ServerInfo)(nil),      // 1: proto.ServerInfo
 	(*ConfigString)(nil),    // 2: proto.ConfigString
-	(*StuffText)(nil),       // 3: proto.StuffText
-	(*PackedEntity)(nil),    // 4: proto.PackedEntity
-	(*PlayerMove)(nil),      // 5: proto.PlayerMove
-	(*PackedPlayer)(nil),    // 6: proto.PackedPlayer
-	(*Frame)(nil),           // 7: proto.Frame
-	(*Print)(nil),           // 8: proto.Print
-	(*PackedSound)(nil),     // 9: proto.PackedSound
-	(*TemporaryEntity)(nil), // 10: proto.TemporaryEntity
-	(*MuzzleFlash)(nil),     // 11: proto.MuzzleFlash
-	(*Layout)(nil),          // 12: proto.Layout
-	(*CenterPrint)(nil),     // 13: proto.CenterPrint
-	nil,                     // 14: proto.DM2Demo.BaselinesEntry
-	nil,                     // 15: proto.DM2Demo.ConfigstringsEntry
-	nil,                     // 16: proto.DM2Demo.FramesEntry
-	nil,                     // 17: proto.PackedPlayer.StatsEntry
-	nil,                     // 18: proto.Frame.EntitiesEntry
-	nil,                     // 19: proto.Frame.ConfigstringsEntry
+	(*Setting)(nil),         // 3: proto.Setting
+	(*StuffText)(nil),       // 4: proto.StuffText
+	(*PackedEntity)(nil),    // 5: proto.PackedEntity
+	(*PlayerMove)(nil),      // 6: proto.PlayerMove
+	(*PackedPlayer)(nil),    // 7: proto.PackedPlayer
+	(*Frame)(nil),           // 8: proto.Frame
+	(*Print)(nil),           // 9: proto.Print
+	(*PackedSound)(nil),     // 10: proto.PackedSound
+	(*TemporaryEntity)(nil), // 11: proto.TemporaryEntity
+	(*MuzzleFlash)(nil),     // 12: proto.MuzzleFlash
+	(*Layout)(nil),          // 13: proto.Layout
+	(*CenterPrint)(nil),     // 14: proto.CenterPrint
+	(*Inventory)(nil),       // 15: proto.Inventory
+	nil,                     // 16: proto.DM2Demo.BaselinesEntry
+	nil,                     // 17: proto.DM2Demo.ConfigstringsEntry
+	nil,                     // 18: proto.DM2Demo.FramesEntry
+	nil,                     // 19: proto.PackedPlayer.StatsEntry
+	nil,                     // 20: proto.Frame.EntitiesEntry
+	nil,                     // 21: proto.Frame.ConfigstringsEntry
 }
 var file_server_message_proto_depIdxs = []int32{
 	1,  // 0: proto.DM2Demo.serverinfo:type_name -> proto.ServerInfo
-	14, // 1: proto.DM2Demo.baselines:type_name -> proto.DM2Demo.BaselinesEntry
-	15, // 2: proto.DM2Demo.configstrings:type_name -> proto.DM2Demo.ConfigstringsEntry
-	16, // 3: proto.DM2Demo.frames:type_name -> proto.DM2Demo.FramesEntry
-	5,  // 4: proto.PackedPlayer.movestate:type_name -> proto.PlayerMove
-	17, // 5: proto.PackedPlayer.stats:type_name -> proto.PackedPlayer.StatsEntry
-	6,  // 6: proto.Frame.player_state:type_name -> proto.PackedPlayer
-	18, // 7: proto.Frame.entities:type_name -> proto.Frame.EntitiesEntry
-	19, // 8: proto.Frame.configstrings:type_name -> proto.Frame.ConfigstringsEntry
-	13, // 9: proto.Frame.centerprints:type_name -> proto.CenterPrint
-	3,  // 10: proto.Frame.stufftexts:type_name -> proto.StuffText
-	8,  // 11: proto.Frame.prints:type_name -> proto.Print
-	9,  // 12: proto.Frame.sounds:type_name -> proto.PackedSound
-	10, // 13: proto.Frame.temporary_entities:type_name -> proto.TemporaryEntity
-	11, // 14: proto.Frame.flashes1:type_name -> proto.MuzzleFlash
-	11, // 15: proto.Frame.flashes2:type_name -> proto.MuzzleFlash
-	12, // 16: proto.Frame.layouts:type_name -> proto.Layout
-	4,  // 17: proto.DM2Demo.BaselinesEntry.value:type_name -> proto.PackedEntity
+	16, // 1: proto.DM2Demo.baselines:type_name -> proto.DM2Demo.BaselinesEntry
+	17, // 2: proto.DM2Demo.configstrings:type_name -> proto.DM2Demo.ConfigstringsEntry
+	18, // 3: proto.DM2Demo.frames:type_name -> proto.DM2Demo.FramesEntry
+	6,  // 4: proto.PackedPlayer.movestate:type_name -> proto.PlayerMove
+	19, // 5: proto.PackedPlayer.stats:type_name -> proto.PackedPlayer.StatsEntry
+	7,  // 6: proto.Frame.player_state:type_name -> proto.PackedPlayer
+	20, // 7: proto.Frame.entities:type_name -> proto.Frame.EntitiesEntry
+	21, // 8: proto.Frame.configstrings:type_name -> proto.Frame.ConfigstringsEntry
+	14, // 9: proto.Frame.centerprints:type_name -> proto.CenterPrint
+	4,  // 10: proto.Frame.stufftexts:type_name -> proto.StuffText
+	9,  // 11: proto.Frame.prints:type_name -> proto.Print
+	10, // 12: proto.Frame.sounds:type_name -> proto.PackedSound
+	11, // 13: proto.Frame.temporary_entities:type_name -> proto.TemporaryEntity
+	12, // 14: proto.Frame.flashes1:type_name -> proto.MuzzleFlash
+	12, // 15: proto.Frame.flashes2:type_name -> proto.MuzzleFlash
+	13, // 16: proto.Frame.layouts:type_name -> proto.Layout
+	5,  // 17: proto.DM2Demo.BaselinesEntry.value:type_name -> proto.PackedEntity
 	2,  // 18: proto.DM2Demo.ConfigstringsEntry.value:type_name -> proto.ConfigString
-	7,  // 19: proto.DM2Demo.FramesEntry.value:type_name -> proto.Frame
-	4,  // 20: proto.Frame.EntitiesEntry.value:type_name -> proto.PackedEntity
+	8,  // 19: proto.DM2Demo.FramesEntry.value:type_name -> proto.Frame
+	5,  // 20: proto.Frame.EntitiesEntry.value:type_name -> proto.PackedEntity
 	2,  // 21: proto.Frame.ConfigstringsEntry.value:type_name -> proto.ConfigString
 	22, // [22:22] is the sub-list for method output_type
 	22, // [22:22] is the sub-list for method input_type
@@ -1992,7 +2122,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StuffText); i {
+			switch v := v.(*Setting); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2004,7 +2134,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PackedEntity); i {
+			switch v := v.(*StuffText); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2016,7 +2146,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayerMove); i {
+			switch v := v.(*PackedEntity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2028,7 +2158,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PackedPlayer); i {
+			switch v := v.(*PlayerMove); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2040,7 +2170,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Frame); i {
+			switch v := v.(*PackedPlayer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2052,7 +2182,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Print); i {
+			switch v := v.(*Frame); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2064,7 +2194,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PackedSound); i {
+			switch v := v.(*Print); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2076,7 +2206,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TemporaryEntity); i {
+			switch v := v.(*PackedSound); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2088,7 +2218,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuzzleFlash); i {
+			switch v := v.(*TemporaryEntity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2100,7 +2230,7 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Layout); i {
+			switch v := v.(*MuzzleFlash); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2112,6 +2242,18 @@ func file_server_message_proto_init() {
 			}
 		}
 		file_server_message_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Layout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CenterPrint); i {
 			case 0:
 				return &v.state
@@ -2123,6 +2265,18 @@ func file_server_message_proto_init() {
 				return nil
 			}
 		}
+		file_server_message_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Inventory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2130,7 +2284,7 @@ func file_server_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,440 @@
+// compile with:
+// protoc --go_out=. --go_opt=paths=source_relative stats_report.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v4.22.2
+// source: stats_report.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Match statistics built from a demo
+type StatsReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Map      string         `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	Frames   int32          `protobuf:"varint,2,opt,name=frames,proto3" json:"frames,omitempty"`     // frames seen
+	Duration int32          `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"` // msecs
+	Players  []*PlayerStats `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`    // highest frags first
+}
+
+func (x *StatsReport) Reset() {
+	*x = StatsReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsReport) ProtoMessage() {}
+
+func (x *StatsReport) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsReport.ProtoReflect.Descriptor instead.
+func (*StatsReport) Descriptor() ([]byte, []int) {
+	return file_stats_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatsReport) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *StatsReport) GetFrames() int32 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *StatsReport) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *StatsReport) GetPlayers() []*PlayerStats {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type PlayerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientNumber int32             `protobuf:"varint,1,opt,name=client_number,json=clientNumber,proto3" json:"client_number,omitempty"` // last known, -1 if never seen
+	Name         string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Frags        int32             `protobuf:"varint,3,opt,name=frags,proto3" json:"frags,omitempty"` // kills minus suicides
+	Deaths       int32             `protobuf:"varint,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	Suicides     int32             `protobuf:"varint,5,opt,name=suicides,proto3" json:"suicides,omitempty"`
+	WeaponKills  map[string]int32  `protobuf:"bytes,6,rep,name=weapon_kills,json=weaponKills,proto3" json:"weapon_kills,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	DamageTaken  int32             `protobuf:"varint,7,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"`                                                              // health and armor lost
+	Pickups      map[string]int32  `protobuf:"bytes,8,rep,name=pickups,proto3" json:"pickups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // item name -> times picked up
+	Accuracy     []*WeaponAccuracy `protobuf:"bytes,9,rep,name=accuracy,proto3" json:"accuracy,omitempty"`
+	TimeAlive    int32             `protobuf:"varint,10,opt,name=time_alive,json=timeAlive,proto3" json:"time_alive,omitempty"` // msecs
+}
+
+func (x *PlayerStats) Reset() {
+	*x = PlayerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_report_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStats) ProtoMessage() {}
+
+func (x *PlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_report_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
+func (*PlayerStats) Descriptor() ([]byte, []int) {
+	return file_stats_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerStats) GetClientNumber() int32 {
+	if x != nil {
+		return x.ClientNumber
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayerStats) GetFrags() int32 {
+	if x != nil {
+		return x.Frags
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetSuicides() int32 {
+	if x != nil {
+		return x.Suicides
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWeaponKills() map[string]int32 {
+	if x != nil {
+		return x.WeaponKills
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetDamageTaken() int32 {
+	if x != nil {
+		return x.DamageTaken
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetPickups() map[string]int32 {
+	if x != nil {
+		return x.Pickups
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetAccuracy() []*WeaponAccuracy {
+	if x != nil {
+		return x.Accuracy
+	}
+	return nil
+}
+
+func (x *PlayerStats) GetTimeAlive() int32 {
+	if x != nil {
+		return x.TimeAlive
+	}
+	return 0
+}
+
+// Hits are approximated, demos don't say who damaged who
+type WeaponAccuracy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weapon   string  `protobuf:"bytes,1,opt,name=weapon,proto3" json:"weapon,omitempty"`
+	Shots    int32   `protobuf:"varint,2,opt,name=shots,proto3" json:"shots,omitempty"`
+	Hits     int32   `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+	Accuracy float32 `protobuf:"fixed32,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"` // hits / shots
+}
+
+func (x *WeaponAccuracy) Reset() {
+	*x = WeaponAccuracy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_report_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeaponAccuracy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeaponAccuracy) ProtoMessage() {}
+
+func (x *WeaponAccuracy) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_report_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeaponAccuracy.ProtoReflect.Descriptor instead.
+func (*WeaponAccuracy) Descriptor() ([]byte, []int) {
+	return file_stats_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WeaponAccuracy) GetWeapon() string {
+	if x != nil {
+		return x.Weapon
+	}
+	return ""
+}
+
+func (x *WeaponAccuracy) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+func (x *WeaponAccuracy) GetHits() int32 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *WeaponAccuracy) GetAccuracy() float32 {
+	if x != nil {
+		return x.Accuracy
+	}
+	return 0
+}
+
+var File_stats_report_proto protoreflect.FileDescriptor
+
+var file_stats_report_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x01, 0x0a, 0x0b,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22,
+	0x84, 0x04, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x72, 0x61, 0x67, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x69, 0x63, 0x69, 0x64,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x75, 0x69, 0x63, 0x69, 0x64,
+	0x65, 0x73, 0x12, 0x46, 0x0a, 0x0c, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x5f, 0x6b, 0x69, 0x6c,
+	0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x57, 0x65, 0x61,
+	0x70, 0x6f, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x77,
+	0x65, 0x61, 0x70, 0x6f, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x61,
+	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x61, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x54, 0x61, 0x6b, 0x65, 0x6e, 0x12, 0x39, 0x0a,
+	0x07, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x2e, 0x50, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x75,
+	0x72, 0x61, 0x63, 0x79, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x57, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63,
+	0x79, 0x52, 0x08, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x1a, 0x3e, 0x0a, 0x10, 0x57, 0x65,
+	0x61, 0x70, 0x6f, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x50, 0x69,
+	0x63, 0x6b, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6e, 0x0a, 0x0e, 0x57, 0x65, 0x61, 0x70, 0x6f, 0x6e,
+	0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x61, 0x70,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63,
+	0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x61, 0x63,
+	0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x66, 0x6c, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x2f, 0x6c, 0x69, 0x62, 0x71, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_stats_report_proto_rawDescOnce sync.Once
+	file_stats_report_proto_rawDescData = file_stats_report_proto_rawDesc
+)
+
+func file_stats_report_proto_rawDescGZIP() []byte {
+	file_stats_report_proto_rawDescOnce.Do(func() {
+		file_stats_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_stats_report_proto_rawDescData)
+	})
+	return file_stats_report_proto_rawDescData
+}
+
+var file_stats_report_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_stats_report_proto_goTypes = []interface{}{
+	(*StatsReport)(nil),    // 0: proto.StatsReport
+	(*PlayerStats)(nil),    // 1: proto.PlayerStats
+	(*WeaponAccuracy)(nil), // 2: proto.WeaponAccuracy
+	nil,                    // 3: proto.PlayerStats.WeaponKillsEntry
+	nil,                    // 4: proto.PlayerStats.PickupsEntry
+}
+var file_stats_report_proto_depIdxs = []int32{
+	1, // 0: proto.StatsReport.players:type_name -> proto.PlayerStats
+	3, // 1: proto.PlayerStats.weapon_kills:type_name -> proto.PlayerStats.WeaponKillsEntry
+	4, // 2: proto.PlayerStats.pickups:type_name -> proto.PlayerStats.PickupsEntry
+	2, // 3: proto.PlayerStats.accuracy:type_name -> proto.WeaponAccuracy
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_stats_report_proto_init() }
+func file_stats_report_proto_init() {
+	if File_stats_report_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_stats_report_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stats_report_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stats_report_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WeaponAccuracy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_stats_report_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_stats_report_proto_goTypes,
+		DependencyIndexes: file_stats_report_proto_depIdxs,
+		MessageInfos:      file_stats_report_proto_msgTypes,
+	}.Build()
+	File_stats_report_proto = out.File
+	file_stats_report_proto_rawDesc = nil
+	file_stats_report_proto_goTypes = nil
+	file_stats_report_proto_depIdxs = nil
+}
@@ -103,7 +103,7 @@ func LoadGeoIPData(data []byte) (GeoIPList, error) {
 		out = append(out, GeoIP{
 			First:   net.ParseIP(tokens[0]),
 			Last:    net.ParseIP(tokens[1]),
-			Country: strings.ToLower(tokens[2]),
+			Country: strings.ToLower(strings.TrimSpace(tokens[2])),
 		})
 	}
 	return out, nil
@@ -128,12 +128,24 @@ func gzippedContent(data []byte) ([]byte, error) {
 // Get the country code for a particular IP address string. Output of "zz" is
 // considered country-less (think RFC1918). This is also used for any address
 // not found in the list.
+//
+// IPv4 addresses are only compared to IPv4 ranges and IPv6 to IPv6. Both
+// are 16 bytes in a net.IP, so an IPv6 range starting at "::" would
+// otherwise contain every IPv4 address.
 func (n *GeoIPList) Lookup(addr string) string {
-	if addr == "" {
-		return "zz"
+	addr = strings.Trim(addr, "[]")
+	if i := strings.IndexByte(addr, '%'); i != -1 {
+		addr = addr[:i] // link-local zone
 	}
 	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "zz"
+	}
+	v4 := ip.To4() != nil
 	for _, nc := range *n {
+		if (nc.First.To4() != nil) != v4 {
+			continue
+		}
 		c1 := bytes.Compare(nc.First, ip)
 		c2 := bytes.Compare(nc.Last, ip)
 		if (c1 == 0 || c1 == -1) && (c2 == 0 || c2 == 1) {
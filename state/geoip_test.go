@@ -1,6 +1,7 @@
 package state
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -50,3 +51,39 @@ func TestLookup(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupFamilies(t *testing.T) {
+	data := strings.Join([]string{
+		"1.0.0.0,1.0.0.255,AU",
+		"169.197.128.0,169.197.143.255,US\r",
+		"::,1fff:ffff:ffff:ffff:ffff:ffff:ffff:ffff,XX", // contains every IPv4 address as a 16 byte net.IP
+		"2001:db8::,2001:db8:ffff:ffff:ffff:ffff:ffff:ffff,DE",
+		"2a01:4f8::,2a01:4f8:ffff:ffff:ffff:ffff:ffff:ffff,FI",
+	}, "\n")
+	geoips, err := LoadGeoIPData([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		addr string
+		want string
+	}{
+		{name: "ipv4", addr: "1.0.0.5", want: "au"},
+		{name: "ipv4 crlf", addr: "169.197.131.131", want: "us"},
+		{name: "ipv4 not in v6 range", addr: "10.3.5.6", want: "zz"},
+		{name: "ipv6", addr: "2001:db8::1", want: "de"},
+		{name: "ipv6 brackets", addr: "[2a01:4f8::1]", want: "fi"},
+		{name: "ipv6 zone", addr: "2001:db8::1%eth0", want: "de"},
+		{name: "ipv6 unknown", addr: "2600::1", want: "zz"},
+		{name: "garbage", addr: "example.com", want: "zz"},
+		{name: "empty", addr: "", want: "zz"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := geoips.Lookup(tc.addr); got != tc.want {
+				t.Errorf("Lookup(%q) = %q, want %q", tc.addr, got, tc.want)
+			}
+		})
+	}
+}
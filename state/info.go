@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/packetflinger/libq2/message"
 )
@@ -18,10 +19,15 @@ type ServerInfo struct {
 }
 
 func (s *Server) FetchInfo() (ServerInfo, error) {
+	return s.FetchInfoTimeout(time.Second)
+}
+
+// Same as FetchInfo() but waiting up to timeout for the server to answer
+func (s *Server) FetchInfoTimeout(timeout time.Duration) (ServerInfo, error) {
 	p := message.ConnectionlessPacket{
 		Data: "status",
 	}
-	out, err := p.Send(s.Address, s.Port)
+	out, err := p.SendTimeout(s.Address, s.Port, timeout)
 	if err != nil {
 		return ServerInfo{}, err
 	}
@@ -0,0 +1,437 @@
+// Package stats builds per-player match statistics from parsed demos. Both
+// regular (.dm2) and multi-view (.mvd2) demos are supported, they're both
+// flattened into the same stream of events (see demo.DM2Events) before being
+// collected.
+//
+// Demos don't explicitly record who damaged who, so some of the numbers are
+// approximations:
+//
+//   - Frags, deaths and suicides come from the obituary prints.
+//   - Damage taken is health and armor lost between frames. A regular demo
+//     only has the playerstate of the player who recorded it, so this (and
+//     time alive and pickups) is only available for that one player. Multi-
+//     view demos have every player's state.
+//   - Shots come from muzzle flashes. Hits for bullet weapons are counted from
+//     blood temp entities when only one player fired a bullet weapon that
+//     frame. Hits for other weapons are credited to the last player to fire
+//     one within a few frames of a player taking damage.
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	"github.com/packetflinger/libq2/player"
+	pb "github.com/packetflinger/libq2/proto"
+	"github.com/packetflinger/libq2/util"
+)
+
+const (
+	ShotgunPellets  = 12 // deathmatch default
+	SShotgunPellets = 20
+	MegaHealthMax   = 100 // health over this rots 1 point per second
+)
+
+// A weapon as seen from its muzzle flash
+type weapon struct {
+	name   string
+	shots  int32 // per flash, pellets for shotguns
+	bullet bool  // hits show as blood
+	window int32 // frames a projectile might take to hit something
+}
+
+var weapons = map[uint32]weapon{
+	message.MzBlaster:      {name: "blaster", shots: 1, window: 10},
+	message.MzMachinegun:   {name: "machinegun", shots: 1, bullet: true},
+	message.MzShotgun:      {name: "shotgun", shots: ShotgunPellets, bullet: true},
+	message.MzChaingun1:    {name: "chaingun", shots: 1, bullet: true},
+	message.MzChaingun2:    {name: "chaingun", shots: 2, bullet: true},
+	message.MzChaingun3:    {name: "chaingun", shots: 3, bullet: true},
+	message.MzRailgun:      {name: "railgun", shots: 1, window: 1},
+	message.MzRocket:       {name: "rocket", shots: 1, window: 10},
+	message.MzGrenade:      {name: "grenade", shots: 1, window: 25},
+	message.MzBFG:          {name: "BFG", shots: 1, window: 20},
+	message.MzSShotgun:     {name: "super shotgun", shots: SShotgunPellets, bullet: true},
+	message.MzHyperblaster: {name: "hyperblaster", shots: 1, window: 10},
+}
+
+// A recent non-bullet shot that might still hit something
+type shot struct {
+	client int32
+	frame  int32
+	weapon weapon
+	hit    map[int32]bool // victims already credited
+}
+
+// Collector accumulates stats from demo events. Feed it every event in order
+// with Add() and then build the report.
+type Collector struct {
+	players     map[string]*pb.PlayerStats // keyed by name
+	accuracy    map[string]map[string]*pb.WeaponAccuracy
+	names       map[int32]string // client number -> name
+	configs     map[int32]string
+	itemsCS     int32 // configstring offsets
+	skinsCS     int32
+	recorder    int32 // dm2 client number, -1 for mvd
+	dummy       int32 // mvd observer, not a real player
+	states      map[int32]*pb.PackedPlayer
+	previous    map[int32]*pb.PackedPlayer // states from the last frame
+	flashes     []*pb.MuzzleFlash          // pending for the current frame
+	bloods      int32
+	shots       []*shot
+	mapname     string
+	frame       int32
+	frames      int32
+	firstTime   int32
+	lastTime    int32
+	pendingTime int32
+	pending     bool // a frame is waiting to be processed
+}
+
+// Create an empty collector
+func NewCollector() *Collector {
+	return &Collector{
+		players:  make(map[string]*pb.PlayerStats),
+		accuracy: make(map[string]map[string]*pb.WeaponAccuracy),
+		names:    make(map[int32]string),
+		configs:  make(map[int32]string),
+		itemsCS:  message.CSItems,
+		skinsCS:  message.CSPlayerSkins,
+		recorder: -1,
+		dummy:    -1,
+		states:   make(map[int32]*pb.PackedPlayer),
+		previous: make(map[int32]*pb.PackedPlayer),
+		lastTime: -1,
+	}
+}
+
+// Build a report for a regular demo
+func FromDM2(dm2 *pb.DM2Demo) *pb.StatsReport {
+	return FromEvents(demo.DM2Events(dm2, demo.DefaultFrameRate))
+}
+
+// Build a report for a multi-view demo
+func FromMVD2(mvd *pb.MvdDemo) *pb.StatsReport {
+	return FromEvents(demo.MVD2Events(mvd, demo.DefaultFrameRate))
+}
+
+// Build a report from a stream of demo events
+func FromEvents(events []*pb.DemoEvent) *pb.StatsReport {
+	c := NewCollector()
+	for _, ev := range events {
+		c.Add(ev)
+	}
+	return c.Report()
+}
+
+// Process the next event from a demo.
+func (c *Collector) Add(ev *pb.DemoEvent) {
+	if ev.GetFrame() != c.frame {
+		c.endFrame()
+		c.frame = ev.GetFrame()
+	}
+	if sd := ev.GetServerdata(); sd != nil {
+		c.recorder = int32(sd.GetClientNumber())
+		c.mapname = sd.GetMapName()
+	}
+	if sd := ev.GetMvdServerdata(); sd != nil {
+		c.recorder = -1
+		c.dummy = sd.GetDummyClient()
+		if remap := sd.GetRemap(); remap != nil {
+			c.itemsCS = remap.GetItems()
+			c.skinsCS = remap.GetPlayerSkins()
+		}
+		c.states = make(map[int32]*pb.PackedPlayer)
+		c.previous = make(map[int32]*pb.PackedPlayer)
+	}
+	if cs := ev.GetConfigstring(); cs != nil {
+		c.configString(cs)
+	}
+	if pr := ev.GetPrint(); pr != nil {
+		c.print(pr)
+	}
+	if fr := ev.GetDm2Frame(); fr != nil {
+		if fr.GetPlayerState() != nil && c.recorder >= 0 {
+			c.states[c.recorder] = fr.GetPlayerState()
+		}
+		c.markFrame(ev)
+	}
+	if fr := ev.GetMvdFrame(); fr != nil {
+		for num, ps := range fr.GetPlayers() {
+			c.states[num] = ps
+		}
+		for _, num := range fr.GetRemovedPlayers() {
+			delete(c.states, num)
+		}
+		c.markFrame(ev)
+	}
+	if mf := ev.GetMuzzleFlash(); mf != nil {
+		c.flashes = append(c.flashes, mf)
+	}
+	if te := ev.GetTempEntity(); te != nil {
+		c.tempEntity(te)
+	}
+	if mc := ev.GetMulticast(); mc != nil {
+		c.multicast(mc)
+	}
+}
+
+// The frame's events are all known once the next frame starts
+func (c *Collector) markFrame(ev *pb.DemoEvent) {
+	c.pending = true
+	c.pendingTime = ev.GetServerTime()
+}
+
+func (c *Collector) configString(cs *pb.ConfigString) {
+	idx := int32(cs.GetIndex())
+	c.configs[idx] = cs.GetData()
+	if cs.GetIndex() == message.CSMapname && c.mapname == "" {
+		c.mapname = cs.GetData()
+	}
+	num := idx - c.skinsCS
+	if num < 0 || num >= demo.MaxClients {
+		return
+	}
+	name, _, _ := strings.Cut(cs.GetData(), "\\")
+	if name == "" {
+		delete(c.names, num)
+		return
+	}
+	c.names[num] = name
+	c.player(name).ClientNumber = num
+}
+
+// Obituaries are all the death info there is
+func (c *Collector) print(pr *pb.Print) {
+	if pr.GetLevel() != message.PrintLevelObit {
+		return
+	}
+	death, err := player.CalculateDeath(strings.TrimSpace(pr.GetData()))
+	if err != nil {
+		return
+	}
+	victim := c.player(death.Victim)
+	victim.Deaths++
+	if death.Solo {
+		victim.Suicides++
+		victim.Frags--
+		return
+	}
+	murderer := c.player(death.Murderer)
+	murderer.Frags++
+	if murderer.WeaponKills == nil {
+		murderer.WeaponKills = make(map[string]int32)
+	}
+	murderer.WeaponKills[player.MODToString(death.Means)]++
+}
+
+func (c *Collector) tempEntity(te *pb.TemporaryEntity) {
+	switch te.GetType() {
+	case message.TentBlood, message.TentMoreBlood:
+		c.bloods++
+	}
+}
+
+// Multi-view demos send muzzle flashes and temp entities as multicasts,
+// regular server messages that are passed through.
+func (c *Collector) multicast(mc *pb.MvdMulticast) {
+	c.flashes = append(c.flashes, mc.GetMuzzleFlashes()...)
+	for _, te := range mc.GetTempEnts() {
+		c.tempEntity(te)
+	}
+}
+
+// Settle everything that happened in the last frame.
+func (c *Collector) endFrame() {
+	if !c.pending {
+		return
+	}
+	c.pending = false
+	c.frames++
+	elapsed := int32(0)
+	if c.lastTime < 0 {
+		c.firstTime = c.pendingTime
+	} else {
+		elapsed = c.pendingTime - c.lastTime
+	}
+	c.lastTime = c.pendingTime
+
+	c.fire()
+	var damaged []int32
+	for _, num := range util.SortedKeys(c.states) {
+		if num == c.dummy {
+			continue
+		}
+		name, ok := c.names[num]
+		if !ok {
+			continue
+		}
+		ps := c.states[num]
+		stats := c.player(name)
+		prev, seen := c.previous[num]
+		if seen && alive(prev) {
+			stats.TimeAlive += elapsed
+			if dmg := damage(prev, ps); dmg > 0 {
+				stats.DamageTaken += dmg
+				damaged = append(damaged, num)
+			}
+		}
+		pickup := ps.GetStats()[message.StatPickupString]
+		if pickup != 0 && (!seen || prev.GetStats()[message.StatPickupString] != pickup) {
+			if item := c.item(pickup); item != "" {
+				if stats.Pickups == nil {
+					stats.Pickups = make(map[string]int32)
+				}
+				stats.Pickups[item]++
+			}
+		}
+	}
+	c.hit(damaged)
+	c.previous = make(map[int32]*pb.PackedPlayer)
+	for k, v := range c.states {
+		c.previous[k] = v
+	}
+}
+
+// Count the shots from this frame's muzzle flashes and credit bullet hits.
+func (c *Collector) fire() {
+	bullets := make(map[int32]weapon)
+	for _, mf := range c.flashes {
+		w, ok := weapons[mf.GetWeapon()&^message.MzSilenced]
+		if !ok {
+			continue
+		}
+		num := int32(mf.GetEntity()) - 1
+		name, ok := c.names[num]
+		if !ok {
+			continue
+		}
+		c.weapon(name, w.name).Shots += w.shots
+		if w.bullet {
+			bullets[num] = w
+			continue
+		}
+		c.shots = append(c.shots, &shot{
+			client: num,
+			frame:  c.frame,
+			weapon: w,
+			hit:    make(map[int32]bool),
+		})
+	}
+	if len(bullets) == 1 && c.bloods > 0 {
+		for num, w := range bullets {
+			c.weapon(c.names[num], w.name).Hits += c.bloods
+		}
+	}
+	c.flashes = nil
+	c.bloods = 0
+}
+
+// Credit damage taken to the most recent shot that could have caused it.
+func (c *Collector) hit(victims []int32) {
+	var live []*shot
+	for _, s := range c.shots {
+		if c.frame-s.frame <= s.weapon.window {
+			live = append(live, s)
+		}
+	}
+	c.shots = live
+	for _, v := range victims {
+		for i := len(live) - 1; i >= 0; i-- {
+			s := live[i]
+			if s.client == v || s.hit[v] {
+				continue
+			}
+			s.hit[v] = true
+			c.weapon(c.names[s.client], s.weapon.name).Hits++
+			break
+		}
+	}
+}
+
+// Get the stats for a player, creating them if needed
+func (c *Collector) player(name string) *pb.PlayerStats {
+	ps, ok := c.players[name]
+	if !ok {
+		ps = &pb.PlayerStats{Name: name, ClientNumber: -1}
+		c.players[name] = ps
+	}
+	return ps
+}
+
+func (c *Collector) weapon(name, weapon string) *pb.WeaponAccuracy {
+	if _, ok := c.accuracy[name]; !ok {
+		c.accuracy[name] = make(map[string]*pb.WeaponAccuracy)
+	}
+	acc, ok := c.accuracy[name][weapon]
+	if !ok {
+		acc = &pb.WeaponAccuracy{Weapon: weapon}
+		c.accuracy[name][weapon] = acc
+		c.player(name)
+	}
+	return acc
+}
+
+// The item name for a pickup string stat. The game uses the original
+// configstring numbering, which might have been remapped.
+func (c *Collector) item(index int32) string {
+	if name, ok := c.configs[index]; ok {
+		return name
+	}
+	return c.configs[index-message.CSItems+c.itemsCS]
+}
+
+// Generate the report from everything collected so far.
+func (c *Collector) Report() *pb.StatsReport {
+	c.endFrame()
+	report := &pb.StatsReport{
+		Map:    c.mapname,
+		Frames: c.frames,
+	}
+	if c.lastTime >= 0 {
+		report.Duration = c.lastTime - c.firstTime
+	}
+	for name, ps := range c.players {
+		ps.Accuracy = nil
+		for _, w := range util.SortedKeys(c.accuracy[name]) {
+			acc := c.accuracy[name][w]
+			if acc.GetShots() > 0 {
+				acc.Accuracy = float32(acc.GetHits()) / float32(acc.GetShots())
+			}
+			ps.Accuracy = append(ps.Accuracy, acc)
+		}
+		report.Players = append(report.Players, ps)
+	}
+	slices.SortFunc(report.Players, func(a, b *pb.PlayerStats) int {
+		if n := cmp.Compare(b.GetFrags(), a.GetFrags()); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	return report
+}
+
+// Actually in the game, not dead or spectating
+func alive(ps *pb.PackedPlayer) bool {
+	return ps.GetMovestate().GetType() == message.PMNormal && ps.GetStats()[message.StatHealth] > 0
+}
+
+// Health and armor lost from one frame to the next
+func damage(from, to *pb.PackedPlayer) int32 {
+	dmg := int32(0)
+	hfrom := from.GetStats()[message.StatHealth]
+	hto := to.GetStats()[message.StatHealth]
+	// megahealth rotting isn't damage
+	if hto < hfrom && !(hfrom > MegaHealthMax && hfrom-hto == 1) {
+		dmg += hfrom - hto
+	}
+	afrom := from.GetStats()[message.StatArmor]
+	ato := to.GetStats()[message.StatArmor]
+	if ato < afrom {
+		dmg += afrom - ato
+	}
+	return dmg
+}
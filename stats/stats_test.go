@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/packetflinger/libq2/demo"
+	"github.com/packetflinger/libq2/message"
+	pb "github.com/packetflinger/libq2/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// Just the frag counts for each player
+type score struct {
+	frags, deaths, suicides int32
+}
+
+func scores(report *pb.StatsReport) map[string]score {
+	out := make(map[string]score)
+	for _, p := range report.GetPlayers() {
+		if p.GetFrags() == 0 && p.GetDeaths() == 0 {
+			continue
+		}
+		out[p.GetName()] = score{p.GetFrags(), p.GetDeaths(), p.GetSuicides()}
+	}
+	return out
+}
+
+func TestFromDM2(t *testing.T) {
+	tests := []struct {
+		name    string
+		demo    string
+		mapname string
+		want    map[string]score
+	}{
+		{
+			name:    "no frags",
+			demo:    "../testdata/test.dm2",
+			mapname: "The Edge",
+			want:    map[string]score{},
+		},
+		{
+			name:    "duel",
+			demo:    "../testdata/testduel.dm2",
+			mapname: "The Chastity Belt Duel  -  by JaLisK0",
+			want: map[string]score{
+				"claire": {4, 2, 0},
+				"shloo":  {2, 4, 0},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := os.ReadFile(tc.demo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parser := demo.NewDM2Parser()
+			if err := parser.Unmarshal(content); err != nil {
+				t.Fatal(err)
+			}
+			report := FromDM2(parser.GetTextProto())
+			if report.GetMap() != tc.mapname {
+				t.Errorf("map = %q, want %q", report.GetMap(), tc.mapname)
+			}
+			if diff := cmp.Diff(tc.want, scores(report), cmp.AllowUnexported(score{})); diff != "" {
+				t.Errorf("scores mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromMVD2(t *testing.T) {
+	parser, err := demo.NewMVD2Parser("../testdata/test.mvd2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demos, err := parser.Unmarshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := FromMVD2(demos[0])
+	if len(report.GetPlayers()) == 0 {
+		t.Fatal("no players in report")
+	}
+	top := report.GetPlayers()[0]
+	if top.GetName() != "claire" || top.GetFrags() != 17 || top.GetDeaths() != 8 {
+		t.Errorf("top player = %s %d/%d, want claire 17/8", top.GetName(), top.GetFrags(), top.GetDeaths())
+	}
+	// every player's state is in a multi-view demo
+	for _, p := range report.GetPlayers() {
+		if p.GetDeaths() > 0 && (p.GetTimeAlive() == 0 || p.GetDamageTaken() == 0) {
+			t.Errorf("%s has no time alive or damage taken", p.GetName())
+		}
+	}
+}
+
+// A player in the given state
+func state(health, armor, pickup int32) *pb.PackedPlayer {
+	return &pb.PackedPlayer{
+		Movestate: &pb.PlayerMove{Type: message.PMNormal},
+		Stats: map[uint32]int32{
+			message.StatHealth:       health,
+			message.StatArmor:        armor,
+			message.StatPickupString: pickup,
+		},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	skin := func(num int32, name string) *pb.DemoEvent {
+		return &pb.DemoEvent{Event: &pb.DemoEvent_Configstring{Configstring: &pb.ConfigString{
+			Index: uint32(message.CSPlayerSkins + num),
+			Data:  name + "\\male/grunt",
+		}}}
+	}
+	frame := func(num int32, players map[int32]*pb.PackedPlayer) *pb.DemoEvent {
+		return &pb.DemoEvent{Frame: num, ServerTime: num * 100, Event: &pb.DemoEvent_MvdFrame{MvdFrame: &pb.MvdFrame{Players: players}}}
+	}
+	flash := func(num int32, client, weapon uint32) *pb.DemoEvent {
+		return &pb.DemoEvent{Frame: num, Event: &pb.DemoEvent_MuzzleFlash{MuzzleFlash: &pb.MuzzleFlash{Entity: client + 1, Weapon: weapon}}}
+	}
+	events := []*pb.DemoEvent{
+		{Event: &pb.DemoEvent_MvdServerdata{MvdServerdata: &pb.MvdServerData{DummyClient: 9}}},
+		skin(0, "alice"),
+		skin(1, "bob"),
+		{Event: &pb.DemoEvent_Configstring{Configstring: &pb.ConfigString{Index: message.CSItems + 3, Data: "Rockets"}}},
+		frame(1, map[int32]*pb.PackedPlayer{0: state(100, 0, 0), 1: state(100, 50, 0)}),
+		flash(1, 0, message.MzRocket),
+		frame(2, map[int32]*pb.PackedPlayer{0: state(100, 0, message.CSItems+3)}),
+		flash(2, 1, message.MzMachinegun),
+		{Frame: 2, Event: &pb.DemoEvent_TempEntity{TempEntity: &pb.TemporaryEntity{Type: message.TentBlood}}},
+		frame(3, map[int32]*pb.PackedPlayer{0: state(92, 0, message.CSItems+3), 1: state(80, 20, 0)}),
+		{Frame: 3, Event: &pb.DemoEvent_Print{Print: &pb.Print{Level: message.PrintLevelObit, Data: "bob ate alice's rocket\n"}}},
+		frame(4, map[int32]*pb.PackedPlayer{1: state(-20, 0, 0)}),
+		frame(5, nil),
+	}
+	want := &pb.StatsReport{
+		Frames:   5,
+		Duration: 400,
+		Players: []*pb.PlayerStats{
+			{
+				ClientNumber: 0,
+				Name:         "alice",
+				Frags:        1,
+				WeaponKills:  map[string]int32{"rocket": 1},
+				DamageTaken:  8,
+				Pickups:      map[string]int32{"Rockets": 1},
+				Accuracy:     []*pb.WeaponAccuracy{{Weapon: "rocket", Shots: 1, Hits: 1, Accuracy: 1}},
+				TimeAlive:    400,
+			},
+			{
+				ClientNumber: 1,
+				Name:         "bob",
+				Deaths:       1,
+				DamageTaken:  170,
+				Accuracy:     []*pb.WeaponAccuracy{{Weapon: "machinegun", Shots: 1, Hits: 1, Accuracy: 1}},
+				TimeAlive:    300,
+			},
+		},
+	}
+	got := FromEvents(events)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("FromEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
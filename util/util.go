@@ -2,9 +2,11 @@ package util
 
 import (
 	"bufio"
+	"cmp"
 	"encoding/binary"
 	"net"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -51,6 +53,16 @@ func SplitLines(str string) []string {
 	return lines
 }
 
+// Map keys in ascending order, for doing things in a predictable order
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 // Make sure val is in between lower and upper
 func Clamp(val int, lower int, upper int) int {
 	if val < lower {
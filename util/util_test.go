@@ -1,6 +1,7 @@
 package util
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -74,3 +75,23 @@ func TestConvertLowChars(t *testing.T) {
 		t.Error("ConvertLowChars: got", got, "want", want)
 	}
 }
+
+func TestSortedKeys(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   map[int32]string
+		want []int32
+	}{
+		{desc: "nil", in: nil, want: []int32{}},
+		{desc: "one", in: map[int32]string{5: "e"}, want: []int32{5}},
+		{desc: "unordered", in: map[int32]string{3: "c", -1: "z", 10: "j", 0: "a"}, want: []int32{-1, 0, 3, 10}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := SortedKeys(tc.in)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("SortedKeys() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}